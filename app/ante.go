@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+// NewMinGasPriceAnteHandler wraps an AnteHandler and rejects txs whose fee
+// per unit of gas is below the node's minimum gas prices. The check only
+// runs in CheckTx, so blocks stay deterministic regardless of node settings.
+func NewMinGasPriceAnteHandler(ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		if ctx.IsCheckTx() && !simulate {
+			stdTx, ok := tx.(auth.StdTx)
+			if ok {
+				err := checkMinGasPrices(stdTx.Fee, ctx.MinGasPrices())
+				if err != nil {
+					return ctx, err.Result(), true
+				}
+			}
+		}
+		return ah(ctx, tx, simulate)
+	}
+}
+
+// checkMinGasPrices succeeds if the fee covers the minimum gas price of any
+// of the given denominations
+func checkMinGasPrices(fee auth.StdFee, minGasPrices sdk.DecCoins) sdk.Error {
+	if minGasPrices.IsZero() {
+		return nil
+	}
+
+	gas := sdk.NewDec(int64(fee.Gas))
+	for _, gp := range minGasPrices {
+		required := gp.Amount.Mul(gas).Ceil().RoundInt()
+		if fee.Amount.AmountOf(gp.Denom).GTE(required) {
+			return nil
+		}
+	}
+
+	return sdk.ErrInsufficientFee(fmt.Sprintf(
+		"insufficient fee %s for %d gas; minimum gas prices: %s", fee.Amount, fee.Gas, minGasPrices))
+}
+
+// NewFeeDenomAnteHandler wraps an AnteHandler and rejects txs paying fees in
+// denoms missing from the FeeDenoms bank param. An empty param accepts any
+// denom.
+func NewFeeDenomAnteHandler(bk bank.Keeper, ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(auth.StdTx)
+		if ok {
+			err := checkFeeDenoms(stdTx.Fee.Amount, bk.GetParams(ctx).FeeDenoms)
+			if err != nil {
+				return ctx, err.Result(), true
+			}
+		}
+		return ah(ctx, tx, simulate)
+	}
+}
+
+// checkFeeDenoms succeeds if every coin of the fee is in one of the given
+// denominations
+func checkFeeDenoms(fee sdk.Coins, feeDenoms []string) sdk.Error {
+	if len(feeDenoms) == 0 {
+		return nil
+	}
+
+	for _, coin := range fee {
+		accepted := false
+		for _, denom := range feeDenoms {
+			if coin.Denom == denom {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return sdk.ErrInvalidCoins(fmt.Sprintf(
+				"fees cannot be paid in %s; accepted fee denoms: %s", coin.Denom, strings.Join(feeDenoms, ", ")))
+		}
+	}
+	return nil
+}
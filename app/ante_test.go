@@ -0,0 +1,158 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/blockgas"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool"
+)
+
+func passAnteHandler(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+	return ctx, sdk.Result{}, false
+}
+
+func newFeeTx(fee sdk.Coins, gas uint64) auth.StdTx {
+	return auth.NewStdTx(nil, auth.NewStdFee(gas, fee), nil, "")
+}
+
+func TestMinGasPriceAnteHandler(t *testing.T) {
+	ah := NewMinGasPriceAnteHandler(passAnteHandler)
+	minGasPrices := sdk.DecCoins{sdk.NewDecCoinFromDec("steak", sdk.NewDecWithPrec(1, 2))} // 0.01steak
+	ctx := sdk.NewContext(nil, abci.Header{}, true, log.NewNopLogger()).WithMinGasPrices(minGasPrices)
+
+	below := newFeeTx(sdk.Coins{sdk.NewInt64Coin("steak", 99)}, 10000)
+	above := newFeeTx(sdk.Coins{sdk.NewInt64Coin("steak", 100)}, 10000)
+	otherDenom := newFeeTx(sdk.Coins{sdk.NewInt64Coin("foocoin", 1000)}, 10000)
+
+	// CheckTx
+	_, res, abort := ah(ctx, below, false)
+	require.True(t, abort)
+	require.Equal(t, sdk.CodeInsufficientFee, res.Code)
+
+	_, res, abort = ah(ctx, otherDenom, false)
+	require.True(t, abort)
+	require.Equal(t, sdk.CodeInsufficientFee, res.Code)
+
+	_, res, abort = ah(ctx, above, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	// DeliverTx
+	ctx = ctx.WithIsCheckTx(false)
+
+	_, res, abort = ah(ctx, below, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	_, res, abort = ah(ctx, above, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+}
+
+func TestFeeDenomAnteHandler(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+	ah := NewFeeDenomAnteHandler(bapp.bankKeeper, passAnteHandler)
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+
+	steak := newFeeTx(sdk.Coins{sdk.NewInt64Coin("steak", 10)}, 10000)
+	mixed := newFeeTx(sdk.Coins{sdk.NewInt64Coin("foocoin", 10), sdk.NewInt64Coin("steak", 10)}, 10000)
+
+	// any denom is accepted by default
+	_, res, abort := ah(ctx, mixed, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	bankParams := bapp.bankKeeper.GetParams(ctx)
+	bankParams.FeeDenoms = []string{"steak"}
+	bapp.bankKeeper.SetParams(ctx, bankParams)
+
+	_, res, abort = ah(ctx, steak, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	_, res, abort = ah(ctx, mixed, false)
+	require.True(t, abort)
+	require.Equal(t, sdk.CodeInvalidCoins, res.Code)
+
+	// txs without fees are left to the other ante handlers
+	_, res, abort = ah(ctx, newFeeTx(sdk.Coins{}, 10000), false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+}
+
+func TestSignatureKeyTypes(t *testing.T) {
+	for _, priv := range []crypto.PrivKey{secp256k1.GenPrivKey(), ed25519.GenPrivKey()} {
+		bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+		require.Nil(t, err)
+
+		addr := sdk.AccAddress(priv.PubKey().Address())
+		recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+		coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+		require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{
+			Address: addr,
+			Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+		}))
+
+		// the first tx stores the pubkey in the account, the second one is
+		// verified against the stored pubkey
+		for height := int64(1); height <= 2; height++ {
+			txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, nil), sdkbank.NewMsgSend(
+				[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+				[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+			))
+			bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+			res := bapp.DeliverTx(txBytes)
+			require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+			bapp.EndBlock(abci.RequestEndBlock{Height: height})
+			bapp.Commit()
+		}
+
+		ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+		acc := bapp.accountKeeper.GetAccount(ctx, addr)
+		require.IsType(t, &types.AppAccount{}, acc)
+		require.Equal(t, priv.PubKey(), acc.GetPubKey())
+		require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 80)}, acc.GetCoins())
+	}
+}
+
+func TestMsgGasCost(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+	handler := bapp.blockGasKeeper.NewGasCostHandler(cool.NewHandler(bapp.coolKeeper))
+	msg := cool.NewMsgSetTrend(sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()), "icecold")
+
+	gasUsed := func() uint64 {
+		ctx := bapp.BaseApp.NewContext(true, abci.Header{}).WithGasMeter(sdk.NewInfiniteGasMeter())
+		handler(ctx, msg)
+		return ctx.GasMeter().GasConsumed()
+	}
+	base := gasUsed()
+
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	blockGasParams := bapp.blockGasKeeper.GetParams(ctx)
+	blockGasParams.MsgGasCosts = []blockgas.MsgGasCost{{Route: "cool", Gas: 50000}}
+	blockGasParams.DefaultMsgGas = 100
+	bapp.blockGasKeeper.SetParams(ctx, blockGasParams)
+
+	// the cost of the route is charged on top of the gas the handler uses
+	require.Equal(t, base+50000, gasUsed())
+
+	// other routes pay the default
+	require.Equal(t, uint64(100), bapp.blockGasKeeper.GetMsgGasCost(ctx, "bank"))
+}
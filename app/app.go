@@ -2,28 +2,55 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
-	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
-	"github.com/cosmos/cosmos-sdk/x/bank"
-	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/account"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/admin"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/blockgas"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/distribution"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/ibc"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
 )
 
 const (
 	appName = "XpxCosmos"
 )
 
+// store pruning strategies
+const (
+	// keep recent versions and periodic snapshots
+	PruningSyncable = "syncable"
+	// keep every version, for archive nodes
+	PruningNothing = "nothing"
+	// keep only the latest version
+	PruningEverything = "everything"
+
+	DefaultPruning = PruningSyncable
+)
+
 // default home directories for expected binaries
 var (
 	DefaultCLIHome  = os.ExpandEnv("$HOME/.xpx-cosmos-cli")
@@ -35,12 +62,24 @@ type DemocoinApp struct {
 	*bam.BaseApp
 	cdc *codec.Codec
 
+	// database and multistore of the committed state, and the pruning
+	// strategy deciding which past heights can be loaded
+	db      dbm.DB
+	cms     sdk.CommitMultiStore
+	pruning string
+
 	// keys to access the substores
 	capKeyMainStore    *sdk.KVStoreKey
 	capKeyAccountStore *sdk.KVStoreKey
+	capKeyFeeStore     *sdk.KVStoreKey
+	capKeyBankStore    *sdk.KVStoreKey
 	capKeyPowStore     *sdk.KVStoreKey
 	capKeyIBCStore     *sdk.KVStoreKey
 	capKeyStakingStore *sdk.KVStoreKey
+	capKeyDistrStore   *sdk.KVStoreKey
+	capKeyFeeGrant     *sdk.KVStoreKey
+	tkeyBlockGas       *sdk.TransientStoreKey
+	tkeyIBC            *sdk.TransientStoreKey
 	keyParams          *sdk.KVStoreKey
 	tkeyParams         *sdk.TransientStoreKey
 
@@ -48,107 +87,283 @@ type DemocoinApp struct {
 	paramsKeeper        params.Keeper
 	feeCollectionKeeper auth.FeeCollectionKeeper
 	bankKeeper          bank.Keeper
+	coolKeeper          cool.Keeper
+	powKeeper           pow.Keeper
 	ibcMapper           ibc.Mapper
-	stakingKeeper         simplestaking.Keeper
+	stakingKeeper       simplestaking.Keeper
+	adminKeeper         admin.Keeper
+	distrKeeper         distribution.Keeper
+	nameKeeper          account.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	blockGasKeeper      blockgas.Keeper
 
 	// Manage getting and setting accounts
-	accountKeeper auth.AccountKeeper
+	accountKeeper account.HookedAccountKeeper
+
+	// assert invariants every invCheckPeriod blocks, never if zero
+	invCheckPeriod uint
+
+	// halt on a broken invariant, only log it if false
+	assertInvariants bool
+
+	// counters of the handled messages, flushed to db on Flush and Close
+	metrics *MsgMetrics
+
+	closeOnce sync.Once
+
+	// number of sub-queries a batch query may hold
+	maxBatchQueries int
+
+	// time an aggregated query may run, no limit if zero
+	queryTimeout time.Duration
 }
 
-func NewDemocoinApp(logger log.Logger, db dbm.DB) *DemocoinApp {
+// NewDemocoinApp returns the app with the default store pruning
+func NewDemocoinApp(logger log.Logger, db dbm.DB, invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp)) (*DemocoinApp, error) {
+	return NewDemocoinAppWithOptions(logger, db, invCheckPeriod, DefaultPruning, baseAppOptions...)
+}
+
+// NewDemocoinAppWithOptions returns the app pruning its store with the given
+// strategy, one of PruningSyncable, PruningNothing or PruningEverything
+func NewDemocoinAppWithOptions(logger log.Logger, db dbm.DB, invCheckPeriod uint, pruning string,
+	baseAppOptions ...func(*bam.BaseApp)) (*DemocoinApp, error) {
+
+	switch pruning {
+	case PruningSyncable, PruningNothing, PruningEverything:
+	default:
+		return nil, fmt.Errorf("invalid pruning strategy: %s", pruning)
+	}
+	// the multistore is set first so the other options apply to it
+	cms := store.NewCommitMultiStore(db)
+	baseAppOptions = append([]func(*bam.BaseApp){
+		func(bapp *bam.BaseApp) { bapp.SetCMS(cms) },
+		bam.SetPruning(pruning),
+	}, baseAppOptions...)
 
 	// Create app-level codec for txs and accounts.
-	var cdc = MakeCodec()
+	var cdc = MakeDefaultCodec()
 
 	// Create your application object.
 	var app = &DemocoinApp{
-		BaseApp:            bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc)),
+		BaseApp:            bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc), baseAppOptions...),
 		cdc:                cdc,
+		db:                 db,
+		cms:                cms,
+		pruning:            pruning,
 		capKeyMainStore:    sdk.NewKVStoreKey(bam.MainStoreKey),
 		capKeyAccountStore: sdk.NewKVStoreKey(auth.StoreKey),
+		capKeyFeeStore:     sdk.NewKVStoreKey(auth.FeeStoreKey),
+		capKeyBankStore:    sdk.NewKVStoreKey("bank"),
 		capKeyPowStore:     sdk.NewKVStoreKey("pow"),
 		capKeyIBCStore:     sdk.NewKVStoreKey("ibc"),
 		capKeyStakingStore: sdk.NewKVStoreKey(staking.StoreKey),
+		capKeyDistrStore:   sdk.NewKVStoreKey("distribution"),
+		capKeyFeeGrant:     sdk.NewKVStoreKey("feegrant"),
+		tkeyBlockGas:       sdk.NewTransientStoreKey("transient_blockgas"),
+		tkeyIBC:            sdk.NewTransientStoreKey("transient_ibc"),
 		keyParams:          sdk.NewKVStoreKey("params"),
 		tkeyParams:         sdk.NewTransientStoreKey("transient_params"),
+		invCheckPeriod:     invCheckPeriod,
+		assertInvariants:   true,
+		metrics:            NewMsgMetrics(),
+		maxBatchQueries:    DefaultMaxBatchQueries,
+		queryTimeout:       DefaultQueryTimeout,
 	}
 
 	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams, app.tkeyParams)
 
-	// Define the accountKeeper.
-	app.accountKeeper = auth.NewAccountKeeper(
+	// Define the accountKeeper. Modules reacting to new accounts add their
+	// hooks to it.
+	app.accountKeeper = account.NewHookedAccountKeeper(auth.NewAccountKeeper(
 		cdc,
 		app.capKeyAccountStore,
 		app.paramsKeeper.Subspace(auth.DefaultParamspace),
 		types.ProtoAppAccount,
-	)
+	))
+
+	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.capKeyFeeStore)
 
 	// Add handlers.
-	app.bankKeeper = bank.NewBaseKeeper(app.accountKeeper)
-	app.coolKeeper = cool.NewKeeper(app.capKeyMainStore, app.bankKeeper, cool.DefaultCodespace)
-	app.powKeeper = pow.NewKeeper(app.capKeyPowStore, pow.NewConfig("pow", int64(1)), app.bankKeeper, pow.DefaultCodespace)
-	app.ibcMapper = ibc.NewMapper(app.cdc, app.capKeyIBCStore, ibc.DefaultCodespace)
-	app.stakingKeeper = simplestaking.NewKeeper(app.capKeyStakingStore, app.bankKeeper, simplestaking.DefaultCodespace)
+	app.distrKeeper = distribution.NewKeeper(app.capKeyDistrStore, app.cdc, app.feeCollectionKeeper,
+		app.paramsKeeper.Subspace(distribution.DefaultParamspace))
+	app.bankKeeper = bank.NewKeeper(app.capKeyBankStore, app.cdc, app.accountKeeper.AccountKeeper, app.feeCollectionKeeper,
+		app.paramsKeeper.Subspace(bank.DefaultParamspace)).
+		WithCommunityPool(app.distrKeeper).
+		WithAccountCreator(app.accountKeeper)
+	app.coolKeeper = cool.NewKeeper(app.capKeyMainStore, app.bankKeeper, app.paramsKeeper.Subspace(cool.DefaultParamspace),
+		cool.DefaultCodespace)
+	app.powKeeper = pow.NewKeeper(app.capKeyPowStore, app.bankKeeper, app.paramsKeeper.Subspace(pow.DefaultParamspace), pow.DefaultCodespace)
+	app.ibcMapper = ibc.NewMapper(app.cdc, app.capKeyIBCStore, app.tkeyIBC, app.paramsKeeper.Subspace(ibc.DefaultParamspace),
+		ibc.DefaultCodespace)
+	app.stakingKeeper = simplestaking.NewKeeper(app.capKeyStakingStore, app.bankKeeper.Escrow(),
+		app.paramsKeeper.Subspace(simplestaking.DefaultParamspace), simplestaking.DefaultCodespace)
+	app.adminKeeper = admin.NewKeeper(app.bankKeeper, app.coolKeeper, app.paramsKeeper.Subspace(admin.DefaultParamspace), admin.DefaultCodespace)
+	app.nameKeeper = account.NewKeeper(app.accountKeeper.AccountKeeper, account.DefaultCodespace)
+	app.feeGrantKeeper = feegrant.NewKeeper(app.capKeyFeeGrant, app.cdc, app.bankKeeper, feegrant.DefaultCodespace)
+	app.blockGasKeeper = blockgas.NewKeeper(app.tkeyBlockGas, app.paramsKeeper.Subspace(blockgas.DefaultParamspace),
+		blockgas.DefaultCodespace)
+	txLogger := logger.With("module", "tx")
+	wrap := func(h sdk.Handler) sdk.Handler {
+		return NewMetricsHandler(app.metrics, NewLoggingHandler(txLogger, NewRecoveryHandler(txLogger,
+			app.adminKeeper.NewCircuitBreakerHandler(app.blockGasKeeper.NewGasCostHandler(h)))))
+	}
 	app.Router().
-		AddRoute("bank", bank.NewHandler(app.bankKeeper)).
-		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.bankKeeper)).
-		AddRoute("simplestaking", simplestaking.NewHandler(app.stakingKeeper))
+		AddRoute("bank", wrap(bank.NewHandler(app.bankKeeper))).
+		AddRoute("pow", wrap(app.powKeeper.Handler)).
+		AddRoute("ibc", wrap(ibc.NewHandler(app.ibcMapper, app.bankKeeper))).
+		AddRoute("simplestaking", wrap(simplestaking.NewHandler(app.stakingKeeper))).
+		AddRoute("admin", wrap(admin.NewHandler(app.adminKeeper))).
+		AddRoute("account", wrap(account.NewHandler(app.nameKeeper))).
+		AddRoute("feegrant", wrap(feegrant.NewHandler(app.feeGrantKeeper)))
+	app.QueryRouter().
+		AddRoute(QueryAccount, NewAccountQuerier(app.cdc, app.accountKeeper.AccountKeeper)).
+		AddRoute(QueryAuth, NewAuthQuerier(app.cdc, app.accountKeeper.AccountKeeper)).
+		AddRoute(QueryProfile, NewTimeoutQuerier(NewProfileQuerier(app.cdc, app.accountKeeper.AccountKeeper, app.bankKeeper,
+			app.nameKeeper, app.stakingKeeper), func() time.Duration { return app.queryTimeout })).
+		AddRoute(QueryApp, NewAppQuerier(app.cdc, app.modules(), app.moduleAccounts)).
+		AddRoute(QueryConsensusParams, NewConsensusParamsQuerier(app.cdc, app.capKeyMainStore, app.blockGasKeeper)).
+		AddRoute(QueryBatch, NewTimeoutQuerier(NewBatchQuerier(app.cdc, app.QueryRouter().Route,
+			func() int { return app.maxBatchQueries }), func() time.Duration { return app.queryTimeout })).
+		AddRoute(pow.QuerierRoute, pow.NewQuerier(app.powKeeper)).
+		AddRoute(cool.QuerierRoute, cool.NewQuerier(app.coolKeeper)).
+		AddRoute(simplestaking.QuerierRoute, simplestaking.NewQuerier(app.stakingKeeper)).
+		AddRoute(distribution.QuerierRoute, distribution.NewQuerier(app.distrKeeper)).
+		AddRoute(account.QuerierRoute, account.NewQuerier(app.nameKeeper)).
+		AddRoute(ibc.QuerierRoute, ibc.NewQuerier(app.ibcMapper)).
+		AddRoute(bank.QuerierRoute, bank.NewQuerier(app.bankKeeper))
 
 	// Initialize BaseApp.
 	app.SetInitChainer(app.initChainerFn(app.coolKeeper, app.powKeeper))
-	app.MountStores(app.capKeyMainStore, app.capKeyAccountStore, app.capKeyPowStore, app.capKeyIBCStore, app.capKeyStakingStore)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper))
+	app.SetBeginBlocker(app.BeginBlocker)
+	app.SetEndBlocker(app.EndBlocker)
+	app.MountStores(app.storeKeys()...)
+	app.SetAnteHandler(NewLoggingAnteHandler(txLogger, app.adminKeeper.NewAnteHandler(
+		NewFeeDenomAnteHandler(app.bankKeeper, NewMinGasPriceAnteHandler(app.blockGasKeeper.NewAnteHandler(
+			app.feeGrantKeeper.NewAnteHandler(auth.NewAnteHandler(app.accountKeeper.AccountKeeper,
+				app.feeCollectionKeeper))))))))
 	err := app.LoadLatestVersion(app.capKeyMainStore)
 	if err != nil {
-		cmn.Exit(err.Error())
+		return nil, err
+	}
+	err = app.metrics.load(app.cdc, app.db)
+	if err != nil {
+		return nil, err
 	}
 
 	app.Seal()
 
-	return app
+	return app, nil
 }
 
-// custom tx codec
-func MakeCodec() *codec.Codec {
-	var cdc = codec.New()
-	codec.RegisterCrypto(cdc) // Register crypto.
-	sdk.RegisterCodec(cdc)    // Register Msgs
-	cool.RegisterCodec(cdc)
-	pow.RegisterCodec(cdc)
-	bank.RegisterCodec(cdc)
-	ibc.RegisterCodec(cdc)
-	simplestaking.RegisterCodec(cdc)
-
-	// Register AppAccount
+// storeKeys lists the keys of all the stores mounted by the app
+func (app *DemocoinApp) storeKeys() []sdk.StoreKey {
+	return []sdk.StoreKey{
+		app.capKeyMainStore, app.capKeyAccountStore, app.capKeyFeeStore, app.capKeyBankStore, app.capKeyPowStore,
+		app.capKeyIBCStore, app.capKeyStakingStore, app.capKeyDistrStore, app.capKeyFeeGrant, app.keyParams,
+		app.tkeyParams, app.tkeyBlockGas, app.tkeyIBC,
+	}
+}
+
+// modules lists the modules loaded by the app with their store keys
+func (app *DemocoinApp) modules() []ModuleInfo {
+	return []ModuleInfo{
+		{"bank", app.capKeyBankStore.Name()},
+		{"ibc", app.capKeyIBCStore.Name()},
+		{"pow", app.capKeyPowStore.Name()},
+		{"cool", app.capKeyMainStore.Name()},
+		{"simplestaking", app.capKeyStakingStore.Name()},
+		{"distribution", app.capKeyDistrStore.Name()},
+		{"feegrant", app.capKeyFeeGrant.Name()},
+		{"blockgas", app.tkeyBlockGas.Name()},
+	}
+}
+
+// moduleAccounts returns the accounts of the modules holding coins
+func (app *DemocoinApp) moduleAccounts(ctx sdk.Context) []ModuleAccount {
+	return ModuleAccounts(ctx, app.feeCollectionKeeper, app.distrKeeper, app.stakingKeeper)
+}
+
+// RegisterCodecFn registers types with a codec
+type RegisterCodecFn func(cdc *codec.Codec)
+
+// DefaultRegisterCodecFns returns the registrations of the crypto, sdk and
+// module types and of the app accounts
+func DefaultRegisterCodecFns() []RegisterCodecFn {
+	return []RegisterCodecFn{
+		codec.RegisterCrypto, // Register crypto.
+		sdk.RegisterCodec,    // Register Msgs
+		cool.RegisterCodec,
+		pow.RegisterCodec,
+		bank.RegisterCodec,
+		ibc.RegisterCodec,
+		simplestaking.RegisterCodec,
+		admin.RegisterCodec,
+		account.RegisterCodec,
+		feegrant.RegisterCodec,
+		registerAccounts,
+	}
+}
+
+// registerAccounts registers the account interface and the AppAccount
+func registerAccounts(cdc *codec.Codec) {
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
 	cdc.RegisterConcrete(&types.AppAccount{}, "xpx-cosmos/Account", nil)
+	cdc.RegisterConcrete(&types.ContinuousVestingAccount{}, "xpx-cosmos/ContinuousVestingAccount", nil)
+	cdc.RegisterConcrete(&types.PeriodicVestingAccount{}, "xpx-cosmos/PeriodicVestingAccount", nil)
+}
+
+// MakeCodec returns a sealed codec with the given registrations applied in
+// order. Apps embedding xpx-cosmos append their own registrations to
+// DefaultRegisterCodecFns.
+func MakeCodec(fns ...RegisterCodecFn) *codec.Codec {
+	var cdc = codec.New()
+	for _, fn := range fns {
+		fn(cdc)
+	}
 
 	cdc.Seal()
 
 	return cdc
 }
 
+// MakeDefaultCodec returns the custom tx codec of the app
+func MakeDefaultCodec() *codec.Codec {
+	return MakeCodec(DefaultRegisterCodecFns()...)
+}
+
 // custom logic for democoin initialization
 // nolint: unparam
 func (app *DemocoinApp) initChainerFn(coolKeeper cool.Keeper, powKeeper pow.Keeper) sdk.InitChainer {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 		stateJSON := req.AppStateBytes
 
-		genesisState := new(types.GenesisState)
-		err := app.cdc.UnmarshalJSON(stateJSON, genesisState)
+		genesisState, defaulted, err := types.UnmarshalGenesisStateWithDefaults(app.cdc, stateJSON)
 		if err != nil {
 			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
 			// return sdk.ErrGenesisParse("").TraceCause(err, "")
 		}
+		if len(defaulted) > 0 {
+			ctx.Logger().Info("Applied default genesis for missing sections", "sections", strings.Join(defaulted, ","))
+		}
 
+		err = genesisState.Validate()
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		supply := sdk.Coins{}
 		for _, gacc := range genesisState.Accounts {
-			acc, err := gacc.ToAppAccount()
+			acc, err := gacc.ToAccount()
 			if err != nil {
 				panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
 				//	return sdk.ErrGenesisParse("").TraceCause(err, "")
 			}
 			app.accountKeeper.SetAccount(ctx, acc)
+			supply = supply.Plus(acc.GetCoins())
 		}
+		supply = supply.Plus(genesisState.DistrGenesis.CommunityPool)
+		app.bankKeeper.SetSupply(ctx, supply)
 
 		// Application specific genesis handling
 		err = cool.InitGenesis(ctx, app.coolKeeper, genesisState.CoolGenesis)
@@ -163,34 +378,211 @@ func (app *DemocoinApp) initChainerFn(coolKeeper cool.Keeper, powKeeper pow.Keep
 			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
 		}
 
+		err = simplestaking.InitGenesis(ctx, app.stakingKeeper, genesisState.StakingGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+		// bonded and unbonding coins are held by no account
+		staked := app.stakingKeeper.GetBondedCoins(ctx).Plus(app.stakingKeeper.GetUnbondingCoins(ctx))
+		app.bankKeeper.SetSupply(ctx, app.bankKeeper.GetSupply(ctx).Plus(staked))
+
+		err = admin.InitGenesis(ctx, app.adminKeeper, genesisState.AdminGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		err = distribution.InitGenesis(ctx, app.distrKeeper, genesisState.DistrGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		err = bank.InitGenesis(ctx, app.bankKeeper, genesisState.BankGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		err = ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		err = feegrant.InitGenesis(ctx, app.feeGrantKeeper, genesisState.FeeGrantGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
+		err = blockgas.InitGenesis(ctx, app.blockGasKeeper, genesisState.BlockGasGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
+		}
+
 		return abci.ResponseInitChain{}
 	}
 }
 
+// application updates every begin block
+func (app *DemocoinApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// blocks before the start time are left empty
+	if !app.adminKeeper.IsStarted(ctx) {
+		return abci.ResponseBeginBlock{}
+	}
+
+	// slashed bonds are burned, as are the collected fees if the
+	// distribution params say so
+	burned := simplestaking.BeginBlocker(ctx, req, app.stakingKeeper)
+	burned = burned.Plus(distribution.BeginBlocker(ctx, app.distrKeeper))
+	if !burned.IsZero() {
+		app.bankKeeper.SetSupply(ctx, app.bankKeeper.GetSupply(ctx).Minus(burned))
+	}
+
+	blockgas.BeginBlocker(ctx, app.blockGasKeeper)
+
+	return pow.BeginBlocker(ctx, app.powKeeper)
+}
+
+// application updates every end block
+func (app *DemocoinApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	if !app.adminKeeper.IsStarted(ctx) {
+		return abci.ResponseEndBlock{}
+	}
+
+	pow.EndBlocker(ctx, app.powKeeper)
+	res := simplestaking.EndBlocker(ctx, app.stakingKeeper)
+
+	if app.invCheckPeriod != 0 && ctx.BlockHeight()%int64(app.invCheckPeriod) == 0 {
+		app.assertRuntimeInvariantsOnContext(ctx)
+	}
+
+	return res
+}
+
 // Custom logic for state export
 func (app *DemocoinApp) ExportAppStateAndValidators() (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
+	return app.ExportAppStateAndValidatorsWithOptions(ExportOptions{})
+}
+
+// ExportOptions filters what ExportAppStateAndValidatorsWithOptions writes
+// to the exported genesis
+type ExportOptions struct {
+	// leave out the accounts without coins, such as swept accounts
+	OmitZeroBalances bool
+}
+
+// ExportAppStateAndValidatorsWithOptions exports the genesis state and the
+// validator set like ExportAppStateAndValidators, filtered by the options
+func (app *DemocoinApp) ExportAppStateAndValidatorsWithOptions(opts ExportOptions) (
+	appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
 	ctx := app.NewContext(true, abci.Header{})
 
 	// iterate to get the accounts
 	accounts := []*types.GenesisAccount{}
 	appendAccount := func(acc auth.Account) (stop bool) {
-		account := &types.GenesisAccount{
-			Address: acc.GetAddress(),
-			Coins:   acc.GetCoins(),
+		if opts.OmitZeroBalances && acc.GetCoins().IsZero() {
+			return false
 		}
-		accounts = append(accounts, account)
+		accounts = append(accounts, types.NewGenesisAccountI(acc))
 		return false
 	}
 	app.accountKeeper.IterateAccounts(ctx, appendAccount)
 
+	// the iteration order depends on the store, sort for a reproducible export
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Address.String() < accounts[j].Address.String()
+	})
+
 	genState := types.GenesisState{
-		Accounts:    accounts,
-		POWGenesis:  pow.ExportGenesis(ctx, app.powKeeper),
-		CoolGenesis: cool.ExportGenesis(ctx, app.coolKeeper),
+		Accounts:        accounts,
+		POWGenesis:      pow.ExportGenesis(ctx, app.powKeeper),
+		CoolGenesis:     cool.ExportGenesis(ctx, app.coolKeeper),
+		StakingGenesis:  simplestaking.ExportGenesis(ctx, app.stakingKeeper),
+		AdminGenesis:    admin.ExportGenesis(ctx, app.adminKeeper),
+		DistrGenesis:    distribution.ExportGenesis(ctx, app.distrKeeper),
+		BankGenesis:     bank.ExportGenesis(ctx, app.bankKeeper),
+		IBCGenesis:      ibc.ExportGenesis(ctx, app.ibcMapper),
+		FeeGrantGenesis: feegrant.ExportGenesis(ctx, app.feeGrantKeeper),
+		BlockGasGenesis: blockgas.ExportGenesis(ctx, app.blockGasKeeper),
 	}
-	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
+	appState, err = types.MarshalVersionedGenesisState(app.cdc, genState)
 	if err != nil {
 		return nil, nil, err
 	}
-	return appState, validators, nil
+	return appState, app.exportValidators(ctx), nil
+}
+
+// ExportValidators returns the active validators at the last committed
+// height, leaving out the jailed and inactive ones
+func (app *DemocoinApp) ExportValidators() []tmtypes.GenesisValidator {
+	return app.exportValidators(app.NewContext(true, abci.Header{}))
+}
+
+func (app *DemocoinApp) exportValidators(ctx sdk.Context) (validators []tmtypes.GenesisValidator) {
+	for _, val := range app.stakingKeeper.GetActiveValidators(ctx) {
+		validators = append(validators, tmtypes.GenesisValidator{
+			Address: val.PubKey.Address(),
+			PubKey:  val.PubKey,
+			Power:   val.Power,
+			Name:    val.Address.String(),
+		})
+	}
+	return validators
+}
+
+// SimulateTx runs the encoded tx through the ante handler and the message
+// handlers in simulate mode, without committing any state, and returns the
+// gas it used
+func (app *DemocoinApp) SimulateTx(txBytes []byte) (uint64, error) {
+	tx, err := auth.DefaultTxDecoder(app.cdc)(txBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	res := app.Simulate(txBytes, tx)
+	if !res.IsOK() {
+		return res.GasUsed, fmt.Errorf("simulation failed: %s", res.Log)
+	}
+	return res.GasUsed, nil
+}
+
+// Metrics returns a snapshot of the handled message counters keyed by
+// "route/type/outcome"
+func (app *DemocoinApp) Metrics() map[string]uint64 {
+	return app.metrics.Snapshot()
+}
+
+// Flush writes the in-memory counters to the database without closing it.
+// A node calls it on shutdown, the database being closed by the server.
+func (app *DemocoinApp) Flush() {
+	app.metrics.flush(app.cdc, app.db)
+}
+
+// Close flushes the in-memory counters to the database and closes it, for
+// callers owning the database. Only the first call has an effect.
+func (app *DemocoinApp) Close() {
+	app.closeOnce.Do(func() {
+		app.Flush()
+		app.db.Close()
+	})
+}
+
+// SetPaused pauses or resumes the chain. While paused, every tx is rejected
+// except the admin's MsgUnpause.
+func (app *DemocoinApp) SetPaused(ctx sdk.Context, paused bool) {
+	app.adminKeeper.SetPaused(ctx, paused)
+}
+
+// ExportPowGenesis exports only the pow module state
+func (app *DemocoinApp) ExportPowGenesis(ctx sdk.Context) (json.RawMessage, error) {
+	return codec.MarshalJSONIndent(app.cdc, pow.ExportGenesis(ctx, app.powKeeper))
+}
+
+// ExportCoolGenesis exports only the cool module state
+func (app *DemocoinApp) ExportCoolGenesis(ctx sdk.Context) (json.RawMessage, error) {
+	return codec.MarshalJSONIndent(app.cdc, cool.ExportGenesis(ctx, app.coolKeeper))
 }
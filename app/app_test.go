@@ -1,31 +1,45 @@
 package app
 
 import (
+	"encoding/json"
 	"os"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/admin"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/ibc"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
 )
 
 func setGenesis(bapp *DemocoinApp, trend string, accs ...auth.BaseAccount) error {
 	genaccs := make([]*types.GenesisAccount, len(accs))
 	for i, acc := range accs {
-		genaccs[i] = types.NewGenesisAccount(&types.AppAccount{acc, "foobart"})
+		genaccs[i] = types.NewGenesisAccount(&types.AppAccount{BaseAccount: acc, Name: "foobart"})
 	}
 
 	genesisState := types.GenesisState{
-		Accounts:    genaccs,
-		CoolGenesis: cool.Genesis{trend},
+		Accounts:       genaccs,
+		POWGenesis:     pow.DefaultGenesis(),
+		CoolGenesis:    cool.Genesis{Trend: trend, Params: cool.DefaultParams()},
+		StakingGenesis: simplestaking.DefaultGenesis(),
 	}
 
 	stateBytes, err := codec.MarshalJSONIndent(bapp.cdc, genesisState)
@@ -41,10 +55,24 @@ func setGenesis(bapp *DemocoinApp, trend string, accs ...auth.BaseAccount) error
 	return nil
 }
 
+// signTx signs the msgs with the current sequence of the key's account and
+// returns the encoded tx
+func signTx(t *testing.T, bapp *DemocoinApp, priv crypto.PrivKey, fee auth.StdFee, msgs ...sdk.Msg) []byte {
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	acc := bapp.accountKeeper.GetAccount(ctx, sdk.AccAddress(priv.PubKey().Address()))
+	sig, err := priv.Sign(auth.StdSignBytes("", acc.GetAccountNumber(), acc.GetSequence(), fee, msgs, ""))
+	require.Nil(t, err)
+	tx := auth.NewStdTx(msgs, fee, []auth.StdSignature{{PubKey: priv.PubKey(), Signature: sig}}, "")
+	txBytes, err := auth.DefaultTxEncoder(bapp.cdc)(tx)
+	require.Nil(t, err)
+	return txBytes
+}
+
 func TestGenesis(t *testing.T) {
 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "sdk/app")
 	db := dbm.NewMemDB()
-	bapp := NewDemocoinApp(logger, db)
+	bapp, err := NewDemocoinApp(logger, db, 0)
+	require.Nil(t, err)
 
 	// Construct some genesis bytes to reflect democoin/types/AppAccount
 	pk := ed25519.GenPrivKey().PubKey()
@@ -55,7 +83,7 @@ func TestGenesis(t *testing.T) {
 		Address: addr,
 		Coins:   coins,
 	}
-	acc := &types.AppAccount{baseAcc, "foobart"}
+	acc := &types.AppAccount{BaseAccount: baseAcc, Name: "foobart"}
 
 	err = setGenesis(bapp, "ice-cold", baseAcc)
 	require.Nil(t, err)
@@ -65,9 +93,767 @@ func TestGenesis(t *testing.T) {
 	require.Equal(t, acc, res1)
 
 	// reload app and ensure the account is still there
-	bapp = NewDemocoinApp(logger, db)
+	bapp, err = NewDemocoinApp(logger, db, 0)
+	require.Nil(t, err)
 	bapp.InitChain(abci.RequestInitChain{AppStateBytes: []byte("{}")})
 	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
 	res1 = bapp.accountKeeper.GetAccount(ctx, baseAcc.Address)
 	require.Equal(t, acc, res1)
 }
+
+func TestNewDemocoinAppCorruptedDB(t *testing.T) {
+	db := dbm.NewMemDB()
+
+	// point the latest version at a commit that was never written
+	db.Set([]byte("s/latest"), codec.New().MustMarshalBinaryLengthPrefixed(int64(5)))
+
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.NotNil(t, err)
+	require.Nil(t, bapp)
+}
+
+func TestInitChainInvalidGenesis(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	// explicit zero pow difficulty
+	require.Panics(t, func() {
+		bapp.InitChain(abci.RequestInitChain{AppStateBytes: []byte(`{"pow": {"difficulty": "0"}}`)})
+	})
+}
+
+func TestInitChainPartialGenesis(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	gs := types.DefaultGenesisState()
+	gs.POWGenesis.Difficulty = 2
+	bz, err := bapp.cdc.MarshalJSON(gs)
+	require.Nil(t, err)
+	var sections map[string]json.RawMessage
+	require.Nil(t, json.Unmarshal(bz, &sections))
+	delete(sections, "cool")
+	bz, err = json.Marshal(sections)
+	require.Nil(t, err)
+
+	bapp.InitChain(abci.RequestInitChain{AppStateBytes: bz})
+	bapp.Commit()
+
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	coolGenesis := cool.ExportGenesis(ctx, bapp.coolKeeper)
+	require.Equal(t, cool.DefaultGenesis().Trend, coolGenesis.Trend)
+	require.Equal(t, cool.DefaultParams().TrendSetterDenom, coolGenesis.Params.TrendSetterDenom)
+	require.True(t, cool.DefaultParams().MinTrendSetterCoins.Equal(coolGenesis.Params.MinTrendSetterCoins))
+	difficulty, err := bapp.powKeeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), difficulty)
+}
+
+func TestExportValidators(t *testing.T) {
+	logger := log.NewNopLogger()
+	db := dbm.NewMemDB()
+	bapp, err := NewDemocoinApp(logger, db, 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("steak", 100)},
+	}
+	err = setGenesis(bapp, "ice-cold", baseAcc)
+	require.Nil(t, err)
+
+	// bond a validator in a committed block
+	valPubKey := ed25519.GenPrivKey().PubKey()
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	_, sdkErr := bapp.stakingKeeper.Bond(ctx, addr, valPubKey, sdk.NewInt64Coin("steak", 10))
+	require.Nil(t, sdkErr)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	_, validators, err := bapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+	require.Equal(t, []tmtypes.GenesisValidator{{
+		Address: valPubKey.Address(),
+		PubKey:  valPubKey,
+		Power:   10,
+		Name:    addr.String(),
+	}}, validators)
+}
+
+func TestExportAccountsSorted(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	var accs []auth.BaseAccount
+	for i := 0; i < 5; i++ {
+		accs = append(accs, auth.BaseAccount{
+			Address: sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+			Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 10)},
+		})
+	}
+	// make sure the accounts are not inserted in order
+	sort.Slice(accs, func(i, j int) bool {
+		return accs[i].Address.String() > accs[j].Address.String()
+	})
+	require.Nil(t, setGenesis(bapp, "ice-cold", accs...))
+
+	appState, _, err := bapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+	genState, _, err := types.UnmarshalVersionedGenesisState(bapp.cdc, appState)
+	require.Nil(t, err)
+
+	require.Len(t, genState.Accounts, len(accs))
+	require.True(t, sort.SliceIsSorted(genState.Accounts, func(i, j int) bool {
+		return genState.Accounts[i].Address.String() < genState.Accounts[j].Address.String()
+	}))
+
+	// exports are byte identical
+	again, _, err := bapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+	require.Equal(t, appState, again)
+}
+
+func TestExportOmitZeroBalances(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	funded := auth.BaseAccount{
+		Address: sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 10)},
+	}
+	empty := auth.BaseAccount{
+		Address: sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+		Coins:   sdk.Coins{},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", funded, empty))
+
+	// by default every account is exported
+	appState, _, err := bapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+	genState, _, err := types.UnmarshalVersionedGenesisState(bapp.cdc, appState)
+	require.Nil(t, err)
+	require.Len(t, genState.Accounts, 2)
+
+	appState, _, err = bapp.ExportAppStateAndValidatorsWithOptions(ExportOptions{OmitZeroBalances: true})
+	require.Nil(t, err)
+	genState, _, err = types.UnmarshalVersionedGenesisState(bapp.cdc, appState)
+	require.Nil(t, err)
+	require.Len(t, genState.Accounts, 1)
+	require.Equal(t, funded.Address, genState.Accounts[0].Address)
+}
+
+func TestExportValidatorSet(t *testing.T) {
+	db := dbm.NewMemDB()
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+
+	var accs []auth.BaseAccount
+	for i := 0; i < 3; i++ {
+		accs = append(accs, auth.BaseAccount{
+			Address: sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+			Coins:   sdk.Coins{sdk.NewInt64Coin("steak", 100)},
+		})
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", accs...))
+
+	// bond a validator per account in a committed block
+	expected := make(map[string]tmtypes.GenesisValidator)
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	for i, acc := range accs {
+		pubKey := ed25519.GenPrivKey().PubKey()
+		_, sdkErr := bapp.stakingKeeper.Bond(ctx, acc.Address, pubKey, sdk.NewInt64Coin("steak", int64(10*(i+1))))
+		require.Nil(t, sdkErr)
+		expected[acc.Address.String()] = tmtypes.GenesisValidator{
+			Address: pubKey.Address(),
+			PubKey:  pubKey,
+			Power:   int64(10 * (i + 1)),
+			Name:    acc.Address.String(),
+		}
+	}
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	// export from a fresh app loading the same db, as from a stopped node
+	bapp, err = NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+	bz, err := codec.MarshalJSONIndent(bapp.cdc, bapp.ExportValidators())
+	require.Nil(t, err)
+
+	var validators []tmtypes.GenesisValidator
+	require.Nil(t, bapp.cdc.UnmarshalJSON(bz, &validators))
+	require.Len(t, validators, len(accs))
+	for _, val := range validators {
+		require.Equal(t, expected[val.Name], val)
+	}
+}
+
+func TestExportModuleGenesis(t *testing.T) {
+	logger := log.NewNopLogger()
+	db := dbm.NewMemDB()
+	bapp, err := NewDemocoinApp(logger, db, 0)
+	require.Nil(t, err)
+
+	err = setGenesis(bapp, "hot-dog")
+	require.Nil(t, err)
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+
+	bz, err := bapp.ExportPowGenesis(ctx)
+	require.Nil(t, err)
+	var powGenesis pow.Genesis
+	require.Nil(t, bapp.cdc.UnmarshalJSON(bz, &powGenesis))
+	require.Equal(t, pow.DefaultGenesis().Difficulty, powGenesis.Difficulty)
+	require.Equal(t, pow.DefaultGenesis().Count, powGenesis.Count)
+
+	bz, err = bapp.ExportCoolGenesis(ctx)
+	require.Nil(t, err)
+	var coolGenesis cool.Genesis
+	require.Nil(t, bapp.cdc.UnmarshalJSON(bz, &coolGenesis))
+	require.Equal(t, "hot-dog", coolGenesis.Trend)
+	require.Equal(t, cool.DefaultParams().TrendSetterDenom, coolGenesis.Params.TrendSetterDenom)
+}
+
+func TestExportImportVersionedGenesis(t *testing.T) {
+	logger := log.NewNopLogger()
+	bapp, err := NewDemocoinApp(logger, dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 10)},
+	}
+	err = setGenesis(bapp, "ice-cold", baseAcc)
+	require.Nil(t, err)
+
+	appState, _, err := bapp.ExportAppStateAndValidators()
+	require.Nil(t, err)
+
+	var versioned types.VersionedGenesisState
+	require.Nil(t, bapp.cdc.UnmarshalJSON(appState, &versioned))
+	require.Equal(t, types.AppStateVersion, versioned.AppVersion)
+
+	// the exported state starts a new chain
+	newApp, err := NewDemocoinApp(logger, dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	newApp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newApp.Commit()
+
+	ctx := newApp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, baseAcc.Coins, newApp.accountKeeper.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, "ice-cold", newApp.coolKeeper.GetTrend(ctx))
+
+	// states from future versions are rejected
+	versioned.AppVersion = types.AppStateVersion + 1
+	appState, err = bapp.cdc.MarshalJSON(versioned)
+	require.Nil(t, err)
+	newApp, err = NewDemocoinApp(logger, dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Panics(t, func() {
+		newApp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	})
+}
+
+func TestPowTotalMintedMatchesSupply(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	sender := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	for count := uint64(1); count <= 3; count++ {
+		difficulty, err := bapp.powKeeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		msg := pow.GenerateMsgMine(sender, count, difficulty)
+		require.True(t, bapp.powKeeper.Handler(ctx, msg).IsOK())
+	}
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
+	minted := bapp.powKeeper.GetTotalMinted(ctx)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 3)}, minted)
+	require.Equal(t, minted, bapp.bankKeeper.GetSupply(ctx))
+}
+
+func TestSupplyQuerierAfterMineAndBurn(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	miner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	for count := uint64(1); count <= 3; count++ {
+		difficulty, err := bapp.powKeeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		msg := pow.GenerateMsgMine(miner, count, difficulty)
+		require.True(t, bapp.powKeeper.Handler(ctx, msg).IsOK())
+	}
+	burn := bank.NewMsgBurn(miner, sdk.Coins{sdk.NewInt64Coin("pow", 2)})
+	require.True(t, bank.NewHandler(bapp.bankKeeper)(ctx, burn).IsOK())
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	res := bapp.Query(abci.RequestQuery{Path: "/custom/bank/supply/pow"})
+	require.True(t, res.IsOK(), res.Log)
+	var supply sdk.Coin
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &supply))
+	require.Equal(t, sdk.NewInt64Coin("pow", 1), supply)
+
+	res = bapp.Query(abci.RequestQuery{Path: "/custom/bank/supply_all"})
+	require.True(t, res.IsOK(), res.Log)
+	var all sdk.Coins
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &all))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 1)}, all)
+
+	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, all, bapp.bankKeeper.GetSupply(ctx))
+}
+
+func TestReplayRejected(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	// sign a send with the account's current sequence
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	acc := bapp.accountKeeper.GetAccount(ctx, addr)
+	txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, sdk.Coins{}), sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+	))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	res := bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	// the same bytes no longer match the incremented sequence
+	res = bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(sdk.CodeUnauthorized), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, acc.GetSequence()+1, bapp.accountKeeper.GetAccount(ctx, addr).GetSequence())
+	require.Equal(t, coins, bapp.accountKeeper.GetAccount(ctx, recipient).GetCoins())
+}
+
+func TestFeesMovedToCommunityPool(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 5)}
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100), sdk.NewInt64Coin("steak", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, fees), sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+	))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 1})
+	bapp.Commit()
+
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{}, bapp.distrKeeper.GetCommunityPool(ctx))
+
+	// the fees reach the pool at the beginning of the next block
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	bapp.EndBlock(abci.RequestEndBlock{Height: 2})
+	bapp.Commit()
+
+	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, fees, bapp.distrKeeper.GetCommunityPool(ctx))
+	require.True(t, bapp.feeCollectionKeeper.GetCollectedFees(ctx).IsZero())
+
+	res2 := bapp.Query(abci.RequestQuery{Path: "/custom/distribution/community_pool"})
+	require.Equal(t, uint32(sdk.CodeOK), res2.Code, res2.Log)
+	var pool sdk.Coins
+	require.Nil(t, codec.Cdc.UnmarshalJSON(res2.Value, &pool))
+	require.Equal(t, fees, pool)
+}
+
+func TestFeeGrantPaysFees(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	require.Nil(t, err)
+
+	granterPriv := ed25519.GenPrivKey()
+	granter := sdk.AccAddress(granterPriv.PubKey().Address())
+	granteePriv := ed25519.GenPrivKey()
+	grantee := sdk.AccAddress(granteePriv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 5)}
+	require.Nil(t, setGenesis(bapp, "ice-cold",
+		auth.BaseAccount{Address: granter, Coins: sdk.Coins{sdk.NewInt64Coin("steak", 100)}},
+		auth.BaseAccount{Address: grantee, Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}},
+	))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	grantTx := signTx(t, bapp, granterPriv, auth.NewStdFee(200000, nil),
+		feegrant.NewMsgGrantAllowance(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", 7)}))
+	res := bapp.DeliverTx(grantTx)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 1})
+	bapp.Commit()
+
+	sendTx := func() []byte {
+		return signTx(t, bapp, granteePriv, auth.NewStdFee(200000, fees), sdkbank.NewMsgSend(
+			[]sdkbank.Input{sdkbank.NewInput(grantee, coins)},
+			[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+		))
+	}
+
+	// the grantee holds no steak, the granter pays its fee
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res = bapp.DeliverTx(sendTx())
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 2})
+	bapp.Commit()
+
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 95)}, bapp.accountKeeper.GetAccount(ctx, granter).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 90)}, bapp.accountKeeper.GetAccount(ctx, grantee).GetCoins())
+
+	// the remaining allowance doesn't cover another fee
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	res = bapp.DeliverTx(sendTx())
+	require.Equal(t, uint32(sdk.CodeInsufficientFunds), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 3})
+	bapp.Commit()
+}
+
+func TestFeeGrantUntaxed(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	granter := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	grantee := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	fee := sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	require.Nil(t, setGenesis(bapp, "ice-cold",
+		auth.BaseAccount{Address: granter, Coins: sdk.Coins{sdk.NewInt64Coin("steak", 100)}}))
+
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	params := bapp.bankKeeper.GetParams(ctx)
+	params.TransferTaxRate = sdk.NewDecWithPrec(1, 1) // 10%
+	params.AccountCreationFee = sdk.Coins{sdk.NewInt64Coin("steak", 1)}
+	bapp.bankKeeper.SetParams(ctx, params)
+	bapp.feeGrantKeeper.SetGrant(ctx, feegrant.NewGrant(granter, grantee, nil))
+
+	// the grantee without an account receives the whole fee, nothing goes
+	// to the community pool or the fee collector
+	_, ok := bapp.feeGrantKeeper.UseGrantedFee(ctx, grantee, fee)
+	require.True(t, ok)
+	require.Equal(t, fee, bapp.accountKeeper.GetAccount(ctx, granter).GetCoins())
+	require.Equal(t, fee, bapp.accountKeeper.GetAccount(ctx, grantee).GetCoins())
+	require.True(t, bapp.distrKeeper.GetCommunityPool(ctx).IsZero())
+	require.True(t, bapp.feeCollectionKeeper.GetCollectedFees(ctx).IsZero())
+}
+
+func TestLockedAccountDebits(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	lockedPub := ed25519.GenPrivKey().PubKey()
+	locked := sdk.AccAddress(lockedPub.Address())
+	otherPub := ed25519.GenPrivKey().PubKey()
+	other := sdk.AccAddress(otherPub.Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	stake := sdk.NewInt64Coin("steak", 10)
+	funds := sdk.Coins{sdk.NewInt64Coin("foocoin", 100), sdk.NewInt64Coin("steak", 100)}
+
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	bapp.accountKeeper.SetAccount(ctx, &types.AppAccount{
+		BaseAccount:  auth.BaseAccount{Address: locked, Coins: funds},
+		UnlockHeight: 10,
+	})
+	bapp.accountKeeper.SetAccount(ctx, &types.AppAccount{
+		BaseAccount: auth.BaseAccount{Address: other, Coins: funds},
+	})
+	_, err = bapp.stakingKeeper.Bond(ctx, other, otherPub, stake)
+	require.Nil(t, err)
+	bapp.feeGrantKeeper.SetGrant(ctx, feegrant.NewGrant(locked, other, nil))
+
+	msgs := []sdk.Msg{
+		sdkbank.NewMsgSend([]sdkbank.Input{sdkbank.NewInput(locked, coins)}, []sdkbank.Output{sdkbank.NewOutput(other, coins)}),
+		bank.NewMsgMultiSend([]sdkbank.Input{sdkbank.NewInput(locked, coins)}, []sdkbank.Output{sdkbank.NewOutput(other, coins)}),
+		bank.NewMsgSwap(locked, coins, other, coins),
+		bank.NewMsgBurn(locked, coins),
+		ibc.IBCTransferMsg{IBCPacket: ibc.NewIBCPacket(locked, other, coins, "ice-cold", "hot"), TimeoutHeight: 100},
+		simplestaking.NewMsgBond(locked, stake, lockedPub),
+		simplestaking.NewMsgDelegateMulti(locked, []simplestaking.DelegationEntry{{Validator: other, Amount: stake}}),
+	}
+
+	// before the unlock height nothing can be taken from the account
+	for _, msg := range msgs {
+		res := bapp.Router().Route(msg.Route())(ctx.WithBlockHeight(9), msg)
+		require.Equal(t, sdk.CodeUnauthorized, res.Code, msg.Type())
+	}
+	_, ok := bapp.feeGrantKeeper.UseGrantedFee(ctx.WithBlockHeight(9), other, coins)
+	require.False(t, ok)
+
+	// crediting the account is still allowed
+	_, err = bapp.bankKeeper.SendCoins(ctx.WithBlockHeight(9), other, locked, coins)
+	require.Nil(t, err)
+
+	// from the unlock height on
+	for _, msg := range msgs {
+		res := bapp.Router().Route(msg.Route())(ctx.WithBlockHeight(10), msg)
+		require.True(t, res.IsOK(), "%s: %s", msg.Type(), res.Log)
+	}
+	_, ok = bapp.feeGrantKeeper.UseGrantedFee(ctx.WithBlockHeight(10), other, coins)
+	require.True(t, ok)
+}
+
+func TestDisabledRouteRejected(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	adminPriv := ed25519.GenPrivKey()
+	adminAddr := sdk.AccAddress(adminPriv.PubKey().Address())
+	userPriv := ed25519.GenPrivKey()
+	user := sdk.AccAddress(userPriv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	require.Nil(t, setGenesis(bapp, "ice-cold",
+		auth.BaseAccount{Address: adminAddr, Coins: coins},
+		auth.BaseAccount{Address: user, Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}},
+	))
+
+	// txs are signed with the committed sequence, so each goes in its own
+	// block
+	height := int64(1)
+	deliver := func(priv crypto.PrivKey, msg sdk.Msg) abci.ResponseDeliverTx {
+		height++
+		txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, nil), msg)
+		bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		if height == 2 {
+			bapp.adminKeeper.SetAdmin(bapp.BaseApp.NewContext(false, abci.Header{}), adminAddr)
+		}
+		res := bapp.DeliverTx(txBytes)
+		bapp.EndBlock(abci.RequestEndBlock{Height: height})
+		bapp.Commit()
+		return res
+	}
+	mine := func() sdk.Msg {
+		difficulty, err := bapp.powKeeper.GetLastDifficulty(bapp.BaseApp.NewContext(true, abci.Header{}))
+		require.Nil(t, err)
+		return pow.GenerateMsgMine(user, 1, difficulty)
+	}
+	send := sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(user, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+	)
+
+	// only the admin may disable a route
+	res := deliver(userPriv, admin.NewMsgToggleRoute(user, "pow", true))
+	require.Equal(t, uint32(admin.CodeUnauthorized), res.Code, res.Log)
+	res = deliver(adminPriv, admin.NewMsgToggleRoute(adminAddr, "pow", true))
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	// mining is rejected while sends still go through
+	res = deliver(userPriv, mine())
+	require.Equal(t, uint32(admin.CodeRouteDisabled), res.Code, res.Log)
+	res = deliver(userPriv, send)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	// until the route is enabled again
+	res = deliver(adminPriv, admin.NewMsgToggleRoute(adminAddr, "pow", false))
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	res = deliver(userPriv, mine())
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, coins, bapp.accountKeeper.GetAccount(ctx, recipient).GetCoins())
+	require.Equal(t, uint64(1), bapp.powKeeper.GetMinerCount(ctx, user))
+}
+
+func TestStartTimeGate(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	genesisState := types.DefaultGenesisState()
+	genesisState.Accounts = []*types.GenesisAccount{{Address: addr, Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}}}
+	genesisState.AdminGenesis.Params.StartTime = start.Unix()
+	stateBytes, err := bapp.cdc.MarshalJSON(genesisState)
+	require.Nil(t, err)
+	bapp.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	bapp.Commit()
+
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	send := sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+	)
+	deliver := func(height int64, blockTime time.Time) abci.ResponseDeliverTx {
+		txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, nil), send)
+		header := abci.Header{Height: height, Time: blockTime}
+		bapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+		res := bapp.DeliverTx(txBytes)
+		bapp.EndBlock(abci.RequestEndBlock{Height: height})
+		bapp.Commit()
+		return res
+	}
+
+	// blocks before the start time are not processed
+	res := deliver(2, start.Add(-time.Second))
+	require.Equal(t, uint32(admin.CodeNotStarted), res.Code, res.Log)
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Nil(t, bapp.accountKeeper.GetAccount(ctx, recipient))
+
+	// from the start time on they are
+	res = deliver(3, start)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	ctx = bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, coins, bapp.accountKeeper.GetAccount(ctx, recipient).GetCoins())
+}
+
+func TestPruningNothingKeepsHistory(t *testing.T) {
+	bapp, err := NewDemocoinAppWithOptions(log.NewNopLogger(), dbm.NewMemDB(), 0, PruningNothing)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	// change the account in a few more blocks
+	for height := int64(2); height <= 4; height++ {
+		bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+		_, _, sdkErr := bapp.bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+		require.Nil(t, sdkErr)
+		bapp.EndBlock(abci.RequestEndBlock{Height: height})
+		bapp.Commit()
+	}
+
+	for height, expected := range map[int64]int64{1: 100, 2: 101, 4: 103} {
+		res := bapp.Query(abci.RequestQuery{
+			Path:   "/store/acc/key",
+			Data:   auth.AddressStoreKey(addr),
+			Height: height,
+		})
+		require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+		var acc auth.Account
+		require.Nil(t, bapp.cdc.UnmarshalBinaryBare(res.Value, &acc))
+		require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", expected)}, acc.GetCoins(), "height %d", height)
+	}
+}
+
+func TestInvalidPruning(t *testing.T) {
+	bapp, err := NewDemocoinAppWithOptions(log.NewNopLogger(), dbm.NewMemDB(), 0, "sometimes")
+	require.NotNil(t, err)
+	require.Nil(t, bapp)
+}
+
+func TestSimulateTx(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	send := func(amt sdk.Coins) []byte {
+		return signTx(t, bapp, priv, auth.NewStdFee(200000, sdk.Coins{}), sdkbank.NewMsgSend(
+			[]sdkbank.Input{sdkbank.NewInput(addr, amt)},
+			[]sdkbank.Output{sdkbank.NewOutput(recipient, amt)},
+		))
+	}
+
+	gasUsed, err := bapp.SimulateTx(send(coins))
+	require.Nil(t, err)
+	require.True(t, gasUsed > 0)
+
+	// nothing was committed
+	ctx := bapp.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}, bapp.accountKeeper.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, uint64(0), bapp.accountKeeper.GetAccount(ctx, addr).GetSequence())
+	require.Nil(t, bapp.accountKeeper.GetAccount(ctx, recipient))
+
+	// failing messages are reported
+	_, err = bapp.SimulateTx(send(sdk.Coins{sdk.NewInt64Coin("foocoin", 1000)}))
+	require.NotNil(t, err)
+
+	// undecodable txs are rejected
+	_, err = bapp.SimulateTx([]byte("foo"))
+	require.NotNil(t, err)
+}
+
+// dummyMsg is a message of a module external to the app
+type dummyMsg struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Note   string         `json:"note"`
+}
+
+// nolint
+func (msg dummyMsg) Route() string                { return "dummy" }
+func (msg dummyMsg) Type() string                 { return "dummy" }
+func (msg dummyMsg) ValidateBasic() sdk.Error     { return nil }
+func (msg dummyMsg) GetSignBytes() []byte         { return sdk.MustSortJSON([]byte(`{}`)) }
+func (msg dummyMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+
+func TestMakeCodecExtraRegistrations(t *testing.T) {
+	var msg sdk.Msg = dummyMsg{Sender: sdk.AccAddress([]byte("sender")), Note: "hello"}
+
+	// the default codec doesn't know the external message
+	_, err := MakeDefaultCodec().MarshalBinaryLengthPrefixed(msg)
+	require.NotNil(t, err)
+
+	fns := append(DefaultRegisterCodecFns(), func(cdc *codec.Codec) {
+		cdc.RegisterConcrete(dummyMsg{}, "dummy/DummyMsg", nil)
+	})
+	cdc := MakeCodec(fns...)
+
+	bz, err := cdc.MarshalBinaryLengthPrefixed(msg)
+	require.Nil(t, err)
+	var decoded sdk.Msg
+	require.Nil(t, cdc.UnmarshalBinaryLengthPrefixed(bz, &decoded))
+	require.Equal(t, msg, decoded)
+
+	// the default registrations are kept
+	var acc auth.Account = &types.AppAccount{Name: "foobart"}
+	bz, err = cdc.MarshalBinaryBare(acc)
+	require.Nil(t, err)
+	var decodedAcc auth.Account
+	require.Nil(t, cdc.UnmarshalBinaryBare(bz, &decodedAcc))
+	require.Equal(t, "foobart", decodedAcc.(*types.AppAccount).Name)
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryBatch is the route of the batch querier
+const QueryBatch = "batch"
+
+// DefaultMaxBatchQueries is the default number of sub-queries a batch query
+// may hold
+const DefaultMaxBatchQueries = 50
+
+// BatchQuery is a custom query run as part of a batch, such as
+// /custom/acc/<address>
+type BatchQuery struct {
+	Path string `json:"path"`
+	Data []byte `json:"data"`
+}
+
+// BatchQueryResult is the result of a query of a batch, an error code and
+// log if it failed
+type BatchQueryResult struct {
+	Code  sdk.CodeType `json:"code"`
+	Log   string       `json:"log,omitempty"`
+	Value []byte       `json:"value,omitempty"`
+}
+
+// SetMaxBatchQueries sets the number of sub-queries a batch query may hold
+// on this node
+func (app *DemocoinApp) SetMaxBatchQueries(max int) {
+	app.maxBatchQueries = max
+}
+
+// NewBatchQuerier returns a querier running the JSON array of BatchQuery
+// given as the query data. The results are returned in the order of the
+// queries, a failing query doesn't fail the others.
+func NewBatchQuerier(cdc *codec.Codec, router func(route string) sdk.Querier, maxQueries func() int) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		var queries []BatchQuery
+		if err := cdc.UnmarshalJSON(req.Data, &queries); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("could not parse batch queries", err.Error()))
+		}
+		if max := maxQueries(); len(queries) > max {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("batch holds %d queries, at most %d are allowed", len(queries), max))
+		}
+
+		results := make([]BatchQueryResult, len(queries))
+		for i, query := range queries {
+			// stop as soon as the deadline passes rather than stalling the node
+			if err := checkQueryDeadline(ctx); err != nil {
+				return nil, err
+			}
+			bz, err := runBatchQuery(ctx, router, query, req.Height)
+			if err != nil {
+				results[i] = BatchQueryResult{Code: err.Code(), Log: err.ABCILog()}
+				continue
+			}
+			results[i] = BatchQueryResult{Code: sdk.CodeOK, Value: bz}
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, results)
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
+
+// runBatchQuery runs a single custom query of a batch, batches can't be
+// nested
+func runBatchQuery(ctx sdk.Context, router func(route string) sdk.Querier, query BatchQuery,
+	height int64) ([]byte, sdk.Error) {
+	parts := strings.Split(strings.Trim(query.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "custom" {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("expected a custom query path, got %s", query.Path))
+	}
+	if parts[1] == QueryBatch {
+		return nil, sdk.ErrUnknownRequest("batch queries can't be nested")
+	}
+
+	querier := router(parts[1])
+	if querier == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", parts[1]))
+	}
+	return querier(ctx, parts[2:], abci.RequestQuery{Path: query.Path, Data: query.Data, Height: height})
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func queryBatch(t *testing.T, bapp *DemocoinApp, queries []BatchQuery) abci.ResponseQuery {
+	data, err := bapp.cdc.MarshalJSON(queries)
+	require.Nil(t, err)
+	return bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s", QueryBatch), Data: data})
+}
+
+func TestBatchQuerier(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{Address: addr, Coins: coins}))
+
+	res := queryBatch(t, bapp, []BatchQuery{
+		{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, addr)},
+		{Path: fmt.Sprintf("/custom/%s/%s", pow.QuerierRoute, pow.QueryDifficulty)},
+		{Path: "/custom/foo"},
+	})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	var results []BatchQueryResult
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &results))
+	require.Len(t, results, 3)
+
+	// results come in the order of the queries
+	require.Equal(t, sdk.CodeOK, results[0].Code, results[0].Log)
+	var resCoins sdk.Coins
+	require.Nil(t, bapp.cdc.UnmarshalJSON(results[0].Value, &resCoins))
+	require.Equal(t, coins, resCoins)
+
+	require.Equal(t, sdk.CodeOK, results[1].Code, results[1].Log)
+	var difficulty uint64
+	require.Nil(t, bapp.cdc.UnmarshalJSON(results[1].Value, &difficulty))
+	require.Equal(t, pow.DefaultGenesis().Difficulty, difficulty)
+
+	// a failing query doesn't fail the batch
+	require.Equal(t, sdk.CodeUnknownRequest, results[2].Code)
+	require.Empty(t, results[2].Value)
+
+	// batches are capped
+	bapp.SetMaxBatchQueries(1)
+	res = queryBatch(t, bapp, []BatchQuery{
+		{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, addr)},
+		{Path: fmt.Sprintf("/custom/%s/%s", pow.QuerierRoute, pow.QueryDifficulty)},
+	})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), res.Code)
+}
+
+func TestBatchQuerierTimeout(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{Address: addr, Coins: coins}))
+
+	queries := make([]BatchQuery, 5000)
+	for i := range queries {
+		queries[i] = BatchQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, addr)}
+	}
+	bapp.SetMaxBatchQueries(len(queries))
+
+	// the batch aborts once the deadline passes
+	bapp.SetQueryTimeout(time.Nanosecond)
+	res := queryBatch(t, bapp, queries)
+	require.Equal(t, uint32(CodeQueryTimeout), res.Code, res.Log)
+	require.Empty(t, res.Value)
+
+	// and the node keeps serving queries
+	bapp.SetQueryTimeout(0)
+	res = queryBatch(t, bapp, queries)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	var results []BatchQueryResult
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &results))
+	require.Len(t, results, len(queries))
+}
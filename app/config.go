@@ -0,0 +1,21 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// default bech32 prefixes of the xpx network
+const (
+	Bech32PrefixAccAddr = "xpx"
+	Bech32PrefixValAddr = "xpxval"
+)
+
+// SetBech32Prefixes configures the bech32 prefixes of the sdk config. The
+// public key and consensus node prefixes are derived from the given ones.
+// It must be called before the config is sealed.
+func SetBech32Prefixes(accountPrefix, validatorPrefix string) {
+	config := sdk.GetConfig()
+	config.SetBech32PrefixForAccount(accountPrefix, accountPrefix+"pub")
+	config.SetBech32PrefixForValidator(validatorPrefix, validatorPrefix+"pub")
+	config.SetBech32PrefixForConsensusNode(accountPrefix+"cons", accountPrefix+"conspub")
+}
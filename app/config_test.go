@@ -0,0 +1,34 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSetBech32Prefixes(t *testing.T) {
+	SetBech32Prefixes(Bech32PrefixAccAddr, Bech32PrefixValAddr)
+
+	pk := ed25519.GenPrivKey().PubKey()
+	addr := sdk.AccAddress(pk.Address())
+	require.True(t, strings.HasPrefix(addr.String(), Bech32PrefixAccAddr+"1"), addr.String())
+
+	res, err := sdk.AccAddressFromBech32(addr.String())
+	require.Nil(t, err)
+	require.Equal(t, addr, res)
+
+	valAddr := sdk.ValAddress(pk.Address())
+	require.True(t, strings.HasPrefix(valAddr.String(), Bech32PrefixValAddr+"1"), valAddr.String())
+
+	resVal, err := sdk.ValAddressFromBech32(valAddr.String())
+	require.Nil(t, err)
+	require.Equal(t, valAddr, resVal)
+
+	// addresses with another prefix are rejected
+	_, err = sdk.AccAddressFromBech32(valAddr.String())
+	require.NotNil(t, err)
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Query answers custom queries for a past height against the state committed
+// at that height. Other queries are left to the base app.
+func (app *DemocoinApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	path := strings.Split(strings.Trim(req.Path, "/"), "/")
+	if req.Height <= 0 || req.Height >= app.LastBlockHeight() || path[0] != "custom" {
+		return app.BaseApp.Query(req)
+	}
+
+	bz, err := app.QueryAtHeight(req.Path, req.Data, req.Height)
+	if err != nil {
+		return err.QueryResult()
+	}
+	return abci.ResponseQuery{
+		Code:   uint32(sdk.CodeOK),
+		Value:  bz,
+		Height: req.Height,
+	}
+}
+
+// QueryAtHeight runs a custom query path, such as /custom/acc/<address>,
+// against the state committed at the given height. Past heights are only
+// kept when the app runs with PruningNothing.
+func (app *DemocoinApp) QueryAtHeight(path string, data []byte, height int64) ([]byte, sdk.Error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "custom" {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("expected a custom query path, got %s", path))
+	}
+
+	querier := app.QueryRouter().Route(parts[1])
+	if querier == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", parts[1]))
+	}
+
+	ctx, err := app.contextAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return querier(ctx, parts[2:], abci.RequestQuery{Path: path, Data: data, Height: height})
+}
+
+// BalanceAtHeight returns the coins held by the account at the given height
+func (app *DemocoinApp) BalanceAtHeight(addr sdk.AccAddress, height int64) (sdk.Coins, sdk.Error) {
+	bz, err := app.QueryAtHeight(fmt.Sprintf("/custom/%s/%s", QueryAccount, addr), nil, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var coins sdk.Coins
+	if err := codec.Cdc.UnmarshalJSON(bz, &coins); err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not unmarshal result", err.Error()))
+	}
+	return coins, nil
+}
+
+// contextAtHeight loads a read only context over the state committed at the
+// given height, failing if the height was pruned
+func (app *DemocoinApp) contextAtHeight(height int64) (sdk.Context, sdk.Error) {
+	if height <= 0 || height > app.LastBlockHeight() {
+		return sdk.Context{}, sdk.ErrUnknownRequest(
+			fmt.Sprintf("height %d is not committed, the latest height is %d", height, app.LastBlockHeight()))
+	}
+
+	cms, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		msg := fmt.Sprintf("state at height %d is not available, it was likely pruned", height)
+		if app.pruning != PruningNothing {
+			msg = fmt.Sprintf("%s (pruning is %q, run with %q to keep every height)", msg, app.pruning, PruningNothing)
+		}
+		return sdk.Context{}, sdk.ErrUnknownRequest(sdk.AppendMsgToErr(msg, err.Error()))
+	}
+
+	return sdk.NewContext(cms, abci.Header{Height: height}, true, app.Logger()), nil
+}
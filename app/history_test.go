@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// commitBlocksAddingCoins mints one foocoin to the address in each block up
+// to the last height
+func commitBlocksAddingCoins(t *testing.T, bapp *DemocoinApp, addr sdk.AccAddress, last int64) {
+	for height := bapp.LastBlockHeight() + 1; height <= last; height++ {
+		bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+		_, _, err := bapp.bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+		require.Nil(t, err)
+		bapp.EndBlock(abci.RequestEndBlock{Height: height})
+		bapp.Commit()
+	}
+}
+
+func TestQueryAtHeight(t *testing.T) {
+	bapp, err := NewDemocoinAppWithOptions(log.NewNopLogger(), dbm.NewMemDB(), 0, PruningNothing)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+	commitBlocksAddingCoins(t, bapp, addr, 4)
+
+	for height, expected := range map[int64]int64{1: 100, 2: 101, 3: 102, 4: 103} {
+		coins, err := bapp.BalanceAtHeight(addr, height)
+		require.Nil(t, err, "height %d", height)
+		require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", expected)}, coins, "height %d", height)
+
+		// the bank querier sees the supply as of that height too
+		res := bapp.Query(abci.RequestQuery{Path: "/custom/bank/supply/foocoin", Height: height})
+		require.True(t, res.IsOK(), res.Log)
+		var supply sdk.Coin
+		require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &supply))
+		require.Equal(t, sdk.NewInt64Coin("foocoin", expected), supply, "height %d", height)
+	}
+
+	// the auth querier honors the height of abci queries
+	res := bapp.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/custom/%s/%s", QueryAccount, addr),
+		Height: 2,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	var coins sdk.Coins
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &coins))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 101)}, coins)
+
+	// heights not yet committed are rejected
+	_, err = bapp.BalanceAtHeight(addr, 5)
+	require.NotNil(t, err)
+}
+
+func TestQueryAtPrunedHeight(t *testing.T) {
+	bapp, err := NewDemocoinAppWithOptions(log.NewNopLogger(), dbm.NewMemDB(), 0, PruningEverything)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{Address: addr}))
+	commitBlocksAddingCoins(t, bapp, addr, 4)
+
+	_, err = bapp.BalanceAtHeight(addr, 1)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "pruned")
+
+	coins, err := bapp.BalanceAtHeight(addr, 4)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)}, coins)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/distribution"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
+)
+
+// Invariant checks a property of the state, returning an error if it is broken
+type Invariant func(ctx sdk.Context) error
+
+// SupplyInvariant checks that the tracked total supply equals the coins held
+// by accounts, collected as fees, held by the community pool and bonded or
+// unbonding in simplestaking
+func SupplyInvariant(ak auth.AccountKeeper, fck auth.FeeCollectionKeeper,
+	bk bank.Keeper, sk simplestaking.Keeper, dk distribution.Keeper) Invariant {
+
+	return func(ctx sdk.Context) error {
+		total := fck.GetCollectedFees(ctx)
+		ak.IterateAccounts(ctx, func(acc auth.Account) bool {
+			total = total.Plus(acc.GetCoins())
+			return false
+		})
+		total = total.Plus(sk.GetBondedCoins(ctx))
+		total = total.Plus(sk.GetUnbondingCoins(ctx))
+		total = total.Plus(dk.GetCommunityPool(ctx))
+
+		supply := bk.GetSupply(ctx)
+		if !total.IsEqual(supply) {
+			return fmt.Errorf("total supply mismatch: tracked %v, found %v", supply, total)
+		}
+		return nil
+	}
+}
+
+func (app *DemocoinApp) runtimeInvariants() []Invariant {
+	return []Invariant{
+		SupplyInvariant(app.accountKeeper.AccountKeeper, app.feeCollectionKeeper, app.bankKeeper, app.stakingKeeper, app.distrKeeper),
+	}
+}
+
+// SetAssertInvariants sets whether a broken invariant halts the node, the
+// default, or is only logged so the chain keeps running
+func (app *DemocoinApp) SetAssertInvariants(assert bool) {
+	app.assertInvariants = assert
+}
+
+func (app *DemocoinApp) assertRuntimeInvariantsOnContext(ctx sdk.Context) {
+	logger := app.BaseApp.Logger().With("module", "invariants")
+	start := time.Now()
+	for _, inv := range app.runtimeInvariants() {
+		if err := inv(ctx); err != nil {
+			if app.assertInvariants {
+				panic(fmt.Errorf("invariant broken: %s", err))
+			}
+			logger.Error("Invariant broken", "height", ctx.BlockHeight(), "err", err)
+		}
+	}
+	diff := time.Since(start)
+	logger.Info("Asserted all invariants", "duration", diff)
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestSupplyInvariant(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100), sdk.NewInt64Coin("steak", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	header := abci.Header{Height: bapp.LastBlockHeight() + 1}
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := bapp.BaseApp.NewContext(false, header)
+	invariant := SupplyInvariant(bapp.accountKeeper.AccountKeeper, bapp.feeCollectionKeeper, bapp.bankKeeper, bapp.stakingKeeper, bapp.distrKeeper)
+	require.Nil(t, invariant(ctx))
+
+	// bonded coins are still part of the supply
+	_, sdkErr := bapp.stakingKeeper.Bond(ctx, addr, ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin("steak", 10))
+	require.Nil(t, sdkErr)
+	require.Nil(t, invariant(ctx))
+
+	// corrupt the balance of the account
+	acc := bapp.accountKeeper.GetAccount(ctx, addr)
+	require.Nil(t, acc.SetCoins(sdk.Coins{sdk.NewInt64Coin("foocoin", 200), sdk.NewInt64Coin("steak", 90)}))
+	bapp.accountKeeper.SetAccount(ctx, acc)
+	require.NotNil(t, invariant(ctx))
+
+	require.Panics(t, func() {
+		bapp.EndBlock(abci.RequestEndBlock{})
+	})
+}
+
+func TestBrokenInvariantLogged(t *testing.T) {
+	var buf bytes.Buffer
+	bapp, err := NewDemocoinApp(log.NewTMLogger(log.NewSyncWriter(&buf)), dbm.NewMemDB(), 1)
+	require.Nil(t, err)
+	bapp.SetAssertInvariants(false)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	header := abci.Header{Height: bapp.LastBlockHeight() + 1}
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := bapp.BaseApp.NewContext(false, header)
+	acc := bapp.accountKeeper.GetAccount(ctx, addr)
+	require.Nil(t, acc.SetCoins(sdk.Coins{sdk.NewInt64Coin("foocoin", 200)}))
+	bapp.accountKeeper.SetAccount(ctx, acc)
+
+	// the broken invariant is logged and the block still ends
+	require.NotPanics(t, func() {
+		bapp.EndBlock(abci.RequestEndBlock{})
+	})
+	require.Contains(t, buf.String(), "Invariant broken")
+	require.Contains(t, buf.String(), "total supply mismatch")
+}
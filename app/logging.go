@@ -0,0 +1,54 @@
+package app
+
+import (
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// simulateKey marks the context of a simulated tx so that handlers, which
+// don't receive the simulate flag, can tell it apart from CheckTx and DeliverTx
+type simulateKey struct{}
+
+func isSimulate(ctx sdk.Context) bool {
+	simulate, _ := ctx.Value(simulateKey{}).(bool)
+	return simulate
+}
+
+// NewLoggingAnteHandler wraps an AnteHandler and logs the txs it rejects.
+// Simulated txs are not logged.
+func NewLoggingAnteHandler(logger log.Logger, ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		newCtx, res, abort := ah(ctx, tx, simulate)
+		if abort && !res.IsOK() && !simulate {
+			for _, msg := range tx.GetMsgs() {
+				logRejected(logger, msg, res)
+			}
+		}
+		if !newCtx.IsZero() {
+			newCtx = newCtx.WithValue(simulateKey{}, simulate)
+		}
+		return newCtx, res, abort
+	}
+}
+
+// NewLoggingHandler wraps a Handler and logs the msgs it rejects. The context
+// must have gone through NewLoggingAnteHandler to skip simulated txs.
+func NewLoggingHandler(logger log.Logger, h sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		res := h(ctx, msg)
+		if !res.IsOK() && !isSimulate(ctx) {
+			logRejected(logger, msg, res)
+		}
+		return res
+	}
+}
+
+func logRejected(logger log.Logger, msg sdk.Msg, res sdk.Result) {
+	var signer string
+	if signers := msg.GetSigners(); len(signers) > 0 {
+		signer = signers[0].String()
+	}
+	logger.Info("Rejected tx", "route", msg.Route(), "type", msg.Type(), "signer", signer,
+		"codespace", string(res.Codespace), "code", uint32(res.Code))
+}
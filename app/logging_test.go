@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestRejectedTxLogged(t *testing.T) {
+	var buf bytes.Buffer
+	bapp, err := NewDemocoinApp(log.NewTMLogger(log.NewSyncWriter(&buf)), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 10)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+
+	// sending more than the balance is rejected by the bank handler
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}
+	msg := sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(recipient, coins)},
+	)
+	fee := auth.NewStdFee(200000, sdk.Coins{})
+	txBytes := signTx(t, bapp, priv, fee, msg)
+
+	// simulations are not logged
+	buf.Reset()
+	tx := auth.NewStdTx([]sdk.Msg{msg}, fee, []auth.StdSignature{{PubKey: priv.PubKey()}}, "")
+	res := bapp.Simulate(txBytes, tx)
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code, res.Log)
+	require.NotContains(t, buf.String(), "Rejected tx")
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	dres := bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(sdk.CodeInsufficientCoins), dres.Code, dres.Log)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	logged := buf.String()
+	require.Contains(t, logged, "Rejected tx")
+	require.Contains(t, logged, "type=send")
+	require.Contains(t, logged, "signer="+addr.String())
+	require.Contains(t, logged, fmt.Sprintf("code=%d", sdk.CodeInsufficientCoins))
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// outcomes of a handled message in the metrics
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+type msgCounterKey struct {
+	route   string
+	msgType string
+	ok      bool
+}
+
+// MsgMetrics counts the messages handled per route, type and outcome
+type MsgMetrics struct {
+	mtx    sync.Mutex
+	counts map[msgCounterKey]uint64
+}
+
+// NewMsgMetrics returns empty message metrics
+func NewMsgMetrics() *MsgMetrics {
+	return &MsgMetrics{
+		counts: make(map[msgCounterKey]uint64),
+	}
+}
+
+// record counts a handled message. The map only allocates the first time a
+// key is seen.
+func (m *MsgMetrics) record(msg sdk.Msg, ok bool) {
+	key := msgCounterKey{msg.Route(), msg.Type(), ok}
+	m.mtx.Lock()
+	m.counts[key]++
+	m.mtx.Unlock()
+}
+
+// Snapshot returns the counters keyed by "route/type/outcome"
+func (m *MsgMetrics) Snapshot() map[string]uint64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	snapshot := make(map[string]uint64, len(m.counts))
+	for key, count := range m.counts {
+		outcome := OutcomeError
+		if key.ok {
+			outcome = OutcomeSuccess
+		}
+		snapshot[fmt.Sprintf("%s/%s/%s", key.route, key.msgType, outcome)] = count
+	}
+	return snapshot
+}
+
+// msgCount is a counter of the metrics as it is persisted
+type msgCount struct {
+	Route   string `json:"route"`
+	MsgType string `json:"msg_type"`
+	OK      bool   `json:"ok"`
+	Count   uint64 `json:"count"`
+}
+
+// metricsKey is where the counters are flushed in the app database. It is
+// outside of the committed stores so the counters, which differ between
+// nodes, don't change the app hash.
+var metricsKey = []byte("xpx/metrics")
+
+// flush writes the counters to the database
+func (m *MsgMetrics) flush(cdc *codec.Codec, db dbm.DB) {
+	m.mtx.Lock()
+	counts := make([]msgCount, 0, len(m.counts))
+	for key, count := range m.counts {
+		counts = append(counts, msgCount{key.route, key.msgType, key.ok, count})
+	}
+	m.mtx.Unlock()
+
+	db.SetSync(metricsKey, cdc.MustMarshalBinaryBare(counts))
+}
+
+// load adds the counters flushed to the database
+func (m *MsgMetrics) load(cdc *codec.Codec, db dbm.DB) error {
+	bz := db.Get(metricsKey)
+	if bz == nil {
+		return nil
+	}
+	var counts []msgCount
+	if err := cdc.UnmarshalBinaryBare(bz, &counts); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, count := range counts {
+		m.counts[msgCounterKey{count.Route, count.MsgType, count.OK}] += count.Count
+	}
+	return nil
+}
+
+// NewMetricsHandler wraps a Handler and counts the msgs it handles. The
+// context must have gone through NewLoggingAnteHandler to skip simulated
+// txs.
+func NewMetricsHandler(m *MsgMetrics, h sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		res := h(ctx, msg)
+		if !isSimulate(ctx) {
+			m.record(msg, res.IsOK())
+		}
+		return res
+	}
+}
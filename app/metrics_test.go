@@ -0,0 +1,132 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+func TestMsgMetrics(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	recipient := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+	fee := auth.NewStdFee(200000, sdk.Coins{})
+
+	send := func(amount int64) sdk.Msg {
+		coins := sdk.Coins{sdk.NewInt64Coin("foocoin", amount)}
+		return sdkbank.NewMsgSend([]sdkbank.Input{sdkbank.NewInput(addr, coins)}, []sdkbank.Output{sdkbank.NewOutput(recipient, coins)})
+	}
+	burn := func(amount int64) sdk.Msg {
+		return bank.NewMsgBurn(addr, sdk.Coins{sdk.NewInt64Coin("foocoin", amount)})
+	}
+
+	// simulations are not counted
+	_, err = bapp.SimulateTx(signTx(t, bapp, priv, fee, send(10)))
+	require.Nil(t, err)
+	require.Empty(t, bapp.Metrics())
+
+	// a block per tx so each is signed with the committed sequence
+	for _, msg := range []sdk.Msg{send(10), send(10), send(1000), burn(10), burn(1000)} {
+		bapp.BeginBlock(abci.RequestBeginBlock{})
+		bapp.DeliverTx(signTx(t, bapp, priv, fee, msg))
+		bapp.EndBlock(abci.RequestEndBlock{})
+		bapp.Commit()
+	}
+
+	require.Equal(t, map[string]uint64{
+		"bank/send/" + OutcomeSuccess: 2,
+		"bank/send/" + OutcomeError:   1,
+		"bank/burn/" + OutcomeSuccess: 1,
+		"bank/burn/" + OutcomeError:   1,
+	}, bapp.Metrics())
+}
+
+func TestMetricsFlush(t *testing.T) {
+	db := dbm.NewMemDB()
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	bapp.DeliverTx(signTx(t, bapp, priv, auth.NewStdFee(200000, sdk.Coins{}),
+		bank.NewMsgBurn(addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})))
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	// the counters stay in memory until flushed
+	reopened, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+	require.Empty(t, reopened.Metrics())
+
+	bapp.Flush()
+	require.NotNil(t, db.Get(metricsKey))
+
+	reopened, err = NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+	require.Equal(t, map[string]uint64{"bank/burn/" + OutcomeSuccess: 1}, reopened.Metrics())
+}
+
+func TestMetricsFlushedOnClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := dbm.NewGoLevelDB("app", dir)
+	require.Nil(t, err)
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	bapp.DeliverTx(signTx(t, bapp, priv, auth.NewStdFee(200000, sdk.Coins{}),
+		bank.NewMsgBurn(addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})))
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+	counters := map[string]uint64{"bank/burn/" + OutcomeSuccess: 1}
+	require.Equal(t, counters, bapp.Metrics())
+
+	// closing twice is harmless
+	bapp.Close()
+	bapp.Close()
+
+	// the reopened app starts from the flushed counters
+	db, err = dbm.NewGoLevelDB("app", dir)
+	require.Nil(t, err)
+	reopened, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+	defer reopened.Close()
+	require.Equal(t, int64(2), reopened.LastBlockHeight())
+	require.Equal(t, counters, reopened.Metrics())
+}
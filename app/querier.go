@@ -0,0 +1,264 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/account"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/blockgas"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/distribution"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
+)
+
+// query routes supported by the app
+const (
+	QueryAccount = "acc"
+	QueryApp     = "app"
+	QueryAuth    = "auth"
+	QueryProfile = "profile"
+
+	QueryConsensusParams = "consensus_params"
+
+	// paths under QueryApp
+	QueryModules        = "modules"
+	QueryModuleAccounts = "module_accounts"
+
+	// paths under QueryAuth
+	QueryAccountMeta = "account_meta"
+)
+
+// ModuleInfo describes a module loaded by the app and the store it uses
+type ModuleInfo struct {
+	Name     string `json:"name"`
+	StoreKey string `json:"store_key"`
+}
+
+// NewAccountQuerier returns the coins of the account at the bech32 address
+// given as the query path
+func NewAccountQuerier(cdc *codec.Codec, ak auth.AccountKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 1 {
+			return nil, sdk.ErrUnknownRequest("expected an account address")
+		}
+
+		addr, err := sdk.AccAddressFromBech32(path[0])
+		if err != nil {
+			return nil, sdk.ErrInvalidAddress(err.Error())
+		}
+
+		acc := ak.GetAccount(ctx, addr)
+		if acc == nil {
+			return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, acc.GetCoins())
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
+
+// AccountMeta is the account number and sequence clients need to sign txs
+type AccountMeta struct {
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	Exists        bool   `json:"exists"`
+}
+
+// NewAuthQuerier returns the account number and sequence of the account at
+// the bech32 address given after the account_meta path. Unknown accounts
+// return zeros and exists false.
+func NewAuthQuerier(cdc *codec.Codec, ak auth.AccountKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 || path[0] != QueryAccountMeta {
+			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
+		}
+		if len(path) != 2 {
+			return nil, sdk.ErrUnknownRequest("expected an account address")
+		}
+
+		addr, err := sdk.AccAddressFromBech32(path[1])
+		if err != nil {
+			return nil, sdk.ErrInvalidAddress(err.Error())
+		}
+
+		var meta AccountMeta
+		if acc := ak.GetAccount(ctx, addr); acc != nil {
+			meta = AccountMeta{acc.GetAccountNumber(), acc.GetSequence(), true}
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, meta)
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
+
+// AccountProfile gathers what wallets show about an account
+type AccountProfile struct {
+	Address       sdk.AccAddress                  `json:"address"`
+	Coins         sdk.Coins                       `json:"coins"`
+	AccountNumber uint64                          `json:"account_number"`
+	Sequence      uint64                          `json:"sequence"`
+	Name          string                          `json:"name"`
+	Frozen        bool                            `json:"frozen"`
+	Delegations   []simplestaking.DelegationEntry `json:"delegations"`
+}
+
+// NewProfileQuerier returns the profile of the account at the bech32
+// address given as the query path
+func NewProfileQuerier(cdc *codec.Codec, ak auth.AccountKeeper, bk bank.Keeper, nk account.Keeper,
+	sk simplestaking.Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 1 {
+			return nil, sdk.ErrUnknownRequest("expected an account address")
+		}
+
+		addr, err := sdk.AccAddressFromBech32(path[0])
+		if err != nil {
+			return nil, sdk.ErrInvalidAddress(err.Error())
+		}
+
+		acc := ak.GetAccount(ctx, addr)
+		if acc == nil {
+			return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+		}
+
+		// unnamed accounts have an empty name
+		name, _ := nk.GetName(ctx, addr)
+
+		profile := AccountProfile{
+			Address:       addr,
+			Coins:         acc.GetCoins(),
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+			Name:          name,
+			Frozen:        bk.IsFrozen(ctx, addr),
+			Delegations:   sk.GetDelegatorDelegations(ctx, addr),
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, profile)
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
+
+// names of the modules holding coins on behalf of users
+const (
+	ModuleFeeCollector  = "fee_collector"
+	ModuleCommunityPool = "distribution"
+	ModuleBondedPool    = "simplestaking"
+)
+
+// ModuleAddress derives the address of a module account from the module
+// name
+func ModuleAddress(name string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(name)))
+}
+
+// ModuleAccount is the address and balance of the coins held by a module
+type ModuleAccount struct {
+	Name    string         `json:"name"`
+	Address sdk.AccAddress `json:"address"`
+	Coins   sdk.Coins      `json:"coins"`
+}
+
+// ModuleAccounts returns the accounts of the modules holding coins: the
+// collected fees, the community pool and the bonded stake
+func ModuleAccounts(ctx sdk.Context, fck auth.FeeCollectionKeeper, dk distribution.Keeper,
+	sk simplestaking.Keeper) []ModuleAccount {
+	return []ModuleAccount{
+		{ModuleFeeCollector, ModuleAddress(ModuleFeeCollector), fck.GetCollectedFees(ctx)},
+		{ModuleCommunityPool, ModuleAddress(ModuleCommunityPool), dk.GetCommunityPool(ctx)},
+		{ModuleBondedPool, ModuleAddress(ModuleBondedPool), sk.GetBondedCoins(ctx)},
+	}
+}
+
+// NewAppQuerier returns information about the app itself: the loaded
+// modules and the accounts of the modules holding coins
+func NewAppQuerier(cdc *codec.Codec, modules []ModuleInfo,
+	moduleAccounts func(ctx sdk.Context) []ModuleAccount) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("unknown app query endpoint")
+		}
+
+		var res interface{}
+		switch path[0] {
+		case QueryModules:
+			res = modules
+		case QueryModuleAccounts:
+			res = moduleAccounts(ctx)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown app query endpoint")
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, res)
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
+
+// mainConsensusParamsKey is where BaseApp persists the consensus params of
+// the chain in the main store
+var mainConsensusParamsKey = []byte("consensus_params")
+
+// ConsensusParams are the consensus params in effect at a height. Params is
+// what Tendermint enforces and is nil if the chain was started without
+// consensus params. Block gas is limited by the app itself, BlockMaxGas is
+// its MaxBlockGas param, zero meaning no limit.
+type ConsensusParams struct {
+	ChainID     string                `json:"chain_id"`
+	Height      int64                 `json:"height"`
+	Params      *abci.ConsensusParams `json:"params"`
+	BlockMaxGas uint64                `json:"block_max_gas"`
+}
+
+// NewConsensusParamsQuerier returns the consensus params in effect at the
+// height of the query context
+func NewConsensusParamsQuerier(cdc *codec.Codec, mainKey sdk.StoreKey, bgk blockgas.Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) != 0 {
+			return nil, sdk.ErrUnknownRequest("unknown consensus params query endpoint")
+		}
+
+		// query contexts don't carry the params, they are read from the
+		// store BaseApp keeps them in
+		params := ctx.ConsensusParams()
+		if params == nil {
+			if bz := ctx.KVStore(mainKey).Get(mainConsensusParamsKey); bz != nil {
+				params = &abci.ConsensusParams{}
+				if err := proto.Unmarshal(bz, params); err != nil {
+					return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not decode consensus params", err.Error()))
+				}
+			}
+		}
+
+		res := ConsensusParams{
+			ChainID:     ctx.ChainID(),
+			Height:      ctx.BlockHeight(),
+			Params:      params,
+			BlockMaxGas: bgk.GetParams(ctx).MaxBlockGas,
+		}
+
+		bz, err := codec.MarshalJSONIndent(cdc, res)
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestAccountQuerier(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	acc := bapp.accountKeeper.NewAccountWithAddress(ctx, addr)
+	require.Nil(t, acc.SetCoins(coins))
+	bapp.accountKeeper.SetAccount(ctx, acc)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	res := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, addr)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	var resCoins sdk.Coins
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &resCoins))
+	require.Equal(t, coins, resCoins)
+
+	// unknown accounts return an error
+	unknown := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, unknown)})
+	require.Equal(t, uint32(sdk.CodeUnknownAddress), res.Code)
+
+	// malformed addresses are rejected
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryAccount, "foo")})
+	require.Equal(t, uint32(sdk.CodeInvalidAddress), res.Code)
+}
+
+func TestAuthQuerierAccountMeta(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	acc := bapp.accountKeeper.NewAccountWithAddress(ctx, addr)
+	require.Nil(t, acc.SetSequence(acc.GetSequence()+1))
+	bapp.accountKeeper.SetAccount(ctx, acc)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	res := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s/%s", QueryAuth, QueryAccountMeta, addr)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	var meta AccountMeta
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &meta))
+	require.Equal(t, AccountMeta{acc.GetAccountNumber(), 1, true}, meta)
+
+	// unknown accounts return zeros
+	unknown := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s/%s", QueryAuth, QueryAccountMeta, unknown)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &meta))
+	require.Equal(t, AccountMeta{}, meta)
+
+	// malformed addresses are rejected
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s/%s", QueryAuth, QueryAccountMeta, "foo")})
+	require.Equal(t, uint32(sdk.CodeInvalidAddress), res.Code)
+}
+
+func TestAppQuerierModules(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+	require.Nil(t, setGenesis(bapp, "ice-cold"))
+
+	res := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryApp, QueryModules)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	var modules []ModuleInfo
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &modules))
+	names := make(map[string]string)
+	for _, module := range modules {
+		names[module.Name] = module.StoreKey
+	}
+	for _, name := range []string{"bank", "ibc", "pow", "cool", "simplestaking", "distribution", "feegrant", "blockgas"} {
+		require.Contains(t, names, name)
+		require.NotEmpty(t, names[name], name)
+	}
+
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryApp, "foo")})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), res.Code)
+}
+
+func TestProfileQuerier(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	val := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	require.Nil(t, setGenesis(bapp, "ice-cold",
+		auth.BaseAccount{Address: addr, Coins: sdk.Coins{sdk.NewInt64Coin("steak", 100)}},
+		auth.BaseAccount{Address: val, Coins: sdk.Coins{sdk.NewInt64Coin("steak", 100)}},
+	))
+
+	bapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	_, sdkErr := bapp.stakingKeeper.Bond(ctx, val, ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin("steak", 10))
+	require.Nil(t, sdkErr)
+	_, sdkErr = bapp.stakingKeeper.Delegate(ctx, addr, val, sdk.NewInt64Coin("steak", 30))
+	require.Nil(t, sdkErr)
+	bapp.bankKeeper.SetFrozen(ctx, addr, true)
+	bapp.EndBlock(abci.RequestEndBlock{})
+	bapp.Commit()
+
+	res := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryProfile, addr)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+
+	var profile AccountProfile
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &profile))
+	require.Equal(t, addr, profile.Address)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 70)}, profile.Coins)
+	require.Equal(t, uint64(0), profile.AccountNumber)
+	require.Equal(t, uint64(0), profile.Sequence)
+	require.Equal(t, "foobart", profile.Name)
+	require.True(t, profile.Frozen)
+	require.Equal(t, []simplestaking.DelegationEntry{{val, sdk.NewInt64Coin("steak", 30)}}, profile.Delegations)
+
+	// unknown accounts return an error
+	unknown := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryProfile, unknown)})
+	require.Equal(t, uint32(sdk.CodeUnknownAddress), res.Code)
+}
+
+func TestModuleAccountsQuerier(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 5)}
+	require.Nil(t, setGenesis(bapp, "ice-cold", auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100), sdk.NewInt64Coin("steak", 100)},
+	}))
+
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	txBytes := signTx(t, bapp, priv, auth.NewStdFee(200000, fees), sdkbank.NewMsgSend(
+		[]sdkbank.Input{sdkbank.NewInput(addr, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()), coins)},
+	))
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 1})
+	bapp.Commit()
+
+	res2 := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s/%s", QueryApp, QueryModuleAccounts)})
+	require.Equal(t, uint32(sdk.CodeOK), res2.Code, res2.Log)
+	var accounts []ModuleAccount
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res2.Value, &accounts))
+
+	balances := make(map[string]sdk.Coins)
+	for _, acc := range accounts {
+		require.Equal(t, ModuleAddress(acc.Name), acc.Address)
+		balances[acc.Address.String()] = acc.Coins
+	}
+	require.Equal(t, fees, balances[ModuleAddress(ModuleFeeCollector).String()])
+
+	// addresses are derived from the module names alone
+	require.Equal(t, ModuleAddress(ModuleFeeCollector), ModuleAddress("fee_collector"))
+	require.NotEqual(t, ModuleAddress(ModuleFeeCollector), ModuleAddress(ModuleCommunityPool))
+}
+
+func TestConsensusParamsQuerier(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	genesisState := types.DefaultGenesisState()
+	genesisState.BlockGasGenesis.Params.MaxBlockGas = 5000000
+	stateBytes, err := bapp.cdc.MarshalJSON(genesisState)
+	require.Nil(t, err)
+	bapp.InitChain(abci.RequestInitChain{ChainId: "xpx-test", AppStateBytes: stateBytes})
+	bapp.Commit()
+
+	res := bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s", QueryConsensusParams)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	var params ConsensusParams
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &params))
+	require.Equal(t, uint64(5000000), params.BlockMaxGas)
+
+	// param changes show from the next query on
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := bapp.BaseApp.NewContext(false, abci.Header{})
+	bgParams := bapp.blockGasKeeper.GetParams(ctx)
+	bgParams.MaxBlockGas = 1000000
+	bapp.blockGasKeeper.SetParams(ctx, bgParams)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 1})
+	bapp.Commit()
+
+	res = bapp.Query(abci.RequestQuery{Path: fmt.Sprintf("/custom/%s", QueryConsensusParams)})
+	require.Equal(t, uint32(sdk.CodeOK), res.Code, res.Log)
+	require.Nil(t, bapp.cdc.UnmarshalJSON(res.Value, &params))
+	require.Equal(t, uint64(1000000), params.BlockMaxGas)
+	require.Equal(t, int64(1), params.Height)
+}
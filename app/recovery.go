@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultCodespace is the codespace of the errors raised by the app itself
+const DefaultCodespace sdk.CodespaceType = "app"
+
+// app errors reserve the 100-199 code range
+const (
+	CodeInternalPanic sdk.CodeType = 100
+	CodeQueryTimeout  sdk.CodeType = 101
+)
+
+// ErrInternalPanic is returned for msgs whose handler panicked
+func ErrInternalPanic(codespace sdk.CodespaceType, recovered interface{}) sdk.Error {
+	return sdk.NewError(codespace, CodeInternalPanic, fmt.Sprintf("internal panic: %v", recovered))
+}
+
+// NewRecoveryHandler wraps a Handler and turns its panics into an
+// ErrInternalPanic result, logging the stack. Out of gas panics are left to
+// the base app, which accounts for them.
+func NewRecoveryHandler(logger log.Logger, h sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (res sdk.Result) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if _, ok := r.(sdk.ErrorOutOfGas); ok {
+				panic(r)
+			}
+			logger.Error("Recovered from handler panic", "route", msg.Route(), "type", msg.Type(),
+				"panic", fmt.Sprintf("%v", r), "stack", string(debug.Stack()))
+			res = ErrInternalPanic(DefaultCodespace, r).Result()
+		}()
+		return h(ctx, msg)
+	}
+}
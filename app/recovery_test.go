@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestRecoveryHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMLogger(log.NewSyncWriter(&buf))
+	msg := dummyMsg{Sender: sdk.AccAddress([]byte("sender"))}
+	ctx := sdk.Context{}
+
+	h := NewRecoveryHandler(logger, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		panic("boom")
+	})
+	res := h(ctx, msg)
+	require.Equal(t, CodeInternalPanic, res.Code)
+	require.Equal(t, DefaultCodespace, res.Codespace)
+	require.Contains(t, res.Log, "boom")
+	require.Contains(t, buf.String(), "Recovered from handler panic")
+
+	// results of handlers that don't panic are untouched
+	h = NewRecoveryHandler(logger, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		return sdk.ErrUnknownRequest("nope").Result()
+	})
+	require.Equal(t, sdk.CodeUnknownRequest, h(ctx, msg).Code)
+
+	// out of gas is left to the base app
+	h = NewRecoveryHandler(logger, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		panic(sdk.ErrorOutOfGas{Descriptor: "test"})
+	})
+	require.Panics(t, func() { h(ctx, msg) })
+}
+
+func TestPanickingHandlerFailsTx(t *testing.T) {
+	logger := log.NewNopLogger()
+	cdc := MakeCodec(codec.RegisterCrypto, sdk.RegisterCodec, func(cdc *codec.Codec) {
+		cdc.RegisterConcrete(dummyMsg{}, "dummy/DummyMsg", nil)
+	})
+
+	key := sdk.NewKVStoreKey("dummy")
+	bapp := bam.NewBaseApp("test", logger, dbm.NewMemDB(), auth.DefaultTxDecoder(cdc))
+	bapp.MountStores(key)
+	bapp.Router().AddRoute("dummy", NewRecoveryHandler(logger, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx.KVStore(key).Set([]byte("written"), []byte{0x01})
+		panic("boom")
+	}))
+	require.Nil(t, bapp.LoadLatestVersion(key))
+	bapp.InitChain(abci.RequestInitChain{})
+
+	tx := auth.NewStdTx([]sdk.Msg{dummyMsg{Sender: sdk.AccAddress([]byte("sender"))}},
+		auth.NewStdFee(200000, sdk.Coins{}), nil, "")
+	txBytes, err := auth.DefaultTxEncoder(cdc)(tx)
+	require.Nil(t, err)
+
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := bapp.DeliverTx(txBytes)
+	require.Equal(t, uint32(CodeInternalPanic), res.Code, res.Log)
+	require.Equal(t, string(DefaultCodespace), res.Codespace)
+	bapp.EndBlock(abci.RequestEndBlock{Height: 1})
+	bapp.Commit()
+
+	// the state written before the panic is discarded
+	ctx := bapp.NewContext(true, abci.Header{})
+	require.False(t, ctx.KVStore(key).Has([]byte("written")))
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+)
+
+// SnapshotHeader starts a snapshot, it is followed by the pairs of the
+// snapshot and an empty pair ending them
+type SnapshotHeader struct {
+	AppVersion uint64 `json:"app_version"`
+	Height     int64  `json:"height"`
+}
+
+// SnapshotPair is a key and its value in the app database. The entries are
+// copied as is, so the restored stores keep their versions and hashes and
+// the app hash matches the one of the chain.
+type SnapshotPair struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// maxSnapshotPairSize bounds the size of a pair read from a snapshot
+const maxSnapshotPairSize = 1 << 24
+
+// restoreBatchSize is the number of pairs written to the database at once
+// while restoring a snapshot
+const restoreBatchSize = 1000
+
+// WriteSnapshot writes the app state as of the last committed height to w.
+// Only the latest version of every store is written, streamed store by
+// store. The app must not commit blocks while the snapshot is taken.
+func (app *DemocoinApp) WriteSnapshot(w io.Writer) (height int64, err error) {
+	height = app.LastBlockHeight()
+	if height == 0 {
+		return 0, fmt.Errorf("no state committed yet")
+	}
+
+	bw := bufio.NewWriter(w)
+	write := func(o interface{}) error {
+		bz, err := app.cdc.MarshalBinaryLengthPrefixed(o)
+		if err != nil {
+			return err
+		}
+		_, err = bw.Write(bz)
+		return err
+	}
+
+	if err := write(SnapshotHeader{AppVersion: types.AppStateVersion, Height: height}); err != nil {
+		return 0, err
+	}
+	for _, key := range []string{"s/latest", fmt.Sprintf("s/%d", height)} {
+		if err := write(SnapshotPair{[]byte(key), app.db.Get([]byte(key))}); err != nil {
+			return 0, err
+		}
+	}
+	for _, key := range app.storeKeys() {
+		if _, ok := key.(*sdk.KVStoreKey); !ok {
+			continue
+		}
+		err := iterateLatestStoreVersion(app.db, []byte("s/k:"+key.Name()+"/"), height, func(pair SnapshotPair) error {
+			return write(pair)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := write(SnapshotPair{}); err != nil {
+		return 0, err
+	}
+	return height, bw.Flush()
+}
+
+// iterateLatestStoreVersion calls fn with the database entries of the store
+// under prefix making up its version at height: the root of the version and
+// the nodes it reaches, which are the nodes not orphaned by a later version.
+func iterateLatestStoreVersion(db dbm.DB, prefix []byte, height int64, fn func(pair SnapshotPair) error) error {
+	root := make([]byte, 9)
+	root[0] = 'r'
+	binary.BigEndian.PutUint64(root[1:], uint64(height))
+	rootKey := append(append([]byte{}, prefix...), root...)
+	if err := fn(SnapshotPair{rootKey, db.Get(rootKey)}); err != nil {
+		return err
+	}
+
+	// orphan keys end in the hash of the orphaned node
+	orphans := make(map[string]bool)
+	iter := dbm.IteratePrefix(db, append(append([]byte{}, prefix...), 'o'))
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		orphans[string(key[len(key)-32:])] = true
+	}
+	iter.Close()
+
+	nodePrefix := append(append([]byte{}, prefix...), 'n')
+	iter = dbm.IteratePrefix(db, nodePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if orphans[string(iter.Key()[len(nodePrefix):])] {
+			continue
+		}
+		if err := fn(SnapshotPair{iter.Key(), iter.Value()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSnapshot writes the snapshot read from r into an empty database,
+// so an app loading the database resumes from the snapshot height instead of
+// replaying the chain from genesis. Snapshots of another app version are
+// rejected, and the restored database must load at the snapshot height.
+// The database is closed once restored.
+func RestoreSnapshot(cdc *codec.Codec, db dbm.DB, r io.Reader) (height int64, err error) {
+	br := bufio.NewReader(r)
+	var header SnapshotHeader
+	if _, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &header, maxSnapshotPairSize); err != nil {
+		return 0, fmt.Errorf("failed to read snapshot header: %v", err)
+	}
+	if header.AppVersion != types.AppStateVersion {
+		return 0, fmt.Errorf("snapshot of app version %d can't be restored by app version %d",
+			header.AppVersion, types.AppStateVersion)
+	}
+	if header.Height <= 0 {
+		return 0, fmt.Errorf("invalid snapshot height %d", header.Height)
+	}
+
+	iter := db.Iterator(nil, nil)
+	empty := !iter.Valid()
+	iter.Close()
+	if !empty {
+		return 0, fmt.Errorf("snapshots can only be restored into an empty database")
+	}
+
+	batch, n := db.NewBatch(), 0
+	for {
+		var pair SnapshotPair
+		if _, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &pair, maxSnapshotPairSize); err != nil {
+			return 0, fmt.Errorf("failed to read snapshot: %v", err)
+		}
+		if len(pair.Key) == 0 {
+			break
+		}
+		batch.Set(pair.Key, pair.Value)
+		n++
+		if n%restoreBatchSize == 0 {
+			batch.Write()
+			batch = db.NewBatch()
+		}
+	}
+	batch.WriteSync()
+
+	dapp, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer dapp.Close()
+	if restored := dapp.LastBlockHeight(); restored != header.Height {
+		return 0, fmt.Errorf("restored state is at height %d, the snapshot was taken at height %d",
+			restored, header.Height)
+	}
+	return header.Height, nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	bapp, err := NewDemocoinApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+	require.Nil(t, err)
+
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	baseAcc := auth.BaseAccount{
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100)},
+	}
+	require.Nil(t, setGenesis(bapp, "ice-cold", baseAcc))
+	commitBlocksAddingCoins(t, bapp, addr, 3)
+
+	var buf bytes.Buffer
+	height, err := bapp.WriteSnapshot(&buf)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), height)
+	snapshot := buf.Bytes()
+
+	// only the latest version is copied, the previous versions are left out
+	var header SnapshotHeader
+	r := bytes.NewReader(snapshot)
+	_, err = bapp.cdc.UnmarshalBinaryLengthPrefixedReader(r, &header, maxSnapshotPairSize)
+	require.Nil(t, err)
+	require.Equal(t, SnapshotHeader{types.AppStateVersion, 3}, header)
+	pairs := 0
+	for {
+		var pair SnapshotPair
+		_, err = bapp.cdc.UnmarshalBinaryLengthPrefixedReader(r, &pair, maxSnapshotPairSize)
+		require.Nil(t, err)
+		if len(pair.Key) == 0 {
+			break
+		}
+		pairs++
+	}
+	entries := 0
+	iter := bapp.db.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		entries++
+	}
+	iter.Close()
+	require.True(t, pairs < entries)
+
+	// the restored app resumes at the snapshot height with the same state
+	db := dbm.NewMemDB()
+	height, err = RestoreSnapshot(bapp.cdc, db, bytes.NewReader(snapshot))
+	require.Nil(t, err)
+	require.Equal(t, int64(3), height)
+	restored, err := NewDemocoinApp(log.NewNopLogger(), db, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), restored.LastBlockHeight())
+	require.Equal(t, bapp.LastCommitID().Hash, restored.LastCommitID().Hash)
+
+	ctx := restored.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 102)}, restored.accountKeeper.GetAccount(ctx, addr).GetCoins())
+
+	// and keeps committing blocks
+	commitBlocksAddingCoins(t, restored, addr, 4)
+	ctx = restored.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 103)}, restored.accountKeeper.GetAccount(ctx, addr).GetCoins())
+
+	// snapshots are only restored into an empty database
+	_, err = RestoreSnapshot(bapp.cdc, db, bytes.NewReader(snapshot))
+	require.NotNil(t, err)
+
+	// by the same app version
+	var other bytes.Buffer
+	header.AppVersion++
+	other.Write(bapp.cdc.MustMarshalBinaryLengthPrefixed(header))
+	_, err = RestoreSnapshot(bapp.cdc, dbm.NewMemDB(), &other)
+	require.NotNil(t, err)
+
+	// and only complete snapshots
+	_, err = RestoreSnapshot(bapp.cdc, dbm.NewMemDB(), bytes.NewReader(snapshot[:len(snapshot)-1]))
+	require.NotNil(t, err)
+}
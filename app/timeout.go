@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultQueryTimeout is the default time an aggregated query may run
+const DefaultQueryTimeout = 10 * time.Second
+
+// ErrQueryTimeout is returned for queries that ran past their deadline
+func ErrQueryTimeout(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeQueryTimeout, "query deadline exceeded")
+}
+
+// SetQueryTimeout sets the time an aggregated query, such as a batch or a
+// profile, may run on this node. Zero disables the timeout.
+func (app *DemocoinApp) SetQueryTimeout(timeout time.Duration) {
+	app.queryTimeout = timeout
+}
+
+// NewTimeoutQuerier wraps a Querier and gives its context a deadline. The
+// wrapped querier may check the deadline to stop early, and a result
+// computed past the deadline is replaced by an ErrQueryTimeout.
+func NewTimeoutQuerier(q sdk.Querier, timeout func() time.Duration) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		d := timeout()
+		if d <= 0 {
+			return q(ctx, path, req)
+		}
+
+		deadlineCtx, cancel := context.WithTimeout(ctx.Context, d)
+		defer cancel()
+		ctx.Context = deadlineCtx
+
+		bz, err := q(ctx, path, req)
+		if ctx.Err() != nil {
+			return nil, ErrQueryTimeout(DefaultCodespace)
+		}
+		return bz, err
+	}
+}
+
+// checkQueryDeadline fails once the deadline given to the query context by
+// NewTimeoutQuerier has passed
+func checkQueryDeadline(ctx sdk.Context) sdk.Error {
+	if ctx.Err() != nil {
+		return ErrQueryTimeout(DefaultCodespace)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// DecodeTxCmd - decode a base64 encoded signed tx and print it as JSON
+func DecodeTxCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "decode [base64-tx]",
+		Short: "Decode a base64 encoded signed tx and print its msgs, fee, signatures and memo",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := decodeTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+}
+
+// decodeTx decodes a base64 encoded tx and returns it as indented JSON
+func decodeTx(cdc *codec.Codec, encoded string) ([]byte, error) {
+	txBytes, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 tx: %v", err)
+	}
+
+	tx, sdkErr := auth.DefaultTxDecoder(cdc)(txBytes)
+	if sdkErr != nil {
+		return nil, fmt.Errorf("invalid tx: %s", sdkErr.Error())
+	}
+
+	return codec.MarshalJSONIndent(cdc, tx)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/app"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+func TestDecodeTx(t *testing.T) {
+	cdc := app.MakeDefaultCodec()
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+
+	msg := bank.NewMsgBurn(addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	fee := auth.NewStdFee(50000, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+	sig, err := priv.Sign(auth.StdSignBytes("test-chain", 0, 0, fee, []sdk.Msg{msg}, "hello"))
+	require.Nil(t, err)
+	tx := auth.NewStdTx([]sdk.Msg{msg}, fee, []auth.StdSignature{{PubKey: priv.PubKey(), Signature: sig}}, "hello")
+
+	txBytes, err := auth.DefaultTxEncoder(cdc)(tx)
+	require.Nil(t, err)
+
+	bz, err := decodeTx(cdc, base64.StdEncoding.EncodeToString(txBytes))
+	require.Nil(t, err)
+
+	var decoded auth.StdTx
+	require.Nil(t, cdc.UnmarshalJSON(bz, &decoded))
+	require.Equal(t, []sdk.Msg{msg}, decoded.GetMsgs())
+	require.Equal(t, fee, decoded.Fee)
+	require.Equal(t, "hello", decoded.Memo)
+	require.Len(t, decoded.Signatures, 1)
+	require.Equal(t, priv.PubKey(), decoded.Signatures[0].PubKey)
+	require.Equal(t, sig, decoded.Signatures[0].Signature)
+
+	// malformed input is rejected
+	_, err = decodeTx(cdc, "not base64!")
+	require.NotNil(t, err)
+	_, err = decodeTx(cdc, base64.StdEncoding.EncodeToString([]byte("not a tx")))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+const (
+	flagTo       = "to"
+	flagAmount   = "amount"
+	flagSendMemo = "send-memo"
+	flagOffline  = "offline"
+)
+
+// SendTxCmd - send coins. With --generate-only the unsigned tx is printed
+// without contacting a node, so it can be signed on an air-gapped machine.
+func SendTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Create and sign a send tx, or print it unsigned with --generate-only",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			to, err := sdk.AccAddressFromBech32(viper.GetString(flagTo))
+			if err != nil {
+				return err
+			}
+			coins, err := sdk.ParseCoins(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := bank.NewMsgSend(from, to, coins, viper.GetString(flagSendMemo))
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagTo, "", "Address to send coins")
+	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
+	cmd.Flags().String(flagSendMemo, "", "Memo recorded with the transfer")
+	return cmd
+}
+
+// SignTxCmd - sign a tx printed by a --generate-only command. Offline the
+// account number and sequence can't be queried, so they must be given.
+func SignTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := authcmd.GetSignCommand(cdc)
+	sign := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool(flagOffline) {
+			if err := checkOfflineFlags(cmd); err != nil {
+				return err
+			}
+		}
+		return sign(cmd, args)
+	}
+	return cmd
+}
+
+// checkOfflineFlags returns an error unless the account number and sequence
+// were set on the command line
+func checkOfflineFlags(cmd *cobra.Command) error {
+	for _, flag := range []string{client.FlagAccountNumber, client.FlagSequence} {
+		if !cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s is required to sign offline", flag)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/app"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+func TestGenerateOnlySend(t *testing.T) {
+	cdc := app.MakeDefaultCodec()
+	from := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	msg := bank.NewMsgSend(from, to, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, "invoice 42")
+
+	// the unsigned tx is built without a node
+	txBldr := authtxb.TxBuilder{}.
+		WithTxEncoder(utils.GetTxEncoder(cdc)).
+		WithChainID("test-chain").
+		WithGas(50000).
+		WithFees("1foocoin").
+		WithMemo("offline")
+	var buf bytes.Buffer
+	require.Nil(t, utils.PrintUnsignedStdTx(&buf, txBldr, context.CLIContext{}.WithCodec(cdc), []sdk.Msg{msg}, true))
+
+	var unsigned auth.StdTx
+	require.Nil(t, cdc.UnmarshalJSON(buf.Bytes(), &unsigned))
+	require.Equal(t, []sdk.Msg{msg}, unsigned.GetMsgs())
+	require.Equal(t, auth.NewStdFee(50000, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}), unsigned.Fee)
+	require.Equal(t, "offline", unsigned.Memo)
+	require.Empty(t, unsigned.Signatures)
+
+	// and round-trips through the decoder
+	txBytes, err := auth.DefaultTxEncoder(cdc)(unsigned)
+	require.Nil(t, err)
+	bz, err := decodeTx(cdc, base64.StdEncoding.EncodeToString(txBytes))
+	require.Nil(t, err)
+	var decoded auth.StdTx
+	require.Nil(t, cdc.UnmarshalJSON(bz, &decoded))
+	require.Equal(t, unsigned.GetMsgs(), decoded.GetMsgs())
+	require.Equal(t, unsigned.Fee, decoded.Fee)
+	require.Equal(t, unsigned.Memo, decoded.Memo)
+}
+
+func TestCheckOfflineFlags(t *testing.T) {
+	cmd := client.PostCommands(&cobra.Command{Use: "test"})[0]
+	require.NotNil(t, checkOfflineFlags(cmd))
+
+	require.Nil(t, cmd.Flags().Set(client.FlagAccountNumber, "3"))
+	require.NotNil(t, checkOfflineFlags(cmd))
+
+	require.Nil(t, cmd.Flags().Set(client.FlagSequence, "0"))
+	require.Nil(t, checkOfflineFlags(cmd))
+}
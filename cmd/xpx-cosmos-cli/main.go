@@ -15,12 +15,14 @@ import (
 	at "github.com/cosmos/cosmos-sdk/x/auth"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
-	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
 
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/app"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool"
 	coolcmd "github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool/client/cli"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
 	powcmd "github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow/client/cli"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
 	simplestakingcmd "github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking/client/cli"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -39,14 +41,11 @@ func main() {
 	cobra.EnableCommandSorting = false
 
 	// get the codec
-	cdc := app.MakeCodec()
+	cdc := app.MakeDefaultCodec()
 
 	// Setup certain SDK config
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount("demoacc", "demopub")
-	config.SetBech32PrefixForValidator("demoval", "demovalpub")
-	config.SetBech32PrefixForConsensusNode("democons", "democonspub")
-	config.Seal()
+	app.SetBech32Prefixes(app.Bech32PrefixAccAddr, app.Bech32PrefixValAddr)
+	sdk.GetConfig().Seal()
 
 	// TODO: setup keybase, viper object, etc. to be passed into
 	// the below functions and eliminate global vars, like we do
@@ -68,15 +67,19 @@ func main() {
 		authcmd.GetAccountCmd(at.StoreKey, cdc),
 	)
 	rootCmd.AddCommand(
-		bankcmd.SendTxCmd(cdc),
+		client.PostCommands(SendTxCmd(cdc))...,
 	)
 	rootCmd.AddCommand(
 		client.PostCommands(
+			simplestakingcmd.CreateValidatorTxCmd(cdc),
+			simplestakingcmd.EditValidatorTxCmd(cdc),
 			simplestakingcmd.BondTxCmd(cdc),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
 			simplestakingcmd.UnbondTxCmd(cdc),
+			simplestakingcmd.UnjailTxCmd(cdc),
+			simplestakingcmd.TransferValidatorOwnershipTxCmd(cdc),
 		)...)
 	// and now democoin specific commands
 	rootCmd.AddCommand(
@@ -86,6 +89,49 @@ func main() {
 			powcmd.MineCmd(cdc),
 		)...)
 
+	// add query commands for the custom modules
+	coolQueryCmd := &cobra.Command{
+		Use:   "cool",
+		Short: "Querying commands for the cool module",
+	}
+	coolQueryCmd.AddCommand(client.GetCommands(
+		coolcmd.GetCmdQueryTrend(cool.QuerierRoute, cdc),
+	)...)
+
+	simplestakingQueryCmd := &cobra.Command{
+		Use:   "simplestaking",
+		Short: "Querying commands for the simplestaking module",
+	}
+	simplestakingQueryCmd.AddCommand(client.GetCommands(
+		simplestakingcmd.GetCmdQueryUnbonding(simplestaking.QuerierRoute, cdc),
+		simplestakingcmd.GetCmdQueryValidator(simplestaking.QuerierRoute, cdc),
+		simplestakingcmd.GetCmdQueryValidators(simplestaking.QuerierRoute, cdc),
+	)...)
+
+	queryCmd := &cobra.Command{
+		Use:     "query",
+		Aliases: []string{"q"},
+		Short:   "Querying subcommands",
+	}
+	queryCmd.AddCommand(coolQueryCmd, simplestakingQueryCmd)
+	rootCmd.AddCommand(queryCmd)
+
+	// add tx commands for the custom modules
+	powTxCmd := &cobra.Command{
+		Use:   "pow",
+		Short: "Proof-of-work transactions subcommands",
+	}
+	powTxCmd.AddCommand(client.PostCommands(
+		powcmd.GetCmdMine(pow.QuerierRoute, cdc),
+	)...)
+
+	txCmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Transactions subcommands",
+	}
+	txCmd.AddCommand(powTxCmd, SignTxCmd(cdc), DecodeTxCmd(cdc))
+	rootCmd.AddCommand(txCmd)
+
 	// add proxy, version and key info
 	rootCmd.AddCommand(
 		client.LineBreak,
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/libs/common"
@@ -21,17 +24,25 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	gaiaInit "github.com/cosmos/cosmos-sdk/cmd/gaia/init"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/app"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/types"
 	"github.com/cosmos/cosmos-sdk/server"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	flagClientHome = "home-client"
+	flagClientHome       = "home-client"
+	flagInvCheckPeriod   = "inv-check-period"
+	flagAssertInvariants = "assert-invariants"
+	flagOmitZeroBalances = "omit-zero-balances"
+	flagPruning          = "pruning" // defined by the server start command
 )
 
+var invCheckPeriod uint
+
 // coolGenAppParams sets up the app_state and appends the cool app state
 func CoolAppGenState(cdc *codec.Codec, genDoc tmtypes.GenesisDoc, appGenTxs []json.RawMessage) (
 	appState json.RawMessage, err error) {
@@ -40,10 +51,13 @@ func CoolAppGenState(cdc *codec.Codec, genDoc tmtypes.GenesisDoc, appGenTxs []js
 		return
 	}
 
+	genesisState := types.DefaultGenesisState()
+
 	key := "cool"
-	value := json.RawMessage(`{
-        "trend": "ice-cold"
-      }`)
+	value, err := cdc.MarshalJSON(genesisState.CoolGenesis)
+	if err != nil {
+		return
+	}
 
 	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
 	if err != nil {
@@ -51,10 +65,76 @@ func CoolAppGenState(cdc *codec.Codec, genDoc tmtypes.GenesisDoc, appGenTxs []js
 	}
 
 	key = "pow"
-	value = json.RawMessage(`{
-        "difficulty": "1",
-        "count": "0"
-      }`)
+	value, err = cdc.MarshalJSON(genesisState.POWGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "simplestaking"
+	value, err = cdc.MarshalJSON(genesisState.StakingGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "distribution"
+	value, err = cdc.MarshalJSON(genesisState.DistrGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "bank"
+	value, err = cdc.MarshalJSON(genesisState.BankGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "ibc"
+	value, err = cdc.MarshalJSON(genesisState.IBCGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "feegrant"
+	value, err = cdc.MarshalJSON(genesisState.FeeGrantGenesis)
+	if err != nil {
+		return
+	}
+
+	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
+	if err != nil {
+		return
+	}
+
+	key = "blockgas"
+	value, err = cdc.MarshalJSON(genesisState.BlockGasGenesis)
+	if err != nil {
+		return
+	}
 
 	appState, err = server.InsertKeyJSON(cdc, appState, key, value)
 	return
@@ -127,25 +207,194 @@ func InitCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// MigrateCmd returns a command migrating a genesis file to a newer schema
+// and printing the result
+func MigrateCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate [target-version] [genesis-file]",
+		Short: "Migrate a genesis file to the target schema version",
+		Long: `Migrate a genesis file to the target schema version and print it.
+The only supported target version is v1, migrating from the unversioned v0 schema.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if args[0] != "v1" {
+				return fmt.Errorf("unsupported target version %s", args[0])
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			genDoc.AppState, err = types.MigrateAppState(cdc, genDoc.AppState)
+			if err != nil {
+				return fmt.Errorf("failed to migrate app state: %v", err)
+			}
+
+			out, err := codec.MarshalJSONIndent(cdc, genDoc)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+}
+
+// ExportValidatorsCmd returns a command printing the bonded validators of a
+// stopped node as JSON
+func ExportValidatorsCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-validators",
+		Short: "Export the validator set of a stopped node as JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dataDir := filepath.Join(viper.GetString(cli.HomeFlag), "data")
+			if _, err := os.Stat(dataDir); err != nil {
+				return fmt.Errorf("no node data found in %s: %v", dataDir, err)
+			}
+
+			db, err := sdk.NewLevelDB("application", dataDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			dapp, err := app.NewDemocoinApp(ctx.Logger, db, 0)
+			if err != nil {
+				return err
+			}
+
+			out, err := codec.MarshalJSONIndent(cdc, dapp.ExportValidators())
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+}
+
+// SnapshotCmd returns the commands creating a snapshot of the state of a
+// stopped node and restoring it into a fresh node
+func SnapshotCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Create and restore snapshots of the app state",
+	}
+	cmd.AddCommand(snapshotCreateCmd(ctx, cdc), snapshotRestoreCmd(cdc))
+	return cmd
+}
+
+func snapshotCreateCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [file]",
+		Short: "Write the app state of a stopped node at its last height to a file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dataDir := filepath.Join(viper.GetString(cli.HomeFlag), "data")
+			if _, err := os.Stat(dataDir); err != nil {
+				return fmt.Errorf("no node data found in %s: %v", dataDir, err)
+			}
+
+			db, err := sdk.NewLevelDB("application", dataDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			dapp, err := app.NewDemocoinApp(ctx.Logger, db, 0)
+			if err != nil {
+				return err
+			}
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			height, err := dapp.WriteSnapshot(f)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("wrote snapshot of height %d to %s\n", height, args[0])
+			return nil
+		},
+	}
+}
+
+func snapshotRestoreCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [file]",
+		Short: "Load a snapshot into the app state of a fresh node",
+		Long: `Load a snapshot into the app state of a fresh node, which then starts
+from the snapshot height instead of replaying the chain from genesis. Only
+the app state is restored, the Tendermint block store and state of the node
+must be at the same height.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			dataDir := filepath.Join(viper.GetString(cli.HomeFlag), "data")
+			if err := os.MkdirAll(dataDir, 0700); err != nil {
+				return err
+			}
+			db, err := sdk.NewLevelDB("application", dataDir)
+			if err != nil {
+				return err
+			}
+			height, err := app.RestoreSnapshot(cdc, db, f)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("restored snapshot of height %d\n", height)
+			return nil
+		},
+	}
+}
+
 func newApp(logger log.Logger, db dbm.DB, _ io.Writer) abci.Application {
-	return app.NewDemocoinApp(logger, db)
+	dapp, err := app.NewDemocoinAppWithOptions(logger, db, invCheckPeriod, viper.GetString(flagPruning),
+		bam.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)))
+	if err != nil {
+		common.Exit(err.Error())
+	}
+	dapp.SetAssertInvariants(viper.GetBool(flagAssertInvariants))
+	flushOnSignal(dapp)
+	return dapp
+}
+
+// flushOnSignal flushes the in-memory counters of the app when the node is
+// stopped. The database stays open, the server closes it on its way out.
+func flushOnSignal(dapp *app.DemocoinApp) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		dapp.Flush()
+	}()
 }
 
 func exportAppStateAndTMValidators(logger log.Logger, db dbm.DB, _ io.Writer, _ int64, _ bool) (
 	json.RawMessage, []tmtypes.GenesisValidator, error) {
-	dapp := app.NewDemocoinApp(logger, db)
-	return dapp.ExportAppStateAndValidators()
+	dapp, err := app.NewDemocoinApp(logger, db, uint(1))
+	if err != nil {
+		return nil, nil, err
+	}
+	return dapp.ExportAppStateAndValidatorsWithOptions(app.ExportOptions{
+		OmitZeroBalances: viper.GetBool(flagOmitZeroBalances),
+	})
 }
 
 func main() {
-	cdc := app.MakeCodec()
+	cdc := app.MakeDefaultCodec()
 
 	// Setup certain SDK config
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount("demoacc", "demopub")
-	config.SetBech32PrefixForValidator("demoval", "demovalpub")
-	config.SetBech32PrefixForConsensusNode("democons", "democonspub")
-	config.Seal()
+	app.SetBech32Prefixes(app.Bech32PrefixAccAddr, app.Bech32PrefixValAddr)
+	sdk.GetConfig().Seal()
 
 	ctx := server.NewDefaultContext()
 
@@ -157,8 +406,15 @@ func main() {
 
 	rootCmd.AddCommand(InitCmd(ctx, cdc))
 	rootCmd.AddCommand(gaiaInit.TestnetFilesCmd(ctx, cdc))
+	rootCmd.AddCommand(MigrateCmd(cdc))
+	rootCmd.AddCommand(ExportValidatorsCmd(ctx, cdc))
+	rootCmd.AddCommand(SnapshotCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, newApp, exportAppStateAndTMValidators)
+	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
+		0, "Assert registered invariants every N blocks")
+	rootCmd.PersistentFlags().Bool(flagAssertInvariants, true, "Halt on a broken invariant, only log it if false")
+	rootCmd.PersistentFlags().Bool(flagOmitZeroBalances, false, "Leave accounts without coins out of exported genesis")
 
 	// prepare and add flags
 	rootDir := os.ExpandEnv("$HOME/.democoind")
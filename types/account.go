@@ -1,12 +1,23 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/account"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/admin"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/blockgas"
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/cool"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/distribution"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/ibc"
 	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
 )
 
 var _ auth.Account = (*AppAccount)(nil)
@@ -19,16 +30,23 @@ var _ auth.Account = (*AppAccount)(nil)
 type AppAccount struct {
 	auth.BaseAccount
 	Name string `json:"name"`
+
+	// the account cannot send coins before this height
+	UnlockHeight int64 `json:"unlock_height"`
 }
 
+var _ account.NamedAccount = (*AppAccount)(nil)
+
 // Constructor for AppAccount
 func ProtoAppAccount() auth.Account {
 	return &AppAccount{}
 }
 
 // nolint
-func (acc AppAccount) GetName() string      { return acc.Name }
-func (acc *AppAccount) SetName(name string) { acc.Name = name }
+func (acc AppAccount) GetName() string          { return acc.Name }
+func (acc *AppAccount) SetName(name string)     { acc.Name = name }
+func (acc AppAccount) GetUnlockHeight() int64   { return acc.UnlockHeight }
+func (acc *AppAccount) SetUnlockHeight(h int64) { acc.UnlockHeight = h }
 
 // Get the AccountDecoder function for the custom AppAccount
 func GetAccountDecoder(cdc *codec.Codec) auth.AccountDecoder {
@@ -49,7 +67,217 @@ func GetAccountDecoder(cdc *codec.Codec) auth.AccountDecoder {
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts    []*GenesisAccount `json:"accounts"`
+	Accounts        []*GenesisAccount     `json:"accounts"`
+	POWGenesis      pow.Genesis           `json:"pow"`
+	CoolGenesis     cool.Genesis          `json:"cool"`
+	StakingGenesis  simplestaking.Genesis `json:"simplestaking"`
+	AdminGenesis    admin.Genesis         `json:"admin"`
+	DistrGenesis    distribution.Genesis  `json:"distribution"`
+	BankGenesis     bank.Genesis          `json:"bank"`
+	IBCGenesis      ibc.Genesis           `json:"ibc"`
+	FeeGrantGenesis feegrant.Genesis      `json:"feegrant"`
+	BlockGasGenesis blockgas.Genesis      `json:"blockgas"`
+}
+
+// DefaultGenesisState returns a valid genesis state without accounts
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Accounts:        []*GenesisAccount{},
+		POWGenesis:      pow.DefaultGenesis(),
+		CoolGenesis:     cool.DefaultGenesis(),
+		StakingGenesis:  simplestaking.DefaultGenesis(),
+		AdminGenesis:    admin.DefaultGenesis(),
+		DistrGenesis:    distribution.DefaultGenesis(),
+		BankGenesis:     bank.DefaultGenesis(),
+		IBCGenesis:      ibc.DefaultGenesis(),
+		FeeGrantGenesis: feegrant.DefaultGenesis(),
+		BlockGasGenesis: blockgas.DefaultGenesis(),
+	}
+}
+
+// MarshalGenesisState marshals the genesis state with the app codec
+func MarshalGenesisState(cdc *codec.Codec, gs GenesisState) (json.RawMessage, error) {
+	return codec.MarshalJSONIndent(cdc, gs)
+}
+
+// AppStateVersion is the version of the exported app state. Bump it on
+// breaking changes to GenesisState.
+const AppStateVersion uint64 = 1
+
+// VersionedGenesisState wraps the app state with the version it was
+// exported at. Genesis files without the envelope are version 0.
+type VersionedGenesisState struct {
+	AppVersion uint64          `json:"app_version"`
+	State      json.RawMessage `json:"state"`
+}
+
+// MarshalVersionedGenesisState marshals the genesis state wrapped in a
+// versioned envelope
+func MarshalVersionedGenesisState(cdc *codec.Codec, gs GenesisState) (json.RawMessage, error) {
+	state, err := cdc.MarshalJSON(gs)
+	if err != nil {
+		return nil, err
+	}
+	return codec.MarshalJSONIndent(cdc, VersionedGenesisState{AppStateVersion, state})
+}
+
+// UnmarshalVersionedGenesisState unmarshals a genesis state, either wrapped
+// in a versioned envelope or legacy unversioned, and returns its version.
+// Versions newer than AppStateVersion are rejected.
+func UnmarshalVersionedGenesisState(cdc *codec.Codec, bz []byte) (gs GenesisState, version uint64, err error) {
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(bz, &fields)
+	if err != nil {
+		return gs, 0, err
+	}
+
+	if _, ok := fields["app_version"]; !ok {
+		err = cdc.UnmarshalJSON(bz, &gs)
+		return gs, 0, err
+	}
+
+	var versioned VersionedGenesisState
+	err = cdc.UnmarshalJSON(bz, &versioned)
+	if err != nil {
+		return gs, 0, err
+	}
+	if versioned.AppVersion > AppStateVersion {
+		return gs, versioned.AppVersion, fmt.Errorf(
+			"unsupported app state version %d, this binary supports up to %d", versioned.AppVersion, AppStateVersion)
+	}
+
+	err = cdc.UnmarshalJSON(versioned.State, &gs)
+	return gs, versioned.AppVersion, err
+}
+
+// UnmarshalGenesisStateWithDefaults unmarshals a genesis state like
+// UnmarshalVersionedGenesisState, substituting the default genesis of every
+// module whose section is absent. It returns the names of the defaulted
+// sections. Sections that are present but malformed still fail.
+func UnmarshalGenesisStateWithDefaults(cdc *codec.Codec, bz []byte) (gs GenesisState, defaulted []string, err error) {
+	gs, version, err := UnmarshalVersionedGenesisState(cdc, bz)
+	if err != nil {
+		return gs, nil, err
+	}
+
+	state := json.RawMessage(bz)
+	if version > 0 {
+		var versioned VersionedGenesisState
+		err = cdc.UnmarshalJSON(bz, &versioned)
+		if err != nil {
+			return gs, nil, err
+		}
+		state = versioned.State
+	}
+
+	var sections map[string]json.RawMessage
+	err = json.Unmarshal(state, &sections)
+	if err != nil {
+		return gs, nil, err
+	}
+
+	def := DefaultGenesisState()
+	for _, section := range []struct {
+		name  string
+		apply func()
+	}{
+		{"pow", func() { gs.POWGenesis = def.POWGenesis }},
+		{"cool", func() { gs.CoolGenesis = def.CoolGenesis }},
+		{"simplestaking", func() { gs.StakingGenesis = def.StakingGenesis }},
+		{"admin", func() { gs.AdminGenesis = def.AdminGenesis }},
+		{"distribution", func() { gs.DistrGenesis = def.DistrGenesis }},
+		{"bank", func() { gs.BankGenesis = def.BankGenesis }},
+		{"ibc", func() { gs.IBCGenesis = def.IBCGenesis }},
+		{"feegrant", func() { gs.FeeGrantGenesis = def.FeeGrantGenesis }},
+		{"blockgas", func() { gs.BlockGasGenesis = def.BlockGasGenesis }},
+	} {
+		if _, ok := sections[section.name]; !ok {
+			section.apply()
+			defaulted = append(defaulted, section.name)
+		}
+	}
+
+	return gs, defaulted, nil
+}
+
+// Validate performs basic validation of the genesis state
+func (gs GenesisState) Validate() error {
+	seen := make(map[string]bool)
+	for _, acc := range gs.Accounts {
+		addr := acc.Address.String()
+		if seen[addr] {
+			return fmt.Errorf("duplicate account in genesis state: %s", addr)
+		}
+		seen[addr] = true
+
+		if !acc.Coins.IsNotNegative() {
+			return fmt.Errorf("negative coins for genesis account %s: %s", addr, acc.Coins)
+		}
+
+		if acc.UnlockHeight < 0 {
+			return fmt.Errorf("negative unlock height for genesis account %s", addr)
+		}
+
+		if err := account.ValidateName(acc.Name); err != nil {
+			return fmt.Errorf("invalid name for genesis account %s: %v", addr, err)
+		}
+
+		if len(acc.VestingPeriods) > 0 {
+			if !acc.Vesting {
+				return fmt.Errorf("vesting periods without vesting flag for genesis account %s", addr)
+			}
+			var total sdk.Coins
+			for _, period := range acc.VestingPeriods {
+				if period.Length <= 0 {
+					return fmt.Errorf("non-positive vesting period length for genesis account %s", addr)
+				}
+				if !period.Amount.IsValid() || !period.Amount.IsPositive() {
+					return fmt.Errorf("invalid vesting period amount for genesis account %s: %s", addr, period.Amount)
+				}
+				total = total.Plus(period.Amount)
+			}
+			if !acc.Coins.IsAllGTE(total) {
+				return fmt.Errorf("vesting periods exceed coins for genesis account %s", addr)
+			}
+		} else if acc.Vesting {
+			if acc.EndTime <= acc.StartTime {
+				return fmt.Errorf("vesting end time must be after start time for genesis account %s", addr)
+			}
+			if !acc.Coins.IsAllGTE(acc.OriginalVesting) {
+				return fmt.Errorf("original vesting exceeds coins for genesis account %s", addr)
+			}
+		}
+	}
+
+	if gs.POWGenesis.Difficulty == 0 {
+		return fmt.Errorf("pow difficulty must be positive")
+	}
+	if gs.POWGenesis.Difficulty > gs.POWGenesis.Params.MaxDifficulty {
+		return fmt.Errorf("pow difficulty %d exceeds max difficulty %d",
+			gs.POWGenesis.Difficulty, gs.POWGenesis.Params.MaxDifficulty)
+	}
+
+	if err := bank.ValidateGenesis(gs.BankGenesis); err != nil {
+		return err
+	}
+	if err := simplestaking.ValidateGenesis(gs.StakingGenesis); err != nil {
+		return err
+	}
+
+	// coins of unknown denoms would be unspendable, the check only applies
+	// once denoms are registered or whitelisted
+	known := bank.KnownDenoms(gs.BankGenesis)
+	if len(known) == 0 {
+		return nil
+	}
+	for _, acc := range gs.Accounts {
+		for _, coin := range acc.Coins {
+			if !known[coin.Denom] {
+				return fmt.Errorf("unknown denom %s for genesis account %s", coin.Denom, acc.Address)
+			}
+		}
+	}
+	return nil
 }
 
 // GenesisAccount doesn't need pubkey or sequence
@@ -57,14 +285,61 @@ type GenesisAccount struct {
 	Name    string         `json:"name"`
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+
+	// the account cannot send coins before this height
+	UnlockHeight int64 `json:"unlock_height,omitempty"`
+
+	// vesting accounts lock OriginalVesting (all coins if empty)
+	// between StartTime and EndTime
+	Vesting         bool      `json:"vesting,omitempty"`
+	OriginalVesting sdk.Coins `json:"original_vesting,omitempty"`
+	StartTime       int64     `json:"start_time,omitempty"`
+	EndTime         int64     `json:"end_time,omitempty"`
+
+	// periodic vesting accounts also set VestingPeriods, unlocking the
+	// coins of each period at its end. OriginalVesting and EndTime are then
+	// derived from the periods.
+	VestingPeriods []VestingPeriod `json:"vesting_periods,omitempty"`
 }
 
 func NewGenesisAccount(aa *AppAccount) *GenesisAccount {
 	return &GenesisAccount{
-		Name:    aa.Name,
-		Address: aa.Address,
-		Coins:   aa.Coins.Sort(),
+		Name:         aa.Name,
+		Address:      aa.Address,
+		Coins:        aa.Coins.Sort(),
+		UnlockHeight: aa.UnlockHeight,
+	}
+}
+
+// NewGenesisAccountI builds a GenesisAccount from any account kept by the app
+func NewGenesisAccountI(acc auth.Account) *GenesisAccount {
+	gacc := &GenesisAccount{
+		Address: acc.GetAddress(),
+		Coins:   acc.GetCoins().Sort(),
+	}
+
+	switch acc := acc.(type) {
+	case *AppAccount:
+		gacc.Name = acc.Name
+		gacc.UnlockHeight = acc.UnlockHeight
+	case *ContinuousVestingAccount:
+		gacc.Name = acc.Name
+		gacc.UnlockHeight = acc.UnlockHeight
+		gacc.Vesting = true
+		gacc.OriginalVesting = acc.OriginalVesting
+		gacc.StartTime = acc.StartTime
+		gacc.EndTime = acc.EndTime
+	case *PeriodicVestingAccount:
+		gacc.Name = acc.Name
+		gacc.UnlockHeight = acc.UnlockHeight
+		gacc.Vesting = true
+		gacc.OriginalVesting = acc.OriginalVesting
+		gacc.StartTime = acc.StartTime
+		gacc.EndTime = acc.EndTime
+		gacc.VestingPeriods = acc.Periods
 	}
+
+	return gacc
 }
 
 // convert GenesisAccount to AppAccount
@@ -74,7 +349,30 @@ func (ga *GenesisAccount) ToAppAccount() (acc *AppAccount, err error) {
 		Coins:   ga.Coins.Sort(),
 	}
 	return &AppAccount{
-		BaseAccount: baseAcc,
-		Name:        ga.Name,
+		BaseAccount:  baseAcc,
+		Name:         ga.Name,
+		UnlockHeight: ga.UnlockHeight,
 	}, nil
 }
+
+// convert GenesisAccount to an AppAccount, or to a vesting account if the
+// vesting flag is set: a PeriodicVestingAccount if it has vesting periods,
+// a ContinuousVestingAccount otherwise
+func (ga *GenesisAccount) ToAccount() (acc auth.Account, err error) {
+	appAcc, err := ga.ToAppAccount()
+	if err != nil {
+		return nil, err
+	}
+	if !ga.Vesting {
+		return appAcc, nil
+	}
+	if len(ga.VestingPeriods) > 0 {
+		return NewPeriodicVestingAccount(appAcc, ga.StartTime, ga.VestingPeriods), nil
+	}
+
+	vacc := NewContinuousVestingAccount(appAcc, ga.StartTime, ga.EndTime)
+	if !ga.OriginalVesting.IsZero() {
+		vacc.OriginalVesting = ga.OriginalVesting.Sort()
+	}
+	return vacc, nil
+}
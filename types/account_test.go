@@ -0,0 +1,196 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+)
+
+func TestGenesisStateValidate(t *testing.T) {
+	addr1 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	negative := sdk.Coins{sdk.Coin{Denom: "foocoin", Amount: sdk.NewInt(-10)}}
+	powGenesis := pow.DefaultGenesis()
+	tooDifficult := pow.DefaultGenesis()
+	tooDifficult.Difficulty = tooDifficult.Params.MaxDifficulty + 1
+
+	tests := []struct {
+		name    string
+		genesis GenesisState
+		expPass bool
+	}{
+		{"valid", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins}, {Address: addr2, Coins: coins}},
+			POWGenesis: powGenesis,
+		}, true},
+		{"no accounts", GenesisState{POWGenesis: powGenesis}, true},
+		{"duplicate account", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins}, {Address: addr1, Coins: coins}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"negative coins", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: negative}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"unprintable name", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins, Name: "foo\x00"}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"vesting account", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins, Vesting: true, StartTime: 1, EndTime: 2}},
+			POWGenesis: powGenesis,
+		}, true},
+		{"vesting account ends before start", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins, Vesting: true, StartTime: 2, EndTime: 1}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"periodic vesting account", GenesisState{
+			Accounts: []*GenesisAccount{{Address: addr1, Coins: coins, Vesting: true, StartTime: 1,
+				VestingPeriods: []VestingPeriod{{Length: 10, Amount: coins}}}},
+			POWGenesis: powGenesis,
+		}, true},
+		{"vesting periods exceed coins", GenesisState{
+			Accounts: []*GenesisAccount{{Address: addr1, Coins: coins, Vesting: true, StartTime: 1,
+				VestingPeriods: []VestingPeriod{{Length: 10, Amount: coins}, {Length: 10, Amount: coins}}}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"empty vesting period", GenesisState{
+			Accounts: []*GenesisAccount{{Address: addr1, Coins: coins, Vesting: true, StartTime: 1,
+				VestingPeriods: []VestingPeriod{{Length: 0, Amount: coins}}}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"vesting periods without vesting flag", GenesisState{
+			Accounts: []*GenesisAccount{{Address: addr1, Coins: coins,
+				VestingPeriods: []VestingPeriod{{Length: 10, Amount: coins}}}},
+			POWGenesis: powGenesis,
+		}, false},
+		{"difficulty above max", GenesisState{POWGenesis: tooDifficult}, false},
+		{"duplicate denom metadata", GenesisState{
+			POWGenesis: powGenesis,
+			BankGenesis: bank.Genesis{DenomMetadata: []bank.DenomMetadata{
+				bank.NewDenomMetadata("steak", "Steak", 6),
+				bank.NewDenomMetadata("steak", "Steak", 6),
+			}},
+		}, false},
+		{"registered denom", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins}},
+			POWGenesis: powGenesis,
+			BankGenesis: bank.Genesis{DenomMetadata: []bank.DenomMetadata{
+				bank.NewDenomMetadata("foocoin", "Foocoin", 6),
+			}},
+		}, true},
+		{"whitelisted denom", GenesisState{
+			Accounts:    []*GenesisAccount{{Address: addr1, Coins: coins}},
+			POWGenesis:  powGenesis,
+			BankGenesis: bank.Genesis{Params: bank.Params{DenomWhitelist: []string{"foocoin"}}},
+		}, true},
+		{"unregistered denom", GenesisState{
+			Accounts:   []*GenesisAccount{{Address: addr1, Coins: coins.Plus(sdk.Coins{sdk.NewInt64Coin("mystery", 1)})}},
+			POWGenesis: powGenesis,
+			BankGenesis: bank.Genesis{
+				DenomMetadata: []bank.DenomMetadata{bank.NewDenomMetadata("foocoin", "Foocoin", 6)},
+				Params:        bank.Params{DenomWhitelist: []string{"steak"}},
+			},
+		}, false},
+		{"zero difficulty", GenesisState{
+			Accounts: []*GenesisAccount{{Address: addr1, Coins: coins}},
+		}, false},
+	}
+
+	for _, tc := range tests {
+		err := tc.genesis.Validate()
+		if tc.expPass {
+			require.Nil(t, err, tc.name)
+		} else {
+			require.NotNil(t, err, tc.name)
+		}
+	}
+}
+
+func TestDefaultGenesisState(t *testing.T) {
+	cdc := codec.New()
+
+	bz, err := MarshalGenesisState(cdc, DefaultGenesisState())
+	require.Nil(t, err)
+
+	var genesis GenesisState
+	err = cdc.UnmarshalJSON(bz, &genesis)
+	require.Nil(t, err)
+	require.Nil(t, genesis.Validate())
+	require.Empty(t, genesis.Accounts)
+	require.Equal(t, DefaultGenesisState().CoolGenesis, genesis.CoolGenesis)
+	require.Equal(t, DefaultGenesisState().POWGenesis.Difficulty, genesis.POWGenesis.Difficulty)
+	require.True(t, DefaultGenesisState().POWGenesis.Params.DecayRate.Equal(genesis.POWGenesis.Params.DecayRate))
+}
+
+func TestVersionedGenesisState(t *testing.T) {
+	cdc := codec.New()
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	gs := DefaultGenesisState()
+	gs.Accounts = []*GenesisAccount{{Address: addr, Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}}}
+
+	// versioned round trip
+	bz, err := MarshalVersionedGenesisState(cdc, gs)
+	require.Nil(t, err)
+	res, version, err := UnmarshalVersionedGenesisState(cdc, bz)
+	require.Nil(t, err)
+	require.Equal(t, AppStateVersion, version)
+	require.Equal(t, gs.Accounts, res.Accounts)
+	require.Equal(t, gs.CoolGenesis, res.CoolGenesis)
+
+	// legacy genesis without the envelope is version 0
+	bz, err = MarshalGenesisState(cdc, gs)
+	require.Nil(t, err)
+	res, version, err = UnmarshalVersionedGenesisState(cdc, bz)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), version)
+	require.Equal(t, gs.Accounts, res.Accounts)
+	require.Equal(t, gs.CoolGenesis, res.CoolGenesis)
+
+	// future versions are rejected
+	state, err := cdc.MarshalJSON(gs)
+	require.Nil(t, err)
+	bz, err = cdc.MarshalJSON(VersionedGenesisState{AppStateVersion + 1, state})
+	require.Nil(t, err)
+	_, version, err = UnmarshalVersionedGenesisState(cdc, bz)
+	require.NotNil(t, err)
+	require.Equal(t, AppStateVersion+1, version)
+}
+
+func TestUnmarshalGenesisStateWithDefaults(t *testing.T) {
+	cdc := codec.New()
+	gs := DefaultGenesisState()
+	gs.POWGenesis.Difficulty = 2
+	gs.CoolGenesis.Trend = "hot"
+
+	// complete genesis states are left alone
+	bz, err := MarshalVersionedGenesisState(cdc, gs)
+	require.Nil(t, err)
+	res, defaulted, err := UnmarshalGenesisStateWithDefaults(cdc, bz)
+	require.Nil(t, err)
+	require.Empty(t, defaulted)
+	require.Equal(t, "hot", res.CoolGenesis.Trend)
+
+	// absent sections get the module defaults
+	bz = []byte(`{"accounts": [], "pow": {"difficulty": "2", "count": "0", "params": {"max_difficulty": "10", "decay_rate": "0.5", "reward": [], "retarget_interval": "1", "target_mined": "0"}}}`)
+	res, defaulted, err = UnmarshalGenesisStateWithDefaults(cdc, bz)
+	require.Nil(t, err)
+	require.Equal(t, []string{"cool", "simplestaking", "admin", "distribution", "bank", "ibc", "feegrant", "blockgas"}, defaulted)
+	require.Equal(t, uint64(2), res.POWGenesis.Difficulty)
+	require.Equal(t, DefaultGenesisState().CoolGenesis, res.CoolGenesis)
+	require.Equal(t, DefaultGenesisState().IBCGenesis, res.IBCGenesis)
+
+	// malformed sections still fail
+	bz = []byte(`{"accounts": [], "cool": "hot"}`)
+	_, _, err = UnmarshalGenesisStateWithDefaults(cdc, bz)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisAccountV0 is a genesis account of the v0 schema, before unlock
+// heights and vesting
+type GenesisAccountV0 struct {
+	Name    string         `json:"name"`
+	Address sdk.AccAddress `json:"address"`
+	Coins   sdk.Coins      `json:"coins"`
+}
+
+// POWGenesisV0 is the pow genesis of the v0 schema, before params
+type POWGenesisV0 struct {
+	Difficulty uint64 `json:"difficulty"`
+	Count      uint64 `json:"count"`
+}
+
+// CoolGenesisV0 is the cool genesis of the v0 schema, before params
+type CoolGenesisV0 struct {
+	Trend string `json:"trend"`
+}
+
+// GenesisStateV0 is the unversioned genesis state of the v0 schema
+type GenesisStateV0 struct {
+	Accounts    []GenesisAccountV0 `json:"accounts"`
+	POWGenesis  POWGenesisV0       `json:"pow"`
+	CoolGenesis CoolGenesisV0      `json:"cool"`
+}
+
+// MigrateV0ToV1 converts a v0 genesis state to v1, filling the fields
+// missing from v0 with their defaults
+func MigrateV0ToV1(old GenesisStateV0) GenesisState {
+	gs := DefaultGenesisState()
+
+	for _, acc := range old.Accounts {
+		gs.Accounts = append(gs.Accounts, &GenesisAccount{
+			Name:    acc.Name,
+			Address: acc.Address,
+			Coins:   acc.Coins.Sort(),
+		})
+	}
+
+	gs.POWGenesis.Difficulty = old.POWGenesis.Difficulty
+	gs.POWGenesis.Count = old.POWGenesis.Count
+	if gs.POWGenesis.Difficulty > gs.POWGenesis.Params.MaxDifficulty {
+		gs.POWGenesis.Params.MaxDifficulty = gs.POWGenesis.Difficulty
+	}
+
+	if old.CoolGenesis.Trend != "" {
+		gs.CoolGenesis.Trend = old.CoolGenesis.Trend
+	}
+
+	return gs
+}
+
+// MigrateAppState migrates a v0 app state to a v1 app state wrapped in the
+// versioned envelope
+func MigrateAppState(cdc *codec.Codec, appState json.RawMessage) (json.RawMessage, error) {
+	var old GenesisStateV0
+	err := cdc.UnmarshalJSON(appState, &old)
+	if err != nil {
+		return nil, err
+	}
+
+	gs := MigrateV0ToV1(old)
+	err = gs.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalVersionedGenesisState(cdc, gs)
+}
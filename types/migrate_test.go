@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const genesisV0Fixture = `{
+  "accounts": [
+    {
+      "name": "alice",
+      "address": "%s",
+      "coins": [{"denom": "foocoin", "amount": "10"}]
+    }
+  ],
+  "pow": {
+    "difficulty": "1",
+    "count": "3"
+  },
+  "cool": {
+    "trend": "hot"
+  }
+}`
+
+func TestMigrateAppState(t *testing.T) {
+	cdc := codec.New()
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	appState := []byte(fmt.Sprintf(genesisV0Fixture, addr))
+
+	// the v0 schema lacks fields required by v1
+	var gs GenesisState
+	require.Nil(t, cdc.UnmarshalJSON(appState, &gs))
+	require.NotNil(t, gs.Validate())
+
+	bz, err := MigrateAppState(cdc, appState)
+	require.Nil(t, err)
+
+	gs, version, err := UnmarshalVersionedGenesisState(cdc, bz)
+	require.Nil(t, err)
+	require.Equal(t, AppStateVersion, version)
+	require.Nil(t, gs.Validate())
+
+	require.Equal(t, []*GenesisAccount{{
+		Name:    "alice",
+		Address: addr,
+		Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 10)},
+	}}, gs.Accounts)
+	require.Equal(t, uint64(1), gs.POWGenesis.Difficulty)
+	require.Equal(t, uint64(3), gs.POWGenesis.Count)
+	require.Equal(t, DefaultGenesisState().POWGenesis.Params.MaxDifficulty, gs.POWGenesis.Params.MaxDifficulty)
+	require.Equal(t, "hot", gs.CoolGenesis.Trend)
+	require.Equal(t, DefaultGenesisState().IBCGenesis, gs.IBCGenesis)
+
+	// malformed state is rejected
+	_, err = MigrateAppState(cdc, []byte(`{"accounts": 1}`))
+	require.NotNil(t, err)
+}
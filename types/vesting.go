@@ -0,0 +1,242 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var _ auth.VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount is an AppAccount whose original vesting coins
+// unlock linearly between StartTime and EndTime (unix seconds).
+type ContinuousVestingAccount struct {
+	AppAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`
+	DelegatedFree    sdk.Coins `json:"delegated_free"`
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"`
+
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+}
+
+// NewContinuousVestingAccount vests all coins of the account between
+// startTime and endTime
+func NewContinuousVestingAccount(aa *AppAccount, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		AppAccount:      *aa,
+		OriginalVesting: aa.Coins,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+}
+
+// nolint
+func (cva ContinuousVestingAccount) GetStartTime() int64            { return cva.StartTime }
+func (cva ContinuousVestingAccount) GetEndTime() int64              { return cva.EndTime }
+func (cva ContinuousVestingAccount) GetOriginalVesting() sdk.Coins  { return cva.OriginalVesting }
+func (cva ContinuousVestingAccount) GetDelegatedFree() sdk.Coins    { return cva.DelegatedFree }
+func (cva ContinuousVestingAccount) GetDelegatedVesting() sdk.Coins { return cva.DelegatedVesting }
+
+// GetVestedCoins returns the coins unlocked at blockTime
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	var vestedCoins sdk.Coins
+
+	// the start time may be in the future or the start of the chain may not
+	// be known exactly
+	if blockTime.Unix() <= cva.StartTime {
+		return vestedCoins
+	} else if blockTime.Unix() >= cva.EndTime {
+		return cva.OriginalVesting
+	}
+
+	x := blockTime.Unix() - cva.StartTime
+	y := cva.EndTime - cva.StartTime
+	s := sdk.NewDec(x).Quo(sdk.NewDec(y))
+
+	for _, ovc := range cva.OriginalVesting {
+		vestedAmt := sdk.NewDecFromInt(ovc.Amount).Mul(s).RoundInt()
+		vestedCoins = append(vestedCoins, sdk.NewCoin(ovc.Denom, vestedAmt))
+	}
+
+	return vestedCoins
+}
+
+// GetVestingCoins returns the coins still locked at blockTime
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return cva.OriginalVesting.Minus(cva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the coins the account can spend at blockTime
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return SpendableCoins(cva.GetCoins(), cva.GetVestingCoins(blockTime), cva.DelegatedVesting)
+}
+
+// TrackDelegation tracks a delegation, taking vesting coins first
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	cva.DelegatedVesting, cva.DelegatedFree = trackDelegation(
+		cva.GetVestingCoins(blockTime), cva.DelegatedVesting, cva.DelegatedFree, amount)
+	cva.Coins = cva.Coins.Minus(amount)
+}
+
+// TrackUndelegation tracks an undelegation, releasing free coins first
+func (cva *ContinuousVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	cva.DelegatedVesting, cva.DelegatedFree = trackUndelegation(cva.DelegatedVesting, cva.DelegatedFree, amount)
+	cva.Coins = cva.Coins.Plus(amount)
+}
+
+//___________________________________________________________________________________
+
+var _ auth.VestingAccount = (*PeriodicVestingAccount)(nil)
+
+// VestingPeriod is a tranche of a periodic vesting schedule. Its coins
+// unlock Length seconds after the end of the previous period.
+type VestingPeriod struct {
+	Length int64     `json:"length"`
+	Amount sdk.Coins `json:"amount"`
+}
+
+// PeriodicVestingAccount is an AppAccount whose original vesting coins
+// unlock in discrete tranches, each at the end of its period. The first
+// period starts at StartTime (unix seconds).
+type PeriodicVestingAccount struct {
+	AppAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`
+	DelegatedFree    sdk.Coins `json:"delegated_free"`
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"`
+
+	StartTime int64           `json:"start_time"`
+	EndTime   int64           `json:"end_time"`
+	Periods   []VestingPeriod `json:"periods"`
+}
+
+// NewPeriodicVestingAccount vests the coins of the periods starting at
+// startTime
+func NewPeriodicVestingAccount(aa *AppAccount, startTime int64, periods []VestingPeriod) *PeriodicVestingAccount {
+	var originalVesting sdk.Coins
+	endTime := startTime
+	for _, period := range periods {
+		originalVesting = originalVesting.Plus(period.Amount)
+		endTime += period.Length
+	}
+	return &PeriodicVestingAccount{
+		AppAccount:      *aa,
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		Periods:         periods,
+	}
+}
+
+// nolint
+func (pva PeriodicVestingAccount) GetStartTime() int64            { return pva.StartTime }
+func (pva PeriodicVestingAccount) GetEndTime() int64              { return pva.EndTime }
+func (pva PeriodicVestingAccount) GetOriginalVesting() sdk.Coins  { return pva.OriginalVesting }
+func (pva PeriodicVestingAccount) GetDelegatedFree() sdk.Coins    { return pva.DelegatedFree }
+func (pva PeriodicVestingAccount) GetDelegatedVesting() sdk.Coins { return pva.DelegatedVesting }
+
+// GetVestedCoins returns the coins of the periods elapsed at blockTime
+func (pva PeriodicVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	var vestedCoins sdk.Coins
+
+	end := pva.StartTime
+	for _, period := range pva.Periods {
+		end += period.Length
+		if blockTime.Unix() < end {
+			break
+		}
+		vestedCoins = vestedCoins.Plus(period.Amount)
+	}
+
+	return vestedCoins
+}
+
+// GetVestingCoins returns the coins still locked at blockTime
+func (pva PeriodicVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return pva.OriginalVesting.Minus(pva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the coins the account can spend at blockTime
+func (pva PeriodicVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return SpendableCoins(pva.GetCoins(), pva.GetVestingCoins(blockTime), pva.DelegatedVesting)
+}
+
+// TrackDelegation tracks a delegation, taking vesting coins first
+func (pva *PeriodicVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	pva.DelegatedVesting, pva.DelegatedFree = trackDelegation(
+		pva.GetVestingCoins(blockTime), pva.DelegatedVesting, pva.DelegatedFree, amount)
+	pva.Coins = pva.Coins.Minus(amount)
+}
+
+// TrackUndelegation tracks an undelegation, releasing free coins first
+func (pva *PeriodicVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	pva.DelegatedVesting, pva.DelegatedFree = trackUndelegation(pva.DelegatedVesting, pva.DelegatedFree, amount)
+	pva.Coins = pva.Coins.Plus(amount)
+}
+
+//___________________________________________________________________________________
+
+// trackDelegation splits a delegation between the delegated vesting and
+// delegated free coins, taking vesting coins first
+func trackDelegation(vestingCoins, delegatedVesting, delegatedFree, amount sdk.Coins) (sdk.Coins, sdk.Coins) {
+	for _, coin := range amount {
+		vesting := vestingCoins.AmountOf(coin.Denom)
+		delVesting := delegatedVesting.AmountOf(coin.Denom)
+
+		// compute x and y per the specification, where:
+		// X := min(max(V - DV, 0), D)
+		// Y := D - X
+		x := sdk.MinInt(sdk.MaxInt(vesting.Sub(delVesting), sdk.ZeroInt()), coin.Amount)
+		y := coin.Amount.Sub(x)
+
+		if !x.IsZero() {
+			delegatedVesting = delegatedVesting.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			delegatedFree = delegatedFree.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+	}
+	return delegatedVesting, delegatedFree
+}
+
+// trackUndelegation releases an undelegation from the delegated free and
+// delegated vesting coins, releasing free coins first
+func trackUndelegation(delegatedVesting, delegatedFree, amount sdk.Coins) (sdk.Coins, sdk.Coins) {
+	for _, coin := range amount {
+		free := delegatedFree.AmountOf(coin.Denom)
+
+		// compute x and y per the specification, where:
+		// X := min(DF, D)
+		// Y := D - X
+		x := sdk.MinInt(free, coin.Amount)
+		y := coin.Amount.Sub(x)
+
+		if !x.IsZero() {
+			delegatedFree = delegatedFree.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			delegatedVesting = delegatedVesting.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+	}
+	return delegatedVesting, delegatedFree
+}
+
+// SpendableCoins returns the coins that are not locked, given the vesting
+// coins of an account and the part of them that is delegated
+func SpendableCoins(coins, vestingCoins, delegatedVesting sdk.Coins) sdk.Coins {
+	var spendableCoins sdk.Coins
+
+	for _, coin := range coins {
+		// the locked amount is the part of the vesting coins not delegated
+		locked := sdk.MaxInt(vestingCoins.AmountOf(coin.Denom).Sub(delegatedVesting.AmountOf(coin.Denom)), sdk.ZeroInt())
+		spendable := coin.Amount.Sub(locked)
+		if spendable.IsPositive() {
+			spendableCoins = append(spendableCoins, sdk.NewCoin(coin.Denom, spendable))
+		}
+	}
+
+	return spendableCoins
+}
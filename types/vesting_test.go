@@ -0,0 +1,142 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func newTestVestingAccount(start, end time.Time) *ContinuousVestingAccount {
+	aa := &AppAccount{
+		BaseAccount: auth.BaseAccount{
+			Address: sdk.AccAddress([]byte("vesting")),
+			Coins:   sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)},
+		},
+	}
+	return NewContinuousVestingAccount(aa, start.Unix(), end.Unix())
+}
+
+func TestContinuousVestingAccountVestedCoins(t *testing.T) {
+	now := time.Now()
+	end := now.Add(24 * time.Hour)
+	cva := newTestVestingAccount(now, end)
+	origCoins := sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)}
+
+	// nothing is vested before the window opens
+	require.Nil(t, cva.GetVestedCoins(now))
+	require.Equal(t, origCoins, cva.GetVestingCoins(now))
+	require.Nil(t, cva.SpendableCoins(now))
+
+	// half is vested halfway through
+	half := now.Add(12 * time.Hour)
+	halfCoins := sdk.Coins{sdk.NewInt64Coin("fee", 500), sdk.NewInt64Coin("steak", 50)}
+	require.Equal(t, halfCoins, cva.GetVestedCoins(half))
+	require.Equal(t, halfCoins, cva.GetVestingCoins(half))
+	require.Equal(t, halfCoins, cva.SpendableCoins(half))
+
+	// everything is vested once the window is closed
+	require.Equal(t, origCoins, cva.GetVestedCoins(end))
+	require.True(t, cva.GetVestingCoins(end).IsZero())
+	require.Equal(t, origCoins, cva.SpendableCoins(end))
+}
+
+func TestContinuousVestingAccountReceivedCoins(t *testing.T) {
+	now := time.Now()
+	cva := newTestVestingAccount(now, now.Add(24*time.Hour))
+
+	// coins received after genesis are not locked
+	cva.SetCoins(cva.GetCoins().Plus(sdk.Coins{sdk.NewInt64Coin("fee", 50)}))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 50)}, cva.SpendableCoins(now))
+}
+
+func TestContinuousVestingAccountDelegation(t *testing.T) {
+	now := time.Now()
+	cva := newTestVestingAccount(now, now.Add(24*time.Hour))
+	half := now.Add(12 * time.Hour)
+
+	// delegations take vesting coins first
+	cva.TrackDelegation(half, sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 50)}, cva.DelegatedVesting)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 10)}, cva.DelegatedFree)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 500), sdk.NewInt64Coin("steak", 40)}, cva.SpendableCoins(half))
+
+	// undelegations release free coins first
+	cva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.True(t, cva.DelegatedFree.IsZero())
+	require.True(t, cva.DelegatedVesting.IsZero())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)}, cva.GetCoins())
+}
+
+func newTestPeriodicVestingAccount(start time.Time) *PeriodicVestingAccount {
+	aa := &AppAccount{
+		BaseAccount: auth.BaseAccount{
+			Address: sdk.AccAddress([]byte("periodic")),
+			Coins:   sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)},
+		},
+	}
+	return NewPeriodicVestingAccount(aa, start.Unix(), []VestingPeriod{
+		{Length: 3600, Amount: sdk.Coins{sdk.NewInt64Coin("fee", 500)}},
+		{Length: 3600, Amount: sdk.Coins{sdk.NewInt64Coin("fee", 250), sdk.NewInt64Coin("steak", 50)}},
+		{Length: 7200, Amount: sdk.Coins{sdk.NewInt64Coin("fee", 250), sdk.NewInt64Coin("steak", 50)}},
+	})
+}
+
+func TestPeriodicVestingAccountSpendableCoins(t *testing.T) {
+	now := time.Now()
+	pva := newTestPeriodicVestingAccount(now)
+	origCoins := sdk.Coins{sdk.NewInt64Coin("fee", 1000), sdk.NewInt64Coin("steak", 100)}
+	require.Equal(t, origCoins, pva.OriginalVesting)
+	require.Equal(t, now.Add(4*time.Hour).Unix(), pva.EndTime)
+
+	// nothing is vested before the first period ends
+	require.Nil(t, pva.GetVestedCoins(now))
+	require.Nil(t, pva.SpendableCoins(now.Add(59*time.Minute)))
+
+	// each period unlocks its coins at once
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 500)}, pva.SpendableCoins(now.Add(time.Hour)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 500)}, pva.SpendableCoins(now.Add(119*time.Minute)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 750), sdk.NewInt64Coin("steak", 50)},
+		pva.SpendableCoins(now.Add(2*time.Hour)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 750), sdk.NewInt64Coin("steak", 50)},
+		pva.SpendableCoins(now.Add(3*time.Hour)))
+
+	// everything is vested once the last period ends
+	require.Equal(t, origCoins, pva.GetVestedCoins(now.Add(4*time.Hour)))
+	require.True(t, pva.GetVestingCoins(now.Add(4*time.Hour)).IsZero())
+	require.Equal(t, origCoins, pva.SpendableCoins(now.Add(4*time.Hour)))
+}
+
+func TestPeriodicVestingAccountDelegation(t *testing.T) {
+	now := time.Now()
+	pva := newTestPeriodicVestingAccount(now)
+	second := now.Add(2 * time.Hour)
+
+	// delegations take vesting coins first
+	pva.TrackDelegation(second, sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 50)}, pva.DelegatedVesting)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 10)}, pva.DelegatedFree)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 750), sdk.NewInt64Coin("steak", 40)}, pva.SpendableCoins(second))
+
+	// undelegations release free coins first
+	pva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin("steak", 60)})
+	require.True(t, pva.DelegatedFree.IsZero())
+	require.True(t, pva.DelegatedVesting.IsZero())
+}
+
+func TestPeriodicVestingGenesisAccount(t *testing.T) {
+	now := time.Now()
+	pva := newTestPeriodicVestingAccount(now)
+
+	// the schedule survives a genesis export and import
+	gacc := NewGenesisAccountI(pva)
+	require.True(t, gacc.Vesting)
+	require.Equal(t, pva.Periods, gacc.VestingPeriods)
+
+	acc, err := gacc.ToAccount()
+	require.Nil(t, err)
+	require.Equal(t, pva, acc)
+}
@@ -0,0 +1,10 @@
+package account
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgSetAccountName{}, "account/SetAccountName", nil)
+}
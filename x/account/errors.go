@@ -0,0 +1,25 @@
+package account
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Account errors reserve 700 ~ 799.
+const (
+	DefaultCodespace sdk.CodespaceType = "account"
+
+	CodeInvalidName    sdk.CodeType = 700
+	CodeUnnamedAccount sdk.CodeType = 701
+)
+
+// ErrInvalidName - Error returned for names that are too long or not printable
+func ErrInvalidName(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidName, msg)
+}
+
+// ErrUnnamedAccount - Error returned for accounts that cannot hold a name
+func ErrUnnamedAccount(codespace sdk.CodespaceType, addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeUnnamedAccount, fmt.Sprintf("account %v cannot hold a name", addr))
+}
@@ -0,0 +1,28 @@
+package account
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "account" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgSetAccountName:
+			return handleMsgSetAccountName(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized account Msg type: %v", msg.Type())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// Handle MsgSetAccountName
+func handleMsgSetAccountName(ctx sdk.Context, k Keeper, msg MsgSetAccountName) sdk.Result {
+	if err := k.SetName(ctx, msg.Owner, msg.Name); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -0,0 +1,113 @@
+package account
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+type namedAccount struct {
+	auth.BaseAccount
+	Name string `json:"name"`
+}
+
+func (acc namedAccount) GetName() string      { return acc.Name }
+func (acc *namedAccount) SetName(name string) { acc.Name = name }
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	cdc.RegisterConcrete(&namedAccount{}, "test/namedAccount", nil)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace),
+		func() auth.Account { return &namedAccount{} })
+	keeper := NewKeeper(ak, DefaultCodespace)
+
+	return ctx, ak, keeper
+}
+
+func TestMsgSetAccountNameValidateBasic(t *testing.T) {
+	owner := sdk.AccAddress([]byte("owner"))
+
+	tests := []struct {
+		name    string
+		msg     MsgSetAccountName
+		expPass bool
+	}{
+		{"valid", NewMsgSetAccountName(owner, "alice"), true},
+		{"unicode", NewMsgSetAccountName(owner, "ålice ☃"), true},
+		{"empty clears", NewMsgSetAccountName(owner, ""), true},
+		{"max length", NewMsgSetAccountName(owner, strings.Repeat("a", MaxNameLength)), true},
+		{"too long", NewMsgSetAccountName(owner, strings.Repeat("a", MaxNameLength+1)), false},
+		{"control character", NewMsgSetAccountName(owner, "ali\nce"), false},
+		{"invalid utf8", NewMsgSetAccountName(owner, "ali\xffce"), false},
+		{"no owner", NewMsgSetAccountName(nil, "alice"), false},
+	}
+
+	for _, tc := range tests {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.Nil(t, err, tc.name)
+		} else {
+			require.NotNil(t, err, tc.name)
+		}
+	}
+}
+
+func TestHandleMsgSetAccountName(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+
+	owner := sdk.AccAddress([]byte("owner"))
+	ak.SetAccount(ctx, ak.NewAccountWithAddress(ctx, owner))
+
+	queryName := func() string {
+		bz, err := querier(ctx, []string{QueryName, owner.String()}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var name string
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &name))
+		return name
+	}
+	require.Equal(t, "", queryName())
+
+	// set
+	require.True(t, handler(ctx, NewMsgSetAccountName(owner, "alice")).IsOK())
+	require.Equal(t, "alice", queryName())
+
+	// overwrite
+	require.True(t, handler(ctx, NewMsgSetAccountName(owner, "bob")).IsOK())
+	require.Equal(t, "bob", queryName())
+	require.Equal(t, "bob", ak.GetAccount(ctx, owner).(*namedAccount).Name)
+
+	// unknown accounts can't be named
+	unknown := sdk.AccAddress([]byte("unknown"))
+	res := handler(ctx, NewMsgSetAccountName(unknown, "carol"))
+	require.Equal(t, sdk.CodeUnknownAddress, res.Code)
+	_, err := querier(ctx, []string{QueryName, unknown.String()}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,51 @@
+package account
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// Hooks are run after an account is stored for the first time, so modules
+// such as staking or metrics can react to new addresses
+type Hooks interface {
+	AfterAccountCreated(ctx sdk.Context, acc auth.Account)
+}
+
+// HookedAccountKeeper wraps the auth account keeper to run the hooks when
+// SetAccount stores an address without an account. Updates of existing
+// accounts don't run them. Keepers given the embedded auth keeper bypass
+// the hooks.
+type HookedAccountKeeper struct {
+	auth.AccountKeeper
+
+	// run in order after every account creation
+	hooks []Hooks
+}
+
+// NewHookedAccountKeeper wraps the account keeper, without hooks
+func NewHookedAccountKeeper(ak auth.AccountKeeper) HookedAccountKeeper {
+	return HookedAccountKeeper{AccountKeeper: ak}
+}
+
+// AddHooks returns a copy of the keeper also running the hooks after
+// account creations, after the hooks added before them. Hooks must be added
+// before the keeper is handed to other keepers.
+func (k HookedAccountKeeper) AddHooks(h Hooks) HookedAccountKeeper {
+	hooks := make([]Hooks, len(k.hooks), len(k.hooks)+1)
+	copy(hooks, k.hooks)
+	k.hooks = append(hooks, h)
+	return k
+}
+
+// SetAccount stores the account, running the hooks if its address had no
+// account yet
+func (k HookedAccountKeeper) SetAccount(ctx sdk.Context, acc auth.Account) {
+	created := k.AccountKeeper.GetAccount(ctx, acc.GetAddress()) == nil
+	k.AccountKeeper.SetAccount(ctx, acc)
+	if !created {
+		return
+	}
+	for _, h := range k.hooks {
+		h.AfterAccountCreated(ctx, acc)
+	}
+}
@@ -0,0 +1,50 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// recordHooks records its name and the address of every created account
+type recordHooks struct {
+	name    string
+	created *[]string
+}
+
+func (h recordHooks) AfterAccountCreated(_ sdk.Context, acc auth.Account) {
+	*h.created = append(*h.created, h.name+":"+string(acc.GetAddress()))
+}
+
+func TestHookedAccountKeeper(t *testing.T) {
+	ctx, ak, _ := createTestInput(t)
+	var created []string
+	keeper := NewHookedAccountKeeper(ak).
+		AddHooks(recordHooks{"first", &created}).
+		AddHooks(recordHooks{"second", &created})
+
+	// new accounts run the hooks in order
+	acc1 := keeper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("addr1")))
+	keeper.SetAccount(ctx, acc1)
+	require.Equal(t, []string{"first:addr1", "second:addr1"}, created)
+
+	// updates don't
+	require.Nil(t, acc1.SetCoins(sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}))
+	keeper.SetAccount(ctx, acc1)
+	keeper.SetAccount(ctx, acc1)
+	require.Equal(t, []string{"first:addr1", "second:addr1"}, created)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetAccount(ctx, acc1.GetAddress()).GetCoins())
+
+	// every other new address does, once
+	acc2 := keeper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("addr2")))
+	keeper.SetAccount(ctx, acc2)
+	keeper.SetAccount(ctx, acc2)
+	require.Equal(t, []string{"first:addr1", "second:addr1", "first:addr2", "second:addr2"}, created)
+
+	// accounts set through the embedded keeper bypass the hooks
+	ak.SetAccount(ctx, ak.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("addr3"))))
+	require.Len(t, created, 4)
+}
@@ -0,0 +1,63 @@
+package account
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// NamedAccount is an account holding a nickname
+type NamedAccount interface {
+	auth.Account
+
+	GetName() string
+	SetName(string)
+}
+
+// Keeper reads and writes account nicknames through the account keeper
+type Keeper struct {
+	ak auth.AccountKeeper
+
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(ak auth.AccountKeeper, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		ak:        ak,
+		codespace: codespace,
+	}
+}
+
+func (k Keeper) getNamedAccount(ctx sdk.Context, addr sdk.AccAddress) (NamedAccount, sdk.Error) {
+	acc := k.ak.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+	}
+	named, ok := acc.(NamedAccount)
+	if !ok {
+		return nil, ErrUnnamedAccount(k.codespace, addr)
+	}
+	return named, nil
+}
+
+// GetName returns the nickname of the account
+func (k Keeper) GetName(ctx sdk.Context, addr sdk.AccAddress) (string, sdk.Error) {
+	acc, err := k.getNamedAccount(ctx, addr)
+	if err != nil {
+		return "", err
+	}
+	return acc.GetName(), nil
+}
+
+// SetName sets the nickname of the account, overwriting any previous one
+func (k Keeper) SetName(ctx sdk.Context, addr sdk.AccAddress, name string) sdk.Error {
+	acc, err := k.getNamedAccount(ctx, addr)
+	if err != nil {
+		return err
+	}
+	acc.SetName(name)
+	k.ak.SetAccount(ctx, acc)
+	return nil
+}
@@ -0,0 +1,75 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxNameLength is the maximum length in bytes of an account name
+const MaxNameLength = 32
+
+// ValidateName returns an error if the name is too long or contains
+// characters that are not printable. The empty name clears the name.
+func ValidateName(name string) error {
+	if len(name) > MaxNameLength {
+		return fmt.Errorf("name is longer than %d bytes", MaxNameLength)
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("name is not valid UTF-8")
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("name contains the non-printable character %q", r)
+		}
+	}
+	return nil
+}
+
+// MsgSetAccountName - sets the nickname of the sender's account
+type MsgSetAccountName struct {
+	Owner sdk.AccAddress
+	Name  string
+}
+
+// NewMsgSetAccountName - new set account name message
+func NewMsgSetAccountName(owner sdk.AccAddress, name string) MsgSetAccountName {
+	return MsgSetAccountName{
+		Owner: owner,
+		Name:  name,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgSetAccountName{}
+
+// nolint
+func (msg MsgSetAccountName) Route() string                { return "account" }
+func (msg MsgSetAccountName) Type() string                 { return "set_account_name" }
+func (msg MsgSetAccountName) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Owner} }
+func (msg MsgSetAccountName) String() string {
+	return fmt.Sprintf("MsgSetAccountName{Owner: %v, Name: %q}", msg.Owner, msg.Name)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSetAccountName) ValidateBasic() sdk.Error {
+	if len(msg.Owner) == 0 {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateName(msg.Name); err != nil {
+		return ErrInvalidName(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgSetAccountName) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
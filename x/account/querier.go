@@ -0,0 +1,53 @@
+package account
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the account Querier
+const (
+	QuerierRoute = "account"
+	QueryName    = "name"
+)
+
+// NewQuerier returns a querier for the account module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no account query endpoint given")
+		}
+		switch path[0] {
+		case QueryName:
+			return queryName(ctx, path[1:], k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown account query endpoint")
+		}
+	}
+}
+
+// queryName returns the nickname of the bech32 address given as the query
+// path
+func queryName(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected an account address")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	name, sdkErr := k.GetName(ctx, addr)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, name)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
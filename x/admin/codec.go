@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgUnpause{}, "admin/Unpause", nil)
+	cdc.RegisterConcrete(MsgFreezeAccount{}, "admin/FreezeAccount", nil)
+	cdc.RegisterConcrete(MsgRegisterDenomMetadata{}, "admin/RegisterDenomMetadata", nil)
+	cdc.RegisterConcrete(MsgRegisterDenomAlias{}, "admin/RegisterDenomAlias", nil)
+	cdc.RegisterConcrete(MsgToggleRoute{}, "admin/ToggleRoute", nil)
+	cdc.RegisterConcrete(MsgSetTrendLength{}, "admin/SetTrendLength", nil)
+	cdc.RegisterConcrete(MsgSpendCommunityPool{}, "admin/SpendCommunityPool", nil)
+}
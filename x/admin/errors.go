@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Admin errors reserve 500 ~ 599.
+const (
+	DefaultCodespace sdk.CodespaceType = "admin"
+
+	CodeChainPaused    sdk.CodeType = 500
+	CodeUnauthorized   sdk.CodeType = 501
+	CodeDuplicateDenom sdk.CodeType = 502
+	CodeRouteDisabled  sdk.CodeType = 503
+	CodeInvalidRoute   sdk.CodeType = 504
+	CodeInvalidBounds  sdk.CodeType = 505
+	CodeNotStarted     sdk.CodeType = 506
+)
+
+// ErrChainPaused - Error returned for txs submitted while the chain is paused
+func ErrChainPaused(codespace sdk.CodespaceType, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeChainPaused, fmt.Sprintf("chain is paused, %v messages are rejected", msgType))
+}
+
+// ErrUnauthorized - Error returned when the sender is not the admin
+func ErrUnauthorized(codespace sdk.CodespaceType, sender sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorized, fmt.Sprintf("%v is not the admin", sender))
+}
+
+// ErrDuplicateDenom - Error returned when the denom already has metadata
+func ErrDuplicateDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicateDenom, fmt.Sprintf("metadata for denom %v is already registered", denom))
+}
+
+// ErrRouteDisabled - Error returned for messages of a disabled route
+func ErrRouteDisabled(codespace sdk.CodespaceType, route string) sdk.Error {
+	return sdk.NewError(codespace, CodeRouteDisabled, fmt.Sprintf("messages of route %v are disabled", route))
+}
+
+// ErrInvalidRoute - Error returned when toggling a route that can't be disabled
+func ErrInvalidRoute(codespace sdk.CodespaceType, route string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidRoute, fmt.Sprintf("route %v can't be disabled", route))
+}
+
+// ErrInvalidBounds - Error returned when a minimum exceeds its maximum
+func ErrInvalidBounds(codespace sdk.CodespaceType, min, max uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidBounds, fmt.Sprintf("invalid bounds [%d, %d]", min, max))
+}
+
+// ErrNotStarted - Error returned for txs submitted before the start time
+func ErrNotStarted(codespace sdk.CodespaceType, start int64) sdk.Error {
+	return sdk.NewError(codespace, CodeNotStarted, fmt.Sprintf("chain starts at %v", time.Unix(start, 0).UTC()))
+}
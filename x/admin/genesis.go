@@ -0,0 +1,41 @@
+package admin
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state, the admin address, whether the chain starts
+// paused, the disabled routes and the start time
+type Genesis struct {
+	Params Params `json:"params"`
+}
+
+// DefaultGenesis returns the default genesis state for the admin module
+func DefaultGenesis() Genesis {
+	return Genesis{}
+}
+
+// InitGenesis for the admin module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	k.SetAdmin(ctx, genesis.Params.Admin)
+	k.SetPaused(ctx, genesis.Params.Paused)
+	routes := genesis.Params.DisabledRoutes
+	if routes == nil {
+		routes = []string{}
+	}
+	k.paramSpace.Set(ctx, KeyDisabledRoutes, &routes)
+	k.paramSpace.Set(ctx, KeyStartTime, &genesis.Params.StartTime)
+	return nil
+}
+
+// ExportGenesis for the admin module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	return Genesis{
+		Params: Params{
+			Admin:          k.GetAdmin(ctx),
+			Paused:         k.IsPaused(ctx),
+			DisabledRoutes: k.GetDisabledRoutes(ctx),
+			StartTime:      k.GetStartTime(ctx),
+		},
+	}
+}
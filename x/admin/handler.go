@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "admin" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgUnpause:
+			return handleMsgUnpause(ctx, k, msg)
+		case MsgFreezeAccount:
+			return handleMsgFreezeAccount(ctx, k, msg)
+		case MsgRegisterDenomMetadata:
+			return handleMsgRegisterDenomMetadata(ctx, k, msg)
+		case MsgRegisterDenomAlias:
+			return handleMsgRegisterDenomAlias(ctx, k, msg)
+		case MsgToggleRoute:
+			return handleMsgToggleRoute(ctx, k, msg)
+		case MsgSetTrendLength:
+			return handleMsgSetTrendLength(ctx, k, msg)
+		case MsgSpendCommunityPool:
+			return handleMsgSpendCommunityPool(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized admin Msg type: %v", msg.Type())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// Handle MsgUnpause, only the admin may resume the chain
+func handleMsgUnpause(ctx sdk.Context, k Keeper, msg MsgUnpause) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	k.SetPaused(ctx, false)
+	return sdk.Result{}
+}
+
+// Handle MsgFreezeAccount, only the admin may freeze accounts
+func handleMsgFreezeAccount(ctx sdk.Context, k Keeper, msg MsgFreezeAccount) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	k.bk.SetFrozen(ctx, msg.Address, msg.Frozen)
+	return sdk.Result{}
+}
+
+// Handle MsgRegisterDenomMetadata, only the admin may register metadata and
+// a denom's metadata can't be registered twice
+func handleMsgRegisterDenomMetadata(ctx sdk.Context, k Keeper, msg MsgRegisterDenomMetadata) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	if _, found := k.bk.GetDenomMetadata(ctx, msg.Metadata.Denom); found {
+		return ErrDuplicateDenom(k.codespace, msg.Metadata.Denom).Result()
+	}
+	k.bk.SetDenomMetadata(ctx, msg.Metadata)
+	return sdk.Result{}
+}
+
+// Handle MsgRegisterDenomAlias, only the admin may register aliases and the
+// bank keeper rejects duplicate aliases and alias cycles
+func handleMsgRegisterDenomAlias(ctx sdk.Context, k Keeper, msg MsgRegisterDenomAlias) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	if err := k.bk.RegisterDenomAlias(ctx, msg.Alias); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+// Handle MsgToggleRoute, only the admin may disable or enable a route and
+// the admin route itself can't be disabled
+func handleMsgToggleRoute(ctx sdk.Context, k Keeper, msg MsgToggleRoute) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	if msg.TargetRoute == msg.Route() {
+		return ErrInvalidRoute(k.codespace, msg.TargetRoute).Result()
+	}
+	k.SetRouteDisabled(ctx, msg.TargetRoute, msg.Disabled)
+	return sdk.Result{}
+}
+
+// Handle MsgSetTrendLength, only the admin may change the bounds of the
+// length of a cool trend
+func handleMsgSetTrendLength(ctx sdk.Context, k Keeper, msg MsgSetTrendLength) sdk.Result {
+	if !msg.Sender.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Sender).Result()
+	}
+	k.ck.SetTrendLength(ctx, msg.MinTrendLength, msg.MaxTrendLength)
+	return sdk.Result{}
+}
+
+// Handle MsgSpendCommunityPool, only the admin may spend the community pool
+// and only what it holds. The spend is recorded in the tags.
+func handleMsgSpendCommunityPool(ctx sdk.Context, k Keeper, msg MsgSpendCommunityPool) sdk.Result {
+	if !msg.Authority.Equals(k.GetAdmin(ctx)) {
+		return ErrUnauthorized(k.codespace, msg.Authority).Result()
+	}
+	if err := k.bk.SpendCommunityPool(ctx, msg.Recipient, msg.Amount); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			TagAction, ActionSpendCommunityPool,
+			TagRecipient, []byte(msg.Recipient.String()),
+			TagAmount, []byte(msg.Amount.String()),
+		),
+	}
+}
@@ -0,0 +1,137 @@
+package admin
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+// BankKeeper freezes accounts, registers denom metadata and aliases and
+// spends the community pool on behalf of the admin
+type BankKeeper interface {
+	SetFrozen(ctx sdk.Context, addr sdk.AccAddress, frozen bool)
+	GetDenomMetadata(ctx sdk.Context, denom string) (bank.DenomMetadata, bool)
+	SetDenomMetadata(ctx sdk.Context, md bank.DenomMetadata)
+	RegisterDenomAlias(ctx sdk.Context, da bank.DenomAlias) sdk.Error
+	SpendCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amt sdk.Coins) sdk.Error
+}
+
+// CoolKeeper changes the cool params on behalf of the admin
+type CoolKeeper interface {
+	SetTrendLength(ctx sdk.Context, min, max uint64)
+}
+
+// Keeper of the admin params
+type Keeper struct {
+	bk         BankKeeper
+	ck         CoolKeeper
+	paramSpace params.Subspace
+	codespace  sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(bk BankKeeper, ck CoolKeeper, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		bk:         bk,
+		ck:         ck,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+}
+
+// GetAdmin returns the admin address
+func (k Keeper) GetAdmin(ctx sdk.Context) (admin sdk.AccAddress) {
+	k.paramSpace.Get(ctx, KeyAdmin, &admin)
+	return admin
+}
+
+// SetAdmin sets the admin address
+func (k Keeper) SetAdmin(ctx sdk.Context, admin sdk.AccAddress) {
+	k.paramSpace.Set(ctx, KeyAdmin, &admin)
+}
+
+// IsPaused returns whether the chain is paused
+func (k Keeper) IsPaused(ctx sdk.Context) (paused bool) {
+	k.paramSpace.Get(ctx, KeyPaused, &paused)
+	return paused
+}
+
+// SetPaused pauses or resumes the chain
+func (k Keeper) SetPaused(ctx sdk.Context, paused bool) {
+	k.paramSpace.Set(ctx, KeyPaused, &paused)
+}
+
+// GetDisabledRoutes returns the routes whose messages are rejected
+func (k Keeper) GetDisabledRoutes(ctx sdk.Context) (routes []string) {
+	k.paramSpace.Get(ctx, KeyDisabledRoutes, &routes)
+	return routes
+}
+
+// IsRouteDisabled returns whether the messages of the route are rejected
+func (k Keeper) IsRouteDisabled(ctx sdk.Context, route string) bool {
+	for _, disabled := range k.GetDisabledRoutes(ctx) {
+		if disabled == route {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRouteDisabled disables or enables the messages of the route
+func (k Keeper) SetRouteDisabled(ctx sdk.Context, route string, disabled bool) {
+	routes := []string{}
+	for _, r := range k.GetDisabledRoutes(ctx) {
+		if r != route {
+			routes = append(routes, r)
+		}
+	}
+	if disabled {
+		routes = append(routes, route)
+	}
+	k.paramSpace.Set(ctx, KeyDisabledRoutes, &routes)
+}
+
+// GetStartTime returns the time (unix seconds) blocks are processed from
+func (k Keeper) GetStartTime(ctx sdk.Context) (start int64) {
+	k.paramSpace.Get(ctx, KeyStartTime, &start)
+	return start
+}
+
+// IsStarted returns whether the block time reached the start time, always
+// true without a start time
+func (k Keeper) IsStarted(ctx sdk.Context) bool {
+	start := k.GetStartTime(ctx)
+	return start == 0 || ctx.BlockHeader().Time.Unix() >= start
+}
+
+// NewAnteHandler wraps an AnteHandler and rejects every tx before the start
+// time, and every tx holding a message other than MsgUnpause while the chain
+// is paused
+func (k Keeper) NewAnteHandler(ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		if !k.IsStarted(ctx) {
+			return ctx, ErrNotStarted(k.codespace, k.GetStartTime(ctx)).Result(), true
+		}
+		if k.IsPaused(ctx) {
+			for _, msg := range tx.GetMsgs() {
+				if _, ok := msg.(MsgUnpause); !ok {
+					return ctx, ErrChainPaused(k.codespace, msg.Type()).Result(), true
+				}
+			}
+		}
+		return ah(ctx, tx, simulate)
+	}
+}
+
+// NewCircuitBreakerHandler wraps a Handler and rejects the messages of the
+// disabled routes, so a vulnerable module can be switched off without
+// halting the chain
+func (k Keeper) NewCircuitBreakerHandler(h sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if k.IsRouteDisabled(ctx, msg.Route()) {
+			return ErrRouteDisabled(k.codespace, msg.Route()).Result()
+		}
+		return h(ctx, msg)
+	}
+}
@@ -0,0 +1,272 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+// testBankKeeper records the frozen accounts, the denom metadata and
+// aliases and the community pool spends
+type testBankKeeper struct {
+	frozen   map[string]bool
+	metadata map[string]bank.DenomMetadata
+	aliases  map[string]string
+	pool     *sdk.Coins
+	received map[string]sdk.Coins
+}
+
+func (bk testBankKeeper) SetFrozen(_ sdk.Context, addr sdk.AccAddress, frozen bool) {
+	bk.frozen[addr.String()] = frozen
+}
+
+func (bk testBankKeeper) GetDenomMetadata(_ sdk.Context, denom string) (bank.DenomMetadata, bool) {
+	md, found := bk.metadata[denom]
+	return md, found
+}
+
+func (bk testBankKeeper) SetDenomMetadata(_ sdk.Context, md bank.DenomMetadata) {
+	bk.metadata[md.Denom] = md
+}
+
+func (bk testBankKeeper) RegisterDenomAlias(_ sdk.Context, da bank.DenomAlias) sdk.Error {
+	if _, found := bk.aliases[da.Alias]; found {
+		return sdk.ErrInvalidCoins("")
+	}
+	bk.aliases[da.Alias] = da.Denom
+	return nil
+}
+
+func (bk testBankKeeper) SpendCommunityPool(_ sdk.Context, recipient sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	if !bk.pool.IsAllGTE(amt) {
+		return sdk.ErrInsufficientCoins("")
+	}
+	*bk.pool = bk.pool.Minus(amt)
+	bk.received[recipient.String()] = bk.received[recipient.String()].Plus(amt)
+	return nil
+}
+
+// testCoolKeeper records the trend length bounds
+type testCoolKeeper struct {
+	bounds map[string]uint64
+}
+
+func (ck testCoolKeeper) SetTrendLength(_ sdk.Context, min, max uint64) {
+	ck.bounds["min"] = min
+	ck.bounds["max"] = max
+}
+
+func createTestInput(t *testing.T, adminAddr sdk.AccAddress) (sdk.Context, testBankKeeper, Keeper) {
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	bk := testBankKeeper{make(map[string]bool), make(map[string]bank.DenomMetadata), make(map[string]string), &sdk.Coins{}, make(map[string]sdk.Coins)}
+	keeper := NewKeeper(bk, testCoolKeeper{make(map[string]uint64)}, pk.Subspace(DefaultParamspace), DefaultCodespace)
+
+	err := InitGenesis(ctx, keeper, Genesis{Params{Admin: adminAddr}})
+	require.Nil(t, err)
+
+	return ctx, bk, keeper
+}
+
+func passAnteHandler(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+	return ctx, sdk.Result{}, false
+}
+
+func TestPausedAnteHandler(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	ctx, _, keeper := createTestInput(t, adminAddr)
+	ah := keeper.NewAnteHandler(passAnteHandler)
+
+	sendTx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(adminAddr)}, auth.StdFee{}, nil, "")
+	unpauseTx := auth.NewStdTx([]sdk.Msg{NewMsgUnpause(adminAddr)}, auth.StdFee{}, nil, "")
+	mixedTx := auth.NewStdTx([]sdk.Msg{NewMsgUnpause(adminAddr), sdk.NewTestMsg(adminAddr)}, auth.StdFee{}, nil, "")
+
+	// every tx passes while the chain runs
+	for _, tx := range []auth.StdTx{sendTx, unpauseTx, mixedTx} {
+		_, res, abort := ah(ctx, tx, false)
+		require.False(t, abort)
+		require.True(t, res.IsOK())
+	}
+
+	// only MsgUnpause passes while it is paused
+	keeper.SetPaused(ctx, true)
+	require.True(t, keeper.IsPaused(ctx))
+
+	_, res, abort := ah(ctx, sendTx, false)
+	require.True(t, abort)
+	require.Equal(t, CodeChainPaused, res.Code)
+
+	_, res, abort = ah(ctx, mixedTx, false)
+	require.True(t, abort)
+	require.Equal(t, CodeChainPaused, res.Code)
+
+	_, res, abort = ah(ctx, unpauseTx, false)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+}
+
+func TestHandleMsgUnpause(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	ctx, _, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+	keeper.SetPaused(ctx, true)
+
+	// only the admin may unpause
+	res := handler(ctx, NewMsgUnpause(sdk.AccAddress([]byte("other"))))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.True(t, keeper.IsPaused(ctx))
+
+	res = handler(ctx, NewMsgUnpause(adminAddr))
+	require.True(t, res.IsOK())
+	require.False(t, keeper.IsPaused(ctx))
+
+	require.Equal(t, Genesis{Params{Admin: adminAddr}}, ExportGenesis(ctx, keeper))
+}
+
+func TestHandleMsgFreezeAccount(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	addr := sdk.AccAddress([]byte("addr"))
+	ctx, bk, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+
+	// only the admin may freeze accounts
+	res := handler(ctx, NewMsgFreezeAccount(addr, addr, true))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.False(t, bk.frozen[addr.String()])
+
+	res = handler(ctx, NewMsgFreezeAccount(adminAddr, addr, true))
+	require.True(t, res.IsOK())
+	require.True(t, bk.frozen[addr.String()])
+
+	res = handler(ctx, NewMsgFreezeAccount(adminAddr, addr, false))
+	require.True(t, res.IsOK())
+	require.False(t, bk.frozen[addr.String()])
+}
+
+func TestHandleMsgRegisterDenomMetadata(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	ctx, bk, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+	steak := bank.NewDenomMetadata("steak", "Steak", 6)
+
+	require.Nil(t, NewMsgRegisterDenomMetadata(adminAddr, steak).ValidateBasic())
+	require.NotNil(t, NewMsgRegisterDenomMetadata(adminAddr, bank.NewDenomMetadata("steak", "", 6)).ValidateBasic())
+
+	// only the admin may register metadata
+	res := handler(ctx, NewMsgRegisterDenomMetadata(sdk.AccAddress([]byte("other")), steak))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.Empty(t, bk.metadata)
+
+	res = handler(ctx, NewMsgRegisterDenomMetadata(adminAddr, steak))
+	require.True(t, res.IsOK())
+	require.Equal(t, steak, bk.metadata["steak"])
+
+	// a denom is registered only once
+	res = handler(ctx, NewMsgRegisterDenomMetadata(adminAddr, bank.NewDenomMetadata("steak", "Other", 2)))
+	require.Equal(t, CodeDuplicateDenom, res.Code)
+	require.Equal(t, steak, bk.metadata["steak"])
+}
+
+func TestHandleMsgRegisterDenomAlias(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	ctx, bk, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+	alias := bank.NewDenomAlias("oldcoin", "foocoin")
+
+	require.Nil(t, NewMsgRegisterDenomAlias(adminAddr, alias).ValidateBasic())
+	require.NotNil(t, NewMsgRegisterDenomAlias(adminAddr, bank.NewDenomAlias("foocoin", "foocoin")).ValidateBasic())
+	require.NotNil(t, NewMsgRegisterDenomAlias(adminAddr, bank.NewDenomAlias("Old", "foocoin")).ValidateBasic())
+
+	// only the admin may register aliases
+	res := handler(ctx, NewMsgRegisterDenomAlias(sdk.AccAddress([]byte("other")), alias))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.Empty(t, bk.aliases)
+
+	res = handler(ctx, NewMsgRegisterDenomAlias(adminAddr, alias))
+	require.True(t, res.IsOK())
+	require.Equal(t, "foocoin", bk.aliases["oldcoin"])
+
+	// the bank keeper's errors are returned
+	res = handler(ctx, NewMsgRegisterDenomAlias(adminAddr, bank.NewDenomAlias("oldcoin", "barcoin")))
+	require.Equal(t, sdk.CodeInvalidCoins, res.Code)
+	require.Equal(t, "foocoin", bk.aliases["oldcoin"])
+}
+
+func TestHandleMsgSetTrendLength(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	ctx, _, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+	ck := keeper.ck.(testCoolKeeper)
+
+	require.Nil(t, NewMsgSetTrendLength(adminAddr, 3, 10).ValidateBasic())
+	require.NotNil(t, NewMsgSetTrendLength(adminAddr, 0, 10).ValidateBasic())
+	require.NotNil(t, NewMsgSetTrendLength(adminAddr, 11, 10).ValidateBasic())
+
+	// only the admin may change the bounds
+	res := handler(ctx, NewMsgSetTrendLength(sdk.AccAddress([]byte("other")), 3, 10))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.Empty(t, ck.bounds)
+
+	res = handler(ctx, NewMsgSetTrendLength(adminAddr, 3, 10))
+	require.True(t, res.IsOK())
+	require.Equal(t, uint64(3), ck.bounds["min"])
+	require.Equal(t, uint64(10), ck.bounds["max"])
+}
+
+func TestHandleMsgSpendCommunityPool(t *testing.T) {
+	adminAddr := sdk.AccAddress([]byte("admin"))
+	recipient := sdk.AccAddress([]byte("recipient"))
+	ctx, bk, keeper := createTestInput(t, adminAddr)
+	handler := NewHandler(keeper)
+	*bk.pool = sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}
+	amount := sdk.Coins{sdk.NewInt64Coin("foocoin", 60)}
+
+	require.Nil(t, NewMsgSpendCommunityPool(adminAddr, recipient, amount).ValidateBasic())
+	require.NotNil(t, NewMsgSpendCommunityPool(adminAddr, nil, amount).ValidateBasic())
+	require.NotNil(t, NewMsgSpendCommunityPool(adminAddr, recipient, sdk.Coins{}).ValidateBasic())
+
+	// only the admin may spend the pool
+	res := handler(ctx, NewMsgSpendCommunityPool(recipient, recipient, amount))
+	require.Equal(t, CodeUnauthorized, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}, *bk.pool)
+
+	// the spend moves the coins to the recipient and is tagged
+	res = handler(ctx, NewMsgSpendCommunityPool(adminAddr, recipient, amount))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 40)}, *bk.pool)
+	require.Equal(t, amount, bk.received[recipient.String()])
+	require.Equal(t, sdk.NewTags(
+		TagAction, ActionSpendCommunityPool,
+		TagRecipient, []byte(recipient.String()),
+		TagAmount, []byte(amount.String()),
+	), res.Tags)
+
+	// the pool can't be overspent
+	res = handler(ctx, NewMsgSpendCommunityPool(adminAddr, recipient, amount))
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 40)}, *bk.pool)
+	require.Equal(t, amount, bk.received[recipient.String()])
+}
@@ -0,0 +1,355 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/bank"
+)
+
+// MsgUnpause - resumes processing of all messages, only the admin may send it
+type MsgUnpause struct {
+	Sender sdk.AccAddress
+}
+
+// NewMsgUnpause - new unpause message
+func NewMsgUnpause(sender sdk.AccAddress) MsgUnpause {
+	return MsgUnpause{
+		Sender: sender,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgUnpause{}
+
+// nolint
+func (msg MsgUnpause) Route() string                { return "admin" }
+func (msg MsgUnpause) Type() string                 { return "unpause" }
+func (msg MsgUnpause) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgUnpause) String() string {
+	return fmt.Sprintf("MsgUnpause{Sender: %v}", msg.Sender)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgUnpause) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgUnpause) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//_______________________________________________________________________
+
+// MsgFreezeAccount - freezes or unfreezes an account, only the admin may
+// send it
+type MsgFreezeAccount struct {
+	Sender  sdk.AccAddress
+	Address sdk.AccAddress
+	Frozen  bool
+}
+
+// NewMsgFreezeAccount - new freeze account message
+func NewMsgFreezeAccount(sender, addr sdk.AccAddress, frozen bool) MsgFreezeAccount {
+	return MsgFreezeAccount{
+		Sender:  sender,
+		Address: addr,
+		Frozen:  frozen,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgFreezeAccount{}
+
+// nolint
+func (msg MsgFreezeAccount) Route() string                { return "admin" }
+func (msg MsgFreezeAccount) Type() string                 { return "freeze_account" }
+func (msg MsgFreezeAccount) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgFreezeAccount) String() string {
+	return fmt.Sprintf("MsgFreezeAccount{Sender: %v, Address: %v, Frozen: %v}", msg.Sender, msg.Address, msg.Frozen)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgFreezeAccount) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if len(msg.Address) == 0 {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgFreezeAccount) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//_______________________________________________________________________
+
+// MsgRegisterDenomMetadata - registers the display metadata of a denom, only
+// the admin may send it
+type MsgRegisterDenomMetadata struct {
+	Sender   sdk.AccAddress
+	Metadata bank.DenomMetadata
+}
+
+// NewMsgRegisterDenomMetadata - new register denom metadata message
+func NewMsgRegisterDenomMetadata(sender sdk.AccAddress, md bank.DenomMetadata) MsgRegisterDenomMetadata {
+	return MsgRegisterDenomMetadata{
+		Sender:   sender,
+		Metadata: md,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgRegisterDenomMetadata{}
+
+// nolint
+func (msg MsgRegisterDenomMetadata) Route() string { return "admin" }
+func (msg MsgRegisterDenomMetadata) Type() string  { return "register_denom_metadata" }
+func (msg MsgRegisterDenomMetadata) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+func (msg MsgRegisterDenomMetadata) String() string {
+	return fmt.Sprintf("MsgRegisterDenomMetadata{Sender: %v, Metadata: %v}", msg.Sender, msg.Metadata)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgRegisterDenomMetadata) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if err := msg.Metadata.Validate(); err != nil {
+		return sdk.ErrInvalidCoins(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgRegisterDenomMetadata) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//_______________________________________________________________________
+
+// MsgRegisterDenomAlias - registers another name of a denom, only the admin
+// may send it. Coins held in the alias before are not converted.
+type MsgRegisterDenomAlias struct {
+	Sender sdk.AccAddress
+	Alias  bank.DenomAlias
+}
+
+// NewMsgRegisterDenomAlias - new register denom alias message
+func NewMsgRegisterDenomAlias(sender sdk.AccAddress, da bank.DenomAlias) MsgRegisterDenomAlias {
+	return MsgRegisterDenomAlias{
+		Sender: sender,
+		Alias:  da,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgRegisterDenomAlias{}
+
+// nolint
+func (msg MsgRegisterDenomAlias) Route() string                { return "admin" }
+func (msg MsgRegisterDenomAlias) Type() string                 { return "register_denom_alias" }
+func (msg MsgRegisterDenomAlias) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgRegisterDenomAlias) String() string {
+	return fmt.Sprintf("MsgRegisterDenomAlias{Sender: %v, Alias: %v, Denom: %v}", msg.Sender, msg.Alias.Alias,
+		msg.Alias.Denom)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgRegisterDenomAlias) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if err := msg.Alias.Validate(); err != nil {
+		return sdk.ErrInvalidCoins(err.Error())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgRegisterDenomAlias) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//_______________________________________________________________________
+
+// MsgToggleRoute - disables or enables the messages of a route, only the
+// admin may send it
+type MsgToggleRoute struct {
+	Sender      sdk.AccAddress
+	TargetRoute string
+	Disabled    bool
+}
+
+// NewMsgToggleRoute - new toggle route message
+func NewMsgToggleRoute(sender sdk.AccAddress, route string, disabled bool) MsgToggleRoute {
+	return MsgToggleRoute{
+		Sender:      sender,
+		TargetRoute: route,
+		Disabled:    disabled,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgToggleRoute{}
+
+// nolint
+func (msg MsgToggleRoute) Route() string                { return "admin" }
+func (msg MsgToggleRoute) Type() string                 { return "toggle_route" }
+func (msg MsgToggleRoute) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgToggleRoute) String() string {
+	return fmt.Sprintf("MsgToggleRoute{Sender: %v, TargetRoute: %v, Disabled: %v}", msg.Sender, msg.TargetRoute,
+		msg.Disabled)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgToggleRoute) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.TargetRoute == "" || msg.TargetRoute == msg.Route() {
+		return ErrInvalidRoute(DefaultCodespace, msg.TargetRoute)
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgToggleRoute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//_______________________________________________________________________
+
+// MsgSetTrendLength - sets the bounds of the length of a cool trend, only
+// the admin may send it
+type MsgSetTrendLength struct {
+	Sender         sdk.AccAddress
+	MinTrendLength uint64
+	MaxTrendLength uint64
+}
+
+// NewMsgSetTrendLength - new set trend length message
+func NewMsgSetTrendLength(sender sdk.AccAddress, min, max uint64) MsgSetTrendLength {
+	return MsgSetTrendLength{
+		Sender:         sender,
+		MinTrendLength: min,
+		MaxTrendLength: max,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgSetTrendLength{}
+
+// nolint
+func (msg MsgSetTrendLength) Route() string                { return "admin" }
+func (msg MsgSetTrendLength) Type() string                 { return "set_trend_length" }
+func (msg MsgSetTrendLength) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgSetTrendLength) String() string {
+	return fmt.Sprintf("MsgSetTrendLength{Sender: %v, MinTrendLength: %v, MaxTrendLength: %v}", msg.Sender,
+		msg.MinTrendLength, msg.MaxTrendLength)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSetTrendLength) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	if msg.MinTrendLength == 0 || msg.MinTrendLength > msg.MaxTrendLength {
+		return ErrInvalidBounds(DefaultCodespace, msg.MinTrendLength, msg.MaxTrendLength)
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgSetTrendLength) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// MsgSpendCommunityPool - moves coins from the community pool to the
+// recipient, only the admin may send it
+type MsgSpendCommunityPool struct {
+	Authority sdk.AccAddress
+	Recipient sdk.AccAddress
+	Amount    sdk.Coins
+}
+
+// NewMsgSpendCommunityPool - new spend community pool message
+func NewMsgSpendCommunityPool(authority, recipient sdk.AccAddress, amount sdk.Coins) MsgSpendCommunityPool {
+	return MsgSpendCommunityPool{
+		Authority: authority,
+		Recipient: recipient,
+		Amount:    amount,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgSpendCommunityPool{}
+
+// nolint
+func (msg MsgSpendCommunityPool) Route() string { return "admin" }
+func (msg MsgSpendCommunityPool) Type() string  { return "spend_community_pool" }
+func (msg MsgSpendCommunityPool) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Authority}
+}
+func (msg MsgSpendCommunityPool) String() string {
+	return fmt.Sprintf("MsgSpendCommunityPool{Authority: %v, Recipient: %v, Amount: %v}", msg.Authority,
+		msg.Recipient, msg.Amount)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgSpendCommunityPool) ValidateBasic() sdk.Error {
+	if len(msg.Authority) == 0 {
+		return sdk.ErrInvalidAddress(msg.Authority.String())
+	}
+	if len(msg.Recipient) == 0 {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgSpendCommunityPool) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
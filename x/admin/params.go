@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default admin module parameter subspace
+const DefaultParamspace = "admin"
+
+// Parameter store keys
+var (
+	KeyAdmin          = []byte("Admin")
+	KeyPaused         = []byte("Paused")
+	KeyDisabledRoutes = []byte("DisabledRoutes")
+	KeyStartTime      = []byte("StartTime")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the admin module
+type Params struct {
+	// the only address allowed to send admin messages
+	Admin sdk.AccAddress `json:"admin"`
+
+	// if set, every message except MsgUnpause is rejected
+	Paused bool `json:"paused"`
+
+	// messages of these routes are rejected, the admin route can't be
+	// disabled
+	DisabledRoutes []string `json:"disabled_routes"`
+
+	// blocks before this time (unix seconds) are not processed, so the
+	// chain starts at a coordinated time. Zero starts it right away.
+	StartTime int64 `json:"start_time"`
+}
+
+// ParamKeyTable for admin module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyAdmin, &p.Admin},
+		{KeyPaused, &p.Paused},
+		{KeyDisabledRoutes, &p.DisabledRoutes},
+		{KeyStartTime, &p.StartTime},
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Admin:           %s
+  Paused:          %t
+  Disabled Routes: %s
+  Start Time:      %d`, p.Admin, p.Paused, strings.Join(p.DisabledRoutes, ", "), p.StartTime)
+}
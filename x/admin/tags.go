@@ -0,0 +1,14 @@
+package admin
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Tag keys and values emitted for admin actions
+var (
+	TagAction    = sdk.TagAction
+	TagRecipient = "recipient"
+	TagAmount    = "amount"
+
+	ActionSpendCommunityPool = []byte("spend_community_pool")
+)
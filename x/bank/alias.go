@@ -0,0 +1,157 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// DenomAlias is another name of a denom, typically its name before a
+// migration renamed it. Coins given in the alias are moved in the denom.
+type DenomAlias struct {
+	Alias string `json:"alias"`
+	Denom string `json:"denom"`
+}
+
+// NewDenomAlias - new denom alias
+func NewDenomAlias(alias, denom string) DenomAlias {
+	return DenomAlias{
+		Alias: alias,
+		Denom: denom,
+	}
+}
+
+// Validate checks the alias and the denom
+func (da DenomAlias) Validate() error {
+	if !reDenom.MatchString(da.Alias) {
+		return fmt.Errorf("invalid denom alias: %q", da.Alias)
+	}
+	if !reDenom.MatchString(da.Denom) {
+		return fmt.Errorf("invalid denom: %q", da.Denom)
+	}
+	if da.Alias == da.Denom {
+		return fmt.Errorf("denom %s can't be its own alias", da.Denom)
+	}
+	return nil
+}
+
+var denomAliasKeyPrefix = []byte("denom_alias:")
+
+func getDenomAliasKey(alias string) []byte {
+	return append(denomAliasKeyPrefix, []byte(alias)...)
+}
+
+// GetDenomAlias returns the denom the alias was registered for
+func (k Keeper) GetDenomAlias(ctx sdk.Context, alias string) (denom string, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(getDenomAliasKey(alias))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// SetDenomAlias registers the alias of its denom. The caller must make sure
+// the alias is new and doesn't create a cycle, see checkDenomAlias.
+func (k Keeper) SetDenomAlias(ctx sdk.Context, da DenomAlias) {
+	store := ctx.KVStore(k.key)
+	store.Set(getDenomAliasKey(da.Alias), []byte(da.Denom))
+}
+
+// RegisterDenomAlias registers the alias of its denom, refusing to register
+// an alias twice or an alias the denom already resolves to
+func (k Keeper) RegisterDenomAlias(ctx sdk.Context, da DenomAlias) sdk.Error {
+	if err := checkDenomAlias(da, func(alias string) (string, bool) {
+		return k.GetDenomAlias(ctx, alias)
+	}); err != nil {
+		return sdk.ErrInvalidCoins(err.Error())
+	}
+	k.SetDenomAlias(ctx, da)
+	return nil
+}
+
+// IterateDenomAliases iterates over the registered aliases in alias order
+func (k Keeper) IterateDenomAliases(ctx sdk.Context, fn func(da DenomAlias) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, denomAliasKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		alias := string(iter.Key()[len(denomAliasKeyPrefix):])
+		if fn(NewDenomAlias(alias, string(iter.Value()))) {
+			break
+		}
+	}
+}
+
+// ResolveDenom returns the canonical denom of the denom, following its
+// aliases. Denoms without an alias are their own canonical denom.
+func (k Keeper) ResolveDenom(ctx sdk.Context, denom string) string {
+	return resolveDenom(denom, func(alias string) (string, bool) {
+		return k.GetDenomAlias(ctx, alias)
+	})
+}
+
+// ResolveCoins returns the coins in their canonical denoms, adding up the
+// coins given in several names of the same denom
+func (k Keeper) ResolveCoins(ctx sdk.Context, amt sdk.Coins) sdk.Coins {
+	resolved := sdk.Coins{}
+	aliased := false
+	for _, coin := range amt {
+		denom := k.ResolveDenom(ctx, coin.Denom)
+		if denom != coin.Denom {
+			aliased = true
+		}
+		resolved = resolved.Plus(sdk.Coins{sdk.NewCoin(denom, coin.Amount)})
+	}
+	if !aliased {
+		return amt
+	}
+	return resolved
+}
+
+// resolveInputs returns the inputs with their coins in canonical denoms
+func (k Keeper) resolveInputs(ctx sdk.Context, inputs []sdkbank.Input) []sdkbank.Input {
+	resolved := make([]sdkbank.Input, len(inputs))
+	for i, in := range inputs {
+		resolved[i] = sdkbank.NewInput(in.Address, k.ResolveCoins(ctx, in.Coins))
+	}
+	return resolved
+}
+
+// resolveOutputs returns the outputs with their coins in canonical denoms
+func (k Keeper) resolveOutputs(ctx sdk.Context, outputs []sdkbank.Output) []sdkbank.Output {
+	resolved := make([]sdkbank.Output, len(outputs))
+	for i, out := range outputs {
+		resolved[i] = sdkbank.NewOutput(out.Address, k.ResolveCoins(ctx, out.Coins))
+	}
+	return resolved
+}
+
+// resolveDenom follows the aliases of the denom until a denom without an
+// alias. The registered aliases must be free of cycles.
+func resolveDenom(denom string, getAlias func(alias string) (string, bool)) string {
+	for {
+		next, found := getAlias(denom)
+		if !found {
+			return denom
+		}
+		denom = next
+	}
+}
+
+// checkDenomAlias returns an error if the alias is invalid, already
+// registered, or if its denom resolves to the alias, which would make
+// resolving either of them loop forever
+func checkDenomAlias(da DenomAlias, getAlias func(alias string) (string, bool)) error {
+	if err := da.Validate(); err != nil {
+		return err
+	}
+	if denom, found := getAlias(da.Alias); found {
+		return fmt.Errorf("%s is already an alias of %s", da.Alias, denom)
+	}
+	if resolveDenom(da.Denom, getAlias) == da.Alias {
+		return fmt.Errorf("aliasing %s to %s creates a cycle", da.Alias, da.Denom)
+	}
+	return nil
+}
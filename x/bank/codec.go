@@ -0,0 +1,22 @@
+package bank
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	sdkbank.RegisterCodec(cdc)
+	cdc.RegisterConcrete(MsgSend{}, "bank/Send", nil)
+	cdc.RegisterConcrete(MsgMultiSend{}, "bank/MultiSend", nil)
+	cdc.RegisterConcrete(MsgBurn{}, "bank/Burn", nil)
+	cdc.RegisterConcrete(MsgMintTo{}, "bank/MintTo", nil)
+	cdc.RegisterConcrete(MsgSwap{}, "bank/Swap", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
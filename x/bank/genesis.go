@@ -0,0 +1,112 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state of the bank module
+type Genesis struct {
+	DenomMetadata []DenomMetadata `json:"denom_metadata"`
+	DenomAliases  []DenomAlias    `json:"denom_aliases"`
+	Params        Params          `json:"params"`
+}
+
+// DefaultGenesis returns the default genesis state for the bank module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		DenomMetadata: []DenomMetadata{},
+		DenomAliases:  []DenomAlias{},
+		Params:        DefaultParams(),
+	}
+}
+
+// ValidateGenesis checks the metadata, the aliases and the whitelisted denoms
+// and rejects duplicate metadata, duplicate aliases and alias cycles
+func ValidateGenesis(genesis Genesis) error {
+	seen := make(map[string]bool)
+	for _, md := range genesis.DenomMetadata {
+		if err := md.Validate(); err != nil {
+			return err
+		}
+		if seen[md.Denom] {
+			return fmt.Errorf("duplicate metadata for denom %s", md.Denom)
+		}
+		seen[md.Denom] = true
+	}
+	aliases := make(map[string]string)
+	for _, da := range genesis.DenomAliases {
+		if err := checkDenomAlias(da, func(alias string) (string, bool) {
+			denom, found := aliases[alias]
+			return denom, found
+		}); err != nil {
+			return err
+		}
+		aliases[da.Alias] = da.Denom
+	}
+	if !genesis.Params.AccountCreationFee.IsValid() {
+		return fmt.Errorf("invalid account creation fee: %s", genesis.Params.AccountCreationFee)
+	}
+	for _, denom := range genesis.Params.DenomWhitelist {
+		if !reDenom.MatchString(denom) {
+			return fmt.Errorf("invalid whitelisted denom: %q", denom)
+		}
+	}
+	for _, denom := range genesis.Params.FeeDenoms {
+		if !reDenom.MatchString(denom) {
+			return fmt.Errorf("invalid fee denom: %q", denom)
+		}
+	}
+	if rate := genesis.Params.TransferTaxRate; rate.IsNegative() || rate.GTE(sdk.OneDec()) {
+		return fmt.Errorf("transfer tax rate must be in [0, 1), got %s", rate)
+	}
+	return nil
+}
+
+// KnownDenoms returns the denoms with metadata and the whitelisted denoms of
+// the genesis state
+func KnownDenoms(genesis Genesis) map[string]bool {
+	known := make(map[string]bool)
+	for _, md := range genesis.DenomMetadata {
+		known[md.Denom] = true
+	}
+	for _, denom := range genesis.Params.DenomWhitelist {
+		known[denom] = true
+	}
+	return known
+}
+
+// InitGenesis for the bank module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	if err := ValidateGenesis(genesis); err != nil {
+		return err
+	}
+	for _, md := range genesis.DenomMetadata {
+		k.SetDenomMetadata(ctx, md)
+	}
+	for _, da := range genesis.DenomAliases {
+		k.SetDenomAlias(ctx, da)
+	}
+	k.SetParams(ctx, genesis.Params)
+	return nil
+}
+
+// ExportGenesis for the bank module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	metadata := []DenomMetadata{}
+	k.IterateDenomMetadata(ctx, func(md DenomMetadata) bool {
+		metadata = append(metadata, md)
+		return false
+	})
+	aliases := []DenomAlias{}
+	k.IterateDenomAliases(ctx, func(da DenomAlias) bool {
+		aliases = append(aliases, da)
+		return false
+	})
+	return Genesis{
+		DenomMetadata: metadata,
+		DenomAliases:  aliases,
+		Params:        k.GetParams(ctx),
+	}
+}
@@ -0,0 +1,129 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// NewHandler returns a handler for "bank" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	sdkHandler := sdkbank.NewHandler(k)
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgSend:
+			return handleMsgSend(ctx, k, msg)
+		case sdkbank.MsgSend:
+			return handleSDKMsgSend(ctx, k, msg)
+		case MsgMultiSend:
+			return handleMsgMultiSend(ctx, k, msg)
+		case MsgBurn:
+			return handleMsgBurn(ctx, k, msg)
+		case MsgMintTo:
+			return handleMsgMintTo(ctx, k, msg)
+		case MsgSwap:
+			return handleMsgSwap(ctx, k, msg)
+		default:
+			return sdkHandler(ctx, msg)
+		}
+	}
+}
+
+// Handle MsgSend, tagging the result with the transfer's sender, recipient,
+// amount and memo
+func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
+	_, err := k.SendCoins(ctx, msg.From, msg.To, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	outputs := []sdkbank.Output{sdkbank.NewOutput(msg.To, msg.Amount)}
+	tags := transferTags([]sdkbank.Input{sdkbank.NewInput(msg.From, msg.Amount)}, outputs)
+	return sdk.Result{
+		Tags: withMemo(ctx, k, tags, outputs, msg.Memo),
+	}
+}
+
+// Handle the MsgSend of the SDK bank module, tagging the result with the
+// transfer's sender, recipient and amount
+func handleSDKMsgSend(ctx sdk.Context, k Keeper, msg sdkbank.MsgSend) sdk.Result {
+	_, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: transferTags(msg.Inputs, msg.Outputs),
+	}
+}
+
+// Handle MsgMultiSend, inputs are all checked before any coins move
+func handleMsgMultiSend(ctx sdk.Context, k Keeper, msg MsgMultiSend) sdk.Result {
+	tags, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: withMemo(ctx, k, tags, msg.Outputs, msg.Memo),
+	}
+}
+
+// withMemo tags a transfer with its memo, if any, and keeps the memo for
+// the recipients if the keeper persists memos
+func withMemo(ctx sdk.Context, k Keeper, tags sdk.Tags, outputs []sdkbank.Output, memo string) sdk.Tags {
+	if memo == "" {
+		return tags
+	}
+	k.setLastMemo(ctx, outputs, memo)
+	return tags.AppendTag(TagMemo, []byte(memo))
+}
+
+// Handle MsgBurn, the coins leave the owner's account and the total supply
+func handleMsgBurn(ctx sdk.Context, k Keeper, msg MsgBurn) sdk.Result {
+	msg.Amount = k.ResolveCoins(ctx, msg.Amount)
+	coins := k.GetCoins(ctx, msg.Owner)
+	if !coins.IsAllGTE(msg.Amount) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("cannot burn %s, %s only holds %s", msg.Amount, msg.Owner, coins)).Result()
+	}
+
+	_, tags, err := k.SubtractCoins(ctx, msg.Owner, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+// Handle MsgMintTo, the coins are added to the recipient's account and to
+// the total supply
+func handleMsgMintTo(ctx sdk.Context, k Keeper, msg MsgMintTo) sdk.Result {
+	admin := k.GetParams(ctx).MintAdmin
+	if len(admin) == 0 {
+		return sdk.ErrUnauthorized("minting is disabled").Result()
+	}
+	if !admin.Equals(msg.Minter) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("%s is not the mint admin", msg.Minter)).Result()
+	}
+	if err := k.checkNotFrozen(ctx, msg.Recipient); err != nil {
+		return err.Result()
+	}
+
+	_, tags, err := k.AddCoins(ctx, msg.Recipient, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+// Handle MsgSwap, either both sides of the swap move or neither does
+func handleMsgSwap(ctx sdk.Context, k Keeper, msg MsgSwap) sdk.Result {
+	tags, err := k.Swap(ctx, msg.PartyA, msg.CoinsA, msg.PartyB, msg.CoinsB)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tags,
+	}
+}
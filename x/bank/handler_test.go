@@ -0,0 +1,284 @@
+package bank
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestMsgMultiSendValidateBasic(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	moreCoins := sdk.Coins{sdk.NewInt64Coin("foocoin", 11)}
+
+	tests := []struct {
+		name    string
+		msg     MsgMultiSend
+		expPass bool
+	}{
+		{"balanced", NewMsgMultiSend(
+			[]sdkbank.Input{sdkbank.NewInput(addr1, coins)},
+			[]sdkbank.Output{sdkbank.NewOutput(addr2, coins)},
+		), true},
+		{"no inputs", NewMsgMultiSend(nil, []sdkbank.Output{sdkbank.NewOutput(addr2, coins)}), false},
+		{"no outputs", NewMsgMultiSend([]sdkbank.Input{sdkbank.NewInput(addr1, coins)}, nil), false},
+		{"unbalanced", NewMsgMultiSend(
+			[]sdkbank.Input{sdkbank.NewInput(addr1, coins)},
+			[]sdkbank.Output{sdkbank.NewOutput(addr2, moreCoins)},
+		), false},
+	}
+
+	for _, tc := range tests {
+		err := tc.msg.ValidateBasic()
+		if tc.expPass {
+			require.Nil(t, err, tc.name)
+		} else {
+			require.NotNil(t, err, tc.name)
+		}
+	}
+}
+
+func TestHandleMsgMultiSend(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	sender := sdk.AccAddress([]byte("sender"))
+	recipients := []sdk.AccAddress{
+		sdk.AccAddress([]byte("addr1")),
+		sdk.AccAddress([]byte("addr2")),
+		sdk.AccAddress([]byte("addr3")),
+	}
+	_, _, err := keeper.AddCoins(ctx, sender, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)})
+	require.Nil(t, err)
+
+	outputs := []sdkbank.Output{
+		sdkbank.NewOutput(recipients[0], sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}),
+		sdkbank.NewOutput(recipients[1], sdk.Coins{sdk.NewInt64Coin("foocoin", 20)}),
+		sdkbank.NewOutput(recipients[2], sdk.Coins{sdk.NewInt64Coin("foocoin", 30)}),
+	}
+	msg := NewMsgMultiSend([]sdkbank.Input{sdkbank.NewInput(sender, sdk.Coins{sdk.NewInt64Coin("foocoin", 60)})}, outputs)
+	require.Nil(t, msg.ValidateBasic())
+	require.True(t, handler(ctx, msg).IsOK())
+
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 40)}, ak.GetAccount(ctx, sender).GetCoins())
+	for i, out := range outputs {
+		require.Equal(t, out.Coins, ak.GetAccount(ctx, recipients[i]).GetCoins())
+	}
+
+	// an input that can't pay moves nothing
+	msg = NewMsgMultiSend([]sdkbank.Input{sdkbank.NewInput(sender, sdk.Coins{sdk.NewInt64Coin("foocoin", 60)})}, outputs)
+	require.False(t, handler(ctx, msg).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 40)}, ak.GetAccount(ctx, sender).GetCoins())
+	for i, out := range outputs {
+		require.Equal(t, out.Coins, ak.GetAccount(ctx, recipients[i]).GetCoins())
+	}
+}
+
+func TestHandleMsgBurn(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	owner := sdk.AccAddress([]byte("owner"))
+
+	_, _, err := keeper.AddCoins(ctx, owner, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)})
+	require.Nil(t, err)
+
+	msg := NewMsgBurn(owner, sdk.Coins{sdk.NewInt64Coin("foocoin", 30)})
+	require.Nil(t, msg.ValidateBasic())
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 70)}, ak.GetAccount(ctx, owner).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 70)}, keeper.GetSupply(ctx))
+
+	// burning more than the balance fails and changes nothing
+	res := handler(ctx, NewMsgBurn(owner, sdk.Coins{sdk.NewInt64Coin("foocoin", 71)}))
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 70)}, ak.GetAccount(ctx, owner).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 70)}, keeper.GetSupply(ctx))
+
+	require.NotNil(t, NewMsgBurn(owner, sdk.Coins{}).ValidateBasic())
+}
+
+func TestHandleMsgMintTo(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	admin := sdk.AccAddress([]byte("admin"))
+	other := sdk.AccAddress([]byte("other"))
+	recipient := sdk.AccAddress([]byte("recipient"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}
+
+	// minting is disabled without a mint admin
+	msg := NewMsgMintTo(admin, recipient, coins)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, sdk.CodeUnauthorized, handler(ctx, msg).Code)
+
+	params := keeper.GetParams(ctx)
+	params.MintAdmin = admin
+	keeper.SetParams(ctx, params)
+
+	// only the admin may mint
+	res := handler(ctx, NewMsgMintTo(other, recipient, coins))
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+	require.Nil(t, ak.GetAccount(ctx, recipient))
+	require.Equal(t, sdk.Coins{}, keeper.GetSupply(ctx))
+
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}, ak.GetAccount(ctx, recipient).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}, keeper.GetSupply(ctx))
+
+	require.NotNil(t, NewMsgMintTo(admin, recipient, sdk.Coins{}).ValidateBasic())
+	require.NotNil(t, NewMsgMintTo(admin, nil, coins).ValidateBasic())
+}
+
+func TestHandleMsgSendTags(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	sender := sdk.AccAddress([]byte("sender"))
+	recipient := sdk.AccAddress([]byte("recipient"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	_, _, err := keeper.AddCoins(ctx, sender, coins)
+	require.Nil(t, err)
+
+	msg := sdkbank.NewMsgSend([]sdkbank.Input{sdkbank.NewInput(sender, coins)}, []sdkbank.Output{sdkbank.NewOutput(recipient, coins)})
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK())
+	require.Equal(t, coins, ak.GetAccount(ctx, recipient).GetCoins())
+
+	tags := map[string]string{}
+	for _, tag := range res.Tags {
+		tags[string(tag.Key)] = string(tag.Value)
+	}
+	require.Equal(t, string(ActionTransfer), tags[TagAction])
+	require.Equal(t, sender.String(), tags[TagSender])
+	require.Equal(t, recipient.String(), tags[TagRecipient])
+	require.Equal(t, coins.String(), tags[TagAmount])
+
+	// a failed send emits no tags
+	res = handler(ctx, msg)
+	require.False(t, res.IsOK())
+	require.Empty(t, res.Tags)
+}
+
+func TestMsgSendMemo(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	sender := sdk.AccAddress([]byte("sender"))
+	recipient := sdk.AccAddress([]byte("recipient"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	_, _, err := keeper.AddCoins(ctx, sender, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)})
+	require.Nil(t, err)
+
+	// memo length limits
+	msg := NewMsgSend(sender, recipient, coins, strings.Repeat("a", MaxMemoLength))
+	require.Nil(t, msg.ValidateBasic())
+	msg.Memo = strings.Repeat("a", MaxMemoLength+1)
+	require.Equal(t, sdk.CodeMemoTooLarge, msg.ValidateBasic().Code())
+
+	// the memo is tagged with the transfer but not stored by default
+	msg.Memo = "invoice 42"
+	res := NewHandler(keeper)(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, coins, ak.GetAccount(ctx, recipient).GetCoins())
+	tags := map[string]string{}
+	for _, tag := range res.Tags {
+		tags[string(tag.Key)] = string(tag.Value)
+	}
+	require.Equal(t, sender.String(), tags[TagSender])
+	require.Equal(t, recipient.String(), tags[TagRecipient])
+	require.Equal(t, coins.String(), tags[TagAmount])
+	require.Equal(t, "invoice 42", tags[TagMemo])
+	require.Equal(t, "", keeper.GetLastMemo(ctx, recipient))
+
+	// sends without a memo have no memo tag
+	msg.Memo = ""
+	res = NewHandler(keeper)(ctx, msg)
+	require.True(t, res.IsOK())
+	for _, tag := range res.Tags {
+		require.NotEqual(t, TagMemo, string(tag.Key))
+	}
+
+	// a keeper persisting memos keeps the last one of the recipient
+	persisting := keeper.WithPersistMemos(true)
+	msg.Memo = "invoice 43"
+	require.True(t, NewHandler(persisting)(ctx, msg).IsOK())
+	require.Equal(t, "invoice 43", persisting.GetLastMemo(ctx, recipient))
+	require.Equal(t, "", persisting.GetLastMemo(ctx, sender))
+}
+
+func TestMsgMultiSendMemo(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	sender := sdk.AccAddress([]byte("sender"))
+	recipient := sdk.AccAddress([]byte("recipient"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	_, _, err := keeper.AddCoins(ctx, sender, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)})
+	require.Nil(t, err)
+
+	msg := NewMsgMultiSend([]sdkbank.Input{sdkbank.NewInput(sender, coins)}, []sdkbank.Output{sdkbank.NewOutput(recipient, coins)})
+
+	// memo length limits
+	msg.Memo = strings.Repeat("a", MaxMemoLength)
+	require.Nil(t, msg.ValidateBasic())
+	msg.Memo = strings.Repeat("a", MaxMemoLength+1)
+	require.Equal(t, sdk.CodeMemoTooLarge, msg.ValidateBasic().Code())
+
+	// the memo is tagged but not stored by default
+	msg.Memo = "invoice 42"
+	res := NewHandler(keeper)(ctx, msg)
+	require.True(t, res.IsOK())
+	tags := map[string]string{}
+	for _, tag := range res.Tags {
+		tags[string(tag.Key)] = string(tag.Value)
+	}
+	require.Equal(t, "invoice 42", tags[TagMemo])
+	require.Equal(t, "", keeper.GetLastMemo(ctx, recipient))
+
+	// sends without a memo have no memo tag
+	msg.Memo = ""
+	res = NewHandler(keeper)(ctx, msg)
+	require.True(t, res.IsOK())
+	for _, tag := range res.Tags {
+		require.NotEqual(t, TagMemo, string(tag.Key))
+	}
+
+	// a keeper persisting memos keeps the last one per recipient
+	persisting := keeper.WithPersistMemos(true)
+	msg.Memo = "invoice 43"
+	require.True(t, NewHandler(persisting)(ctx, msg).IsOK())
+	require.Equal(t, "invoice 43", persisting.GetLastMemo(ctx, recipient))
+	require.Equal(t, "", persisting.GetLastMemo(ctx, sender))
+}
+
+func TestHandleMsgSwap(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	alice := sdk.AccAddress([]byte("alice"))
+	bob := sdk.AccAddress([]byte("bob"))
+	foo := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	bar := sdk.Coins{sdk.NewInt64Coin("barcoin", 5)}
+
+	_, _, err := keeper.AddCoins(ctx, alice, foo)
+	require.Nil(t, err)
+	_, _, err = keeper.AddCoins(ctx, bob, bar)
+	require.Nil(t, err)
+
+	msg := NewMsgSwap(alice, foo, bob, bar)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{alice, bob}, msg.GetSigners())
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, bar, ak.GetAccount(ctx, alice).GetCoins())
+	require.Equal(t, foo, ak.GetAccount(ctx, bob).GetCoins())
+
+	// if either side lacks funds nothing moves
+	res := handler(ctx, NewMsgSwap(alice, bar, bob, sdk.Coins{sdk.NewInt64Coin("foocoin", 11)}))
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code)
+	require.Equal(t, bar, ak.GetAccount(ctx, alice).GetCoins())
+	require.Equal(t, foo, ak.GetAccount(ctx, bob).GetCoins())
+
+	require.NotNil(t, NewMsgSwap(alice, foo, alice, bar).ValidateBasic())
+	require.NotNil(t, NewMsgSwap(alice, sdk.Coins{}, bob, bar).ValidateBasic())
+}
@@ -0,0 +1,38 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SendHook is run before every transfer made through the keeper, an error
+// aborts the transfer. It lets plugins such as recipient allowlists veto
+// transfers without changing the keeper.
+type SendHook interface {
+	BeforeSend(ctx sdk.Context, from, to sdk.AccAddress, coins sdk.Coins) error
+}
+
+// AddSendHook returns a copy of the keeper also running the hook before
+// transfers, after the hooks added before it. Hooks must be added before
+// the keeper is handed to other keepers.
+func (k Keeper) AddSendHook(hook SendHook) Keeper {
+	hooks := make([]SendHook, len(k.sendHooks), len(k.sendHooks)+1)
+	copy(hooks, k.sendHooks)
+	k.sendHooks = append(hooks, hook)
+	return k
+}
+
+// beforeSend runs the send hooks in the order they were added and returns
+// the first error
+func (k Keeper) beforeSend(ctx sdk.Context, from, to sdk.AccAddress, coins sdk.Coins) sdk.Error {
+	for _, hook := range k.sendHooks {
+		err := hook.BeforeSend(ctx, from, to, coins)
+		if err == nil {
+			continue
+		}
+		if sdkErr, ok := err.(sdk.Error); ok {
+			return sdkErr
+		}
+		return sdk.ErrUnauthorized(err.Error())
+	}
+	return nil
+}
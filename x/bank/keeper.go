@@ -0,0 +1,489 @@
+package bank
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper extends the sdk bank keeper with tracking of the total supply.
+// Coins created by AddCoins and removed by SubtractCoins are minted and
+// burned, while transfers between accounts leave the supply untouched.
+// Transfers may not spend coins still locked in a vesting account, nor
+// move coins from or to a frozen account. Sending coins to an address
+// without an account charges the sender the account creation fee, and a
+// transfer tax may be kept from the recipient for the community pool. Send
+// hooks may veto any transfer. Coins given in a denom alias are moved in its
+// canonical denom.
+type Keeper struct {
+	sdkbank.BaseKeeper
+
+	key        sdk.StoreKey
+	cdc        *codec.Codec
+	ak         auth.AccountKeeper
+	fck        auth.FeeCollectionKeeper
+	paramSpace params.Subspace
+
+	// whether the last transfer memo of each recipient is stored
+	persistMemos bool
+
+	// run in order before every transfer
+	sendHooks []SendHook
+
+	// receives the transfer taxes, which are only charged if set
+	pool CommunityPool
+
+	// stores the accounts of new recipients if set, instead of the base
+	// keeper
+	accounts AccountCreator
+}
+
+// CommunityPool is the pool credited with the transfer taxes and spent
+// from by the admin
+type CommunityPool interface {
+	AddToCommunityPool(ctx sdk.Context, coins sdk.Coins)
+	SubtractFromCommunityPool(ctx sdk.Context, coins sdk.Coins) sdk.Error
+}
+
+var _ sdkbank.Keeper = Keeper{}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, ak auth.AccountKeeper, fck auth.FeeCollectionKeeper,
+	paramSpace params.Subspace) Keeper {
+	return Keeper{
+		BaseKeeper: sdkbank.NewBaseKeeper(ak),
+		key:        key,
+		cdc:        cdc,
+		ak:         ak,
+		fck:        fck,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+	}
+}
+
+// WithPersistMemos returns a copy of the keeper that stores the last
+// transfer memo received by each recipient
+func (k Keeper) WithPersistMemos(persist bool) Keeper {
+	k.persistMemos = persist
+	return k
+}
+
+// AccountCreator creates and stores accounts, so account creation hooks see
+// the accounts of addresses receiving coins for the first time
+type AccountCreator interface {
+	NewAccountWithAddress(ctx sdk.Context, addr sdk.AccAddress) auth.Account
+	SetAccount(ctx sdk.Context, acc auth.Account)
+}
+
+// WithAccountCreator returns a copy of the keeper creating the accounts of
+// new recipients through the creator
+func (k Keeper) WithAccountCreator(creator AccountCreator) Keeper {
+	k.accounts = creator
+	return k
+}
+
+// WithCommunityPool returns a copy of the keeper that charges the transfer
+// tax and moves it to the pool, and that can spend from the pool
+func (k Keeper) WithCommunityPool(pool CommunityPool) Keeper {
+	k.pool = pool
+	return k
+}
+
+var (
+	supplyKey       = []byte("supply")
+	frozenKeyPrefix = []byte("frozen:")
+	memoKeyPrefix   = []byte("memo:")
+)
+
+func getFrozenKey(addr sdk.AccAddress) []byte {
+	return append(frozenKeyPrefix, addr.Bytes()...)
+}
+
+func getMemoKey(addr sdk.AccAddress) []byte {
+	return append(memoKeyPrefix, addr.Bytes()...)
+}
+
+// GetLastMemo returns the memo of the last transfer received by the
+// account, only kept if the keeper persists memos
+func (k Keeper) GetLastMemo(ctx sdk.Context, addr sdk.AccAddress) string {
+	store := ctx.KVStore(k.key)
+	return string(store.Get(getMemoKey(addr)))
+}
+
+// setLastMemo stores the memo of a transfer for its recipients if the keeper
+// persists memos
+func (k Keeper) setLastMemo(ctx sdk.Context, outputs []sdkbank.Output, memo string) {
+	if !k.persistMemos || memo == "" {
+		return
+	}
+	store := ctx.KVStore(k.key)
+	for _, out := range outputs {
+		store.Set(getMemoKey(out.Address), []byte(memo))
+	}
+}
+
+// GetSupply returns the total supply of coins
+func (k Keeper) GetSupply(ctx sdk.Context) (supply sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(supplyKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &supply)
+	return supply
+}
+
+// SetSupply sets the total supply of coins
+func (k Keeper) SetSupply(ctx sdk.Context, supply sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(supply)
+	store.Set(supplyKey, bz)
+}
+
+// IsFrozen returns whether the account is frozen
+func (k Keeper) IsFrozen(ctx sdk.Context, addr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.key)
+	return store.Has(getFrozenKey(addr))
+}
+
+// SetFrozen freezes or unfreezes the account
+func (k Keeper) SetFrozen(ctx sdk.Context, addr sdk.AccAddress, frozen bool) {
+	store := ctx.KVStore(k.key)
+	if frozen {
+		store.Set(getFrozenKey(addr), []byte{0x01})
+	} else {
+		store.Delete(getFrozenKey(addr))
+	}
+}
+
+// HasCoins returns whether the account holds the coins
+func (k Keeper) HasCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) bool {
+	return k.BaseKeeper.HasCoins(ctx, addr, k.ResolveCoins(ctx, amt))
+}
+
+// AddCoins adds coins to the account and to the total supply
+func (k Keeper) AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	amt = k.ResolveCoins(ctx, amt)
+	k.createAccounts(ctx, addr)
+	coins, tags, err := k.BaseKeeper.AddCoins(ctx, addr, amt)
+	if err != nil {
+		return coins, tags, err
+	}
+	k.SetSupply(ctx, k.GetSupply(ctx).Plus(amt))
+	return coins, tags, nil
+}
+
+// SubtractCoins subtracts coins from the account and from the total supply,
+// refusing to debit a frozen account or coins still vesting
+func (k Keeper) SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	amt = k.ResolveCoins(ctx, amt)
+	if err := k.checkDebit(ctx, addr, amt); err != nil {
+		return nil, nil, err
+	}
+	coins, tags, err := k.BaseKeeper.SubtractCoins(ctx, addr, amt)
+	if err != nil {
+		return coins, tags, err
+	}
+	k.SetSupply(ctx, k.GetSupply(ctx).Minus(amt))
+	return coins, tags, nil
+}
+
+// SpendCommunityPool moves coins from the community pool to the recipient,
+// leaving the total supply untouched. It fails if the pool holds less.
+func (k Keeper) SpendCommunityPool(ctx sdk.Context, recipient sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	if k.pool == nil {
+		return sdk.ErrInternal("no community pool to spend from")
+	}
+	amt = k.ResolveCoins(ctx, amt)
+	if err := k.checkNotFrozen(ctx, recipient); err != nil {
+		return err
+	}
+	if err := k.pool.SubtractFromCommunityPool(ctx, amt); err != nil {
+		return err
+	}
+	k.createAccounts(ctx, recipient)
+	_, _, err := k.BaseKeeper.AddCoins(ctx, recipient, amt)
+	return err
+}
+
+// SendCoins moves coins between accounts, refusing to spend vesting coins,
+// to debit locked accounts or to touch frozen accounts. The transfer tax is
+// kept from the coins received.
+func (k Keeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	amt = k.ResolveCoins(ctx, amt)
+	fee := k.accountCreationFee(ctx, toAddr)
+	if err := k.checkDebit(ctx, fromAddr, amt.Plus(fee)); err != nil {
+		return nil, err
+	}
+	if err := k.checkNotFrozen(ctx, toAddr); err != nil {
+		return nil, err
+	}
+	if err := k.beforeSend(ctx, fromAddr, toAddr, amt); err != nil {
+		return nil, err
+	}
+	if err := k.chargeFee(ctx, fromAddr, fee); err != nil {
+		return nil, err
+	}
+	tax := k.transferTax(ctx, amt)
+	if err := k.payTax(ctx, fromAddr, tax); err != nil {
+		return nil, err
+	}
+	k.createAccounts(ctx, toAddr)
+	return k.BaseKeeper.SendCoins(ctx, fromAddr, toAddr, amt.Minus(tax))
+}
+
+// MoveCoins moves coins between accounts on behalf of another module, such
+// as a fee grant, refusing to debit a frozen, locked or vesting account or
+// to credit a frozen one. Unlike SendCoins no transfer tax or account
+// creation fee is charged and the send hooks don't run.
+func (k Keeper) MoveCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	amt = k.ResolveCoins(ctx, amt)
+	if err := k.checkDebit(ctx, fromAddr, amt); err != nil {
+		return err
+	}
+	if err := k.checkNotFrozen(ctx, toAddr); err != nil {
+		return err
+	}
+	k.createAccounts(ctx, toAddr)
+	_, err := k.BaseKeeper.SendCoins(ctx, fromAddr, toAddr, amt)
+	return err
+}
+
+// InputOutputCoins handles a list of inputs and outputs, refusing to spend
+// vesting coins, to debit locked accounts or to touch frozen accounts. The
+// first input pays the account creation fee of every output address without
+// an account. The coins of an output can't be traced to one input, so the
+// send hooks run for every input and output pair with the coins of the
+// output. The transfer tax is kept from the coins of every output.
+func (k Keeper) InputOutputCoins(ctx sdk.Context, inputs []sdkbank.Input, outputs []sdkbank.Output) (sdk.Tags, sdk.Error) {
+	inputs, outputs = k.resolveInputs(ctx, inputs), k.resolveOutputs(ctx, outputs)
+
+	addrs := make([]sdk.AccAddress, len(outputs))
+	for i, out := range outputs {
+		if err := k.checkNotFrozen(ctx, out.Address); err != nil {
+			return nil, err
+		}
+		addrs[i] = out.Address
+	}
+
+	fee := k.accountCreationFee(ctx, addrs...)
+	for i, in := range inputs {
+		spent := in.Coins
+		if i == 0 {
+			spent = spent.Plus(fee)
+		}
+		if err := k.checkDebit(ctx, in.Address, spent); err != nil {
+			return nil, err
+		}
+		for _, out := range outputs {
+			if err := k.beforeSend(ctx, in.Address, out.Address, out.Coins); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(inputs) > 0 {
+		if err := k.chargeFee(ctx, inputs[0].Address, fee); err != nil {
+			return nil, err
+		}
+	}
+
+	// the inputs are debited in full while the outputs are credited without
+	// their tax, which goes to the community pool instead
+	tax := sdk.Coins{}
+	taxed := make([]sdkbank.Output, len(outputs))
+	for i, out := range outputs {
+		outTax := k.transferTax(ctx, out.Coins)
+		tax = tax.Plus(outTax)
+		taxed[i] = sdkbank.NewOutput(out.Address, out.Coins.Minus(outTax))
+	}
+	k.createAccounts(ctx, addrs...)
+	tags, err := k.BaseKeeper.InputOutputCoins(ctx, inputs, taxed)
+	if err != nil {
+		return nil, err
+	}
+	if !tax.IsZero() {
+		k.pool.AddToCommunityPool(ctx, tax)
+	}
+	return tags, nil
+}
+
+// EscrowKeeper moves coins between accounts and a module holding them
+// outside of any account, such as bonded stake. The total supply is left
+// untouched and debits are checked like transfers.
+type EscrowKeeper struct {
+	Keeper
+}
+
+var _ sdkbank.Keeper = EscrowKeeper{}
+
+// Escrow returns the escrow keeper of the bank keeper
+func (k Keeper) Escrow() EscrowKeeper {
+	return EscrowKeeper{k}
+}
+
+// AddCoins returns coins from the escrow to the account
+func (ek EscrowKeeper) AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	amt = ek.ResolveCoins(ctx, amt)
+	ek.createAccounts(ctx, addr)
+	return ek.BaseKeeper.AddCoins(ctx, addr, amt)
+}
+
+// SubtractCoins moves coins from the account to the escrow, refusing to
+// debit a frozen account or coins still vesting
+func (ek EscrowKeeper) SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	amt = ek.ResolveCoins(ctx, amt)
+	if err := ek.checkDebit(ctx, addr, amt); err != nil {
+		return nil, nil, err
+	}
+	return ek.BaseKeeper.SubtractCoins(ctx, addr, amt)
+}
+
+// Swap moves coinsA from addrA to addrB and coinsB from addrB to addrA in a
+// cached context that is only written if both sides succeed
+func (k Keeper) Swap(ctx sdk.Context, addrA sdk.AccAddress, coinsA sdk.Coins, addrB sdk.AccAddress,
+	coinsB sdk.Coins) (sdk.Tags, sdk.Error) {
+	cacheCtx, write := ctx.CacheContext()
+	tags, err := k.InputOutputCoins(cacheCtx,
+		[]sdkbank.Input{sdkbank.NewInput(addrA, coinsA), sdkbank.NewInput(addrB, coinsB)},
+		[]sdkbank.Output{sdkbank.NewOutput(addrB, coinsA), sdkbank.NewOutput(addrA, coinsB)},
+	)
+	if err != nil {
+		return nil, err
+	}
+	write()
+	return tags, nil
+}
+
+// accountCreationFee returns the fee owed for sending coins to the addresses,
+// one account creation fee per distinct address without an account
+func (k Keeper) accountCreationFee(ctx sdk.Context, addrs ...sdk.AccAddress) sdk.Coins {
+	fee := sdk.Coins{}
+	perAccount := k.GetParams(ctx).AccountCreationFee
+	if perAccount.IsZero() {
+		return fee
+	}
+
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if seen[addr.String()] || k.ak.GetAccount(ctx, addr) != nil {
+			continue
+		}
+		seen[addr.String()] = true
+		fee = fee.Plus(perAccount)
+	}
+	return fee
+}
+
+// createAccounts creates the missing accounts of the addresses through the
+// account creator, if any. Otherwise the base keeper creates them.
+func (k Keeper) createAccounts(ctx sdk.Context, addrs ...sdk.AccAddress) {
+	if k.accounts == nil {
+		return
+	}
+	for _, addr := range addrs {
+		if k.ak.GetAccount(ctx, addr) == nil {
+			k.accounts.SetAccount(ctx, k.accounts.NewAccountWithAddress(ctx, addr))
+		}
+	}
+}
+
+// chargeFee moves the fee from the account to the fee collector, leaving
+// the total supply untouched
+func (k Keeper) chargeFee(ctx sdk.Context, addr sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	if fee.IsZero() {
+		return nil
+	}
+	_, _, err := k.BaseKeeper.SubtractCoins(ctx, addr, fee)
+	if err != nil {
+		return err
+	}
+	k.fck.AddCollectedFees(ctx, fee)
+	return nil
+}
+
+// transferTax returns the tax kept from the coins of a transfer, rounded
+// down for every denom in favor of the recipient. No tax is charged without
+// a community pool to receive it.
+func (k Keeper) transferTax(ctx sdk.Context, amt sdk.Coins) sdk.Coins {
+	tax := sdk.Coins{}
+	rate := k.GetParams(ctx).TransferTaxRate
+	if k.pool == nil || !rate.IsPositive() {
+		return tax
+	}
+	for _, coin := range amt {
+		amount := sdk.NewDecFromInt(coin.Amount).Mul(rate).TruncateInt()
+		if amount.IsPositive() {
+			tax = append(tax, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return tax
+}
+
+// payTax moves the tax from the account to the community pool, leaving the
+// total supply untouched
+func (k Keeper) payTax(ctx sdk.Context, addr sdk.AccAddress, tax sdk.Coins) sdk.Error {
+	if tax.IsZero() {
+		return nil
+	}
+	_, _, err := k.BaseKeeper.SubtractCoins(ctx, addr, tax)
+	if err != nil {
+		return err
+	}
+	k.pool.AddToCommunityPool(ctx, tax)
+	return nil
+}
+
+// checkSpendable returns an error if amt exceeds the coins of a vesting
+// account that are unlocked at the current block time. Other accounts are
+// left to the base keeper.
+func (k Keeper) checkSpendable(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	vacc, ok := k.ak.GetAccount(ctx, addr).(auth.VestingAccount)
+	if !ok {
+		return nil
+	}
+	spendable := vacc.SpendableCoins(ctx.BlockHeader().Time)
+	if !spendable.IsAllGTE(amt) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient spendable coins: %s < %s", spendable, amt))
+	}
+	return nil
+}
+
+// lockedAccount is an account that cannot send coins before its unlock height
+type lockedAccount interface {
+	GetUnlockHeight() int64
+}
+
+// checkUnlocked returns an error if the unlock height of the account has
+// not been reached yet
+func (k Keeper) checkUnlocked(ctx sdk.Context, addr sdk.AccAddress) sdk.Error {
+	acc, ok := k.ak.GetAccount(ctx, addr).(lockedAccount)
+	if ok && acc.GetUnlockHeight() > ctx.BlockHeight() {
+		return sdk.ErrUnauthorized(fmt.Sprintf("account %s is locked until height %d", addr, acc.GetUnlockHeight()))
+	}
+	return nil
+}
+
+// checkDebit returns an error if coins can't be taken from the account,
+// for any purpose
+func (k Keeper) checkDebit(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	if err := k.checkNotFrozen(ctx, addr); err != nil {
+		return err
+	}
+	if err := k.checkUnlocked(ctx, addr); err != nil {
+		return err
+	}
+	return k.checkSpendable(ctx, addr, amt)
+}
+
+// checkNotFrozen returns an error if any of the accounts is frozen
+func (k Keeper) checkNotFrozen(ctx sdk.Context, addrs ...sdk.AccAddress) sdk.Error {
+	for _, addr := range addrs {
+		if k.IsFrozen(ctx, addr) {
+			return sdk.ErrUnauthorized(fmt.Sprintf("account %s is frozen", addr))
+		}
+	}
+	return nil
+}
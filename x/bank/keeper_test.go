@@ -0,0 +1,538 @@
+package bank
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyBank := sdk.NewKVStoreKey("bank")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyFee := sdk.NewKVStoreKey(auth.FeeStoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFee, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	cdc.RegisterConcrete(&auth.ContinuousVestingAccount{}, "auth/ContinuousVestingAccount", nil)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFee)
+	keeper := NewKeeper(keyBank, cdc, ak, fck, pk.Subspace(DefaultParamspace))
+	keeper.SetParams(ctx, DefaultParams())
+
+	return ctx, ak, keeper
+}
+
+func TestKeeperSupply(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	require.True(t, keeper.GetSupply(ctx).IsZero())
+
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+
+	// transfers leave the supply untouched
+	_, err = keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+
+	_, _, err = keeper.SubtractCoins(ctx, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 7)}, keeper.GetSupply(ctx))
+
+	// failed subtractions don't change the supply
+	_, _, err = keeper.SubtractCoins(ctx, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 7)}, keeper.GetSupply(ctx))
+}
+
+func TestKeeperVestingTransfers(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	now := time.Now()
+	end := now.Add(24 * time.Hour)
+	ba := &auth.BaseAccount{Address: addr1, Coins: sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}}
+	ak.SetAccount(ctx, auth.NewContinuousVestingAccount(ba, now.Unix(), end.Unix()))
+
+	// before the vesting window every coin is locked
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now.Add(-time.Hour)})
+	_, err := keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+
+	// halfway through only the vested half can be sent
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now.Add(12 * time.Hour)})
+	_, err = keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 51)})
+	require.NotNil(t, err)
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 51)})},
+		[]sdkbank.Output{sdkbank.NewOutput(addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 51)})},
+	)
+	require.NotNil(t, err)
+	_, err = keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
+	require.Nil(t, err)
+
+	// locked coins can't be taken for IBC transfers, burns or bonds either
+	_, _, err = keeper.SubtractCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+	_, _, err = keeper.Escrow().SubtractCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}, ak.GetAccount(ctx, addr1).GetCoins())
+
+	// after the window the rest is unlocked
+	ctx = ctx.WithBlockHeader(abci.Header{Time: end.Add(time.Hour)})
+	_, err = keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
+	require.Nil(t, err)
+	require.True(t, ak.GetAccount(ctx, addr1).GetCoins().IsZero())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}, ak.GetAccount(ctx, addr2).GetCoins())
+}
+
+func TestKeeperFrozenAccounts(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+
+	// frozen senders can't send
+	keeper.SetFrozen(ctx, addr1, true)
+	require.True(t, keeper.IsFrozen(ctx, addr1))
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, coins)},
+		[]sdkbank.Output{sdkbank.NewOutput(addr2, coins)},
+	)
+	require.NotNil(t, err)
+
+	// nor have coins taken for IBC transfers, burns or bonds
+	_, _, err = keeper.SubtractCoins(ctx, addr1, coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	_, _, err = keeper.Escrow().SubtractCoins(ctx, addr1, coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, ak.GetAccount(ctx, addr1).GetCoins())
+
+	// and frozen recipients can't receive
+	keeper.SetFrozen(ctx, addr1, false)
+	keeper.SetFrozen(ctx, addr2, true)
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, ak.GetAccount(ctx, addr1).GetCoins())
+
+	keeper.SetFrozen(ctx, addr2, false)
+	require.False(t, keeper.IsFrozen(ctx, addr2))
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	require.Equal(t, coins, ak.GetAccount(ctx, addr2).GetCoins())
+}
+
+func TestKeeperEscrow(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("addr1"))
+	escrow := keeper.Escrow()
+
+	_, _, err := keeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+
+	// escrowed coins leave the account but not the supply
+	_, _, err = escrow.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 6)}, ak.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+
+	_, _, err = escrow.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, ak.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+}
+
+func TestKeeperAccountCreationFee(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+	fee := sdk.Coins{sdk.NewInt64Coin("foocoin", 2)}
+	params := DefaultParams()
+	params.AccountCreationFee = fee
+	keeper.SetParams(ctx, params)
+
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+
+	// the first send to a new address charges the fee
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 7)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, coins, ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, fee, keeper.fck.GetCollectedFees(ctx))
+
+	// subsequent sends don't
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 6)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, fee, keeper.fck.GetCollectedFees(ctx))
+
+	// the first input pays once per new output address
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)})},
+		[]sdkbank.Output{
+			sdkbank.NewOutput(addr2, coins),
+			sdkbank.NewOutput(addr3, coins),
+			sdkbank.NewOutput(addr3, coins),
+		},
+	)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)}, keeper.fck.GetCollectedFees(ctx))
+
+	// senders who can't cover the fee can't create accounts
+	_, err = keeper.SendCoins(ctx, addr1, sdk.AccAddress([]byte("addr4")), coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}, ak.GetAccount(ctx, addr1).GetCoins())
+
+	// the supply is untouched by fees
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+}
+
+// testPool is a community pool keeping the coins it is credited
+type testPool struct {
+	coins *sdk.Coins
+}
+
+func (p testPool) AddToCommunityPool(_ sdk.Context, coins sdk.Coins) {
+	*p.coins = p.coins.Plus(coins)
+}
+
+func (p testPool) SubtractFromCommunityPool(_ sdk.Context, coins sdk.Coins) sdk.Error {
+	if !p.coins.IsAllGTE(coins) {
+		return sdk.ErrInsufficientCoins("")
+	}
+	*p.coins = p.coins.Minus(coins)
+	return nil
+}
+
+func TestKeeperTransferTax(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	pool := testPool{&sdk.Coins{}}
+	keeper = keeper.WithCommunityPool(pool)
+	params := DefaultParams()
+	params.TransferTaxRate = sdk.NewDecWithPrec(15, 3) // 1.5%
+	keeper.SetParams(ctx, params)
+
+	cases := []struct {
+		amount   int64
+		tax      int64
+		received int64
+	}{
+		{1, 0, 1},
+		{66, 0, 66},
+		{67, 1, 66},
+		{100, 1, 99},
+		{200, 3, 197},
+		{1000, 15, 985},
+		{123456, 1851, 121605},
+	}
+	for i, tc := range cases {
+		from := sdk.AccAddress([]byte(fmt.Sprintf("from%d", i)))
+		to := sdk.AccAddress([]byte(fmt.Sprintf("to%d", i)))
+		_, _, err := keeper.AddCoins(ctx, from, sdk.Coins{sdk.NewInt64Coin("foocoin", tc.amount)})
+		require.Nil(t, err)
+		before := *pool.coins
+
+		_, err = keeper.SendCoins(ctx, from, to, sdk.Coins{sdk.NewInt64Coin("foocoin", tc.amount)})
+		require.Nil(t, err, "case %d", i)
+		require.True(t, ak.GetAccount(ctx, from).GetCoins().IsZero(), "case %d", i)
+		require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", tc.received)}, ak.GetAccount(ctx, to).GetCoins(), "case %d", i)
+		require.Equal(t, before.Plus(sdk.Coins{sdk.NewInt64Coin("foocoin", tc.tax)}), *pool.coins, "case %d", i)
+	}
+
+	// every output of a multi send is taxed on its own coins
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("barcoin", 400), sdk.NewInt64Coin("foocoin", 300)})
+	require.Nil(t, err)
+	before := *pool.coins
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("barcoin", 400), sdk.NewInt64Coin("foocoin", 300)})},
+		[]sdkbank.Output{
+			sdkbank.NewOutput(addr2, sdk.Coins{sdk.NewInt64Coin("barcoin", 400), sdk.NewInt64Coin("foocoin", 50)}),
+			sdkbank.NewOutput(addr3, sdk.Coins{sdk.NewInt64Coin("foocoin", 250)}),
+		},
+	)
+	require.Nil(t, err)
+	require.True(t, ak.GetAccount(ctx, addr1).GetCoins().IsZero())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("barcoin", 394), sdk.NewInt64Coin("foocoin", 50)}, ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 247)}, ak.GetAccount(ctx, addr3).GetCoins())
+	require.Equal(t, before.Plus(sdk.Coins{sdk.NewInt64Coin("barcoin", 6), sdk.NewInt64Coin("foocoin", 3)}), *pool.coins)
+
+	// the supply counts the taxes held by the pool
+	held := sdk.Coins{}
+	ak.IterateAccounts(ctx, func(acc auth.Account) bool {
+		held = held.Plus(acc.GetCoins())
+		return false
+	})
+	require.Equal(t, keeper.GetSupply(ctx), held.Plus(*pool.coins))
+
+	// without a pool no tax is charged
+	untaxed := keeper.WithCommunityPool(nil)
+	_, err = untaxed.SendCoins(ctx, addr2, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}, ak.GetAccount(ctx, addr1).GetCoins())
+}
+
+func TestKeeperMoveCoins(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	pool := testPool{&sdk.Coins{}}
+	keeper = keeper.WithCommunityPool(pool)
+	params := DefaultParams()
+	params.TransferTaxRate = sdk.NewDecWithPrec(1, 1) // 10%
+	params.AccountCreationFee = sdk.Coins{sdk.NewInt64Coin("foocoin", 2)}
+	keeper.SetParams(ctx, params)
+
+	from := sdk.AccAddress([]byte("from"))
+	to := sdk.AccAddress([]byte("to"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}
+	_, _, err := keeper.AddCoins(ctx, from, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)})
+	require.Nil(t, err)
+
+	// the coins arrive in full, without tax or account creation fee
+	require.Nil(t, keeper.MoveCoins(ctx, from, to, coins))
+	require.Equal(t, coins, ak.GetAccount(ctx, from).GetCoins())
+	require.Equal(t, coins, ak.GetAccount(ctx, to).GetCoins())
+	require.True(t, pool.coins.IsZero())
+	require.True(t, keeper.fck.GetCollectedFees(ctx).IsZero())
+
+	// frozen accounts are refused on both sides
+	keeper.SetFrozen(ctx, to, true)
+	require.NotNil(t, keeper.MoveCoins(ctx, from, to, coins))
+	require.NotNil(t, keeper.MoveCoins(ctx, to, from, coins))
+	require.Equal(t, coins, ak.GetAccount(ctx, from).GetCoins())
+}
+
+func TestKeeperSpendCommunityPool(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("addr"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 30)}
+
+	// there is nothing to spend without a pool
+	require.NotNil(t, keeper.SpendCommunityPool(ctx, addr, coins))
+
+	pool := testPool{&sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}}
+	keeper = keeper.WithCommunityPool(pool)
+	keeper.SetSupply(ctx, *pool.coins)
+
+	// the coins move from the pool to the recipient, the supply untouched
+	require.Nil(t, keeper.SpendCommunityPool(ctx, addr, coins))
+	require.Equal(t, coins, ak.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 20)}, *pool.coins)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 50)}, keeper.GetSupply(ctx))
+
+	// the pool can't be overspent
+	err := keeper.SpendCommunityPool(ctx, addr, coins)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+	require.Equal(t, coins, ak.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 20)}, *pool.coins)
+}
+
+func TestValidateGenesisTransferTaxRate(t *testing.T) {
+	genesis := DefaultGenesis()
+	genesis.Params.TransferTaxRate = sdk.NewDecWithPrec(99, 2)
+	require.Nil(t, ValidateGenesis(genesis))
+	genesis.Params.TransferTaxRate = sdk.OneDec()
+	require.NotNil(t, ValidateGenesis(genesis))
+	genesis.Params.TransferTaxRate = sdk.NewDec(-1)
+	require.NotNil(t, ValidateGenesis(genesis))
+}
+
+// recordCreator creates accounts through the account keeper, recording
+// their addresses
+type recordCreator struct {
+	auth.AccountKeeper
+	created *[]sdk.AccAddress
+}
+
+func (c recordCreator) SetAccount(ctx sdk.Context, acc auth.Account) {
+	*c.created = append(*c.created, acc.GetAddress())
+	c.AccountKeeper.SetAccount(ctx, acc)
+}
+
+func TestKeeperAccountCreator(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	var created []sdk.AccAddress
+	keeper = keeper.WithAccountCreator(recordCreator{ak, &created})
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+
+	// recipients without an account are created through the creator once
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 2)})},
+		[]sdkbank.Output{sdkbank.NewOutput(addr2, coins), sdkbank.NewOutput(addr3, coins)},
+	)
+	require.Nil(t, err)
+	require.Equal(t, []sdk.AccAddress{addr1, addr2, addr3}, created)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)}, ak.GetAccount(ctx, addr2).GetCoins())
+}
+
+// blockRecipientHook rejects transfers to an address
+type blockRecipientHook struct {
+	blocked sdk.AccAddress
+}
+
+func (h blockRecipientHook) BeforeSend(_ sdk.Context, _, to sdk.AccAddress, _ sdk.Coins) error {
+	if to.Equals(h.blocked) {
+		return fmt.Errorf("recipient %s is blocked", to)
+	}
+	return nil
+}
+
+// recordHook records its name in the order hooks are run
+type recordHook struct {
+	name  string
+	order *[]string
+}
+
+func (h recordHook) BeforeSend(_ sdk.Context, _, _ sdk.AccAddress, _ sdk.Coins) error {
+	*h.order = append(*h.order, h.name)
+	return nil
+}
+
+func TestKeeperSendHooks(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	blocked := sdk.AccAddress([]byte("blocked"))
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 2)}
+
+	var order []string
+	keeper = keeper.
+		AddSendHook(recordHook{"first", &order}).
+		AddSendHook(blockRecipientHook{blocked}).
+		AddSendHook(recordHook{"last", &order})
+
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+
+	// hooks run in the order they were added
+	_, err = keeper.SendCoins(ctx, addr1, addr2, coins)
+	require.Nil(t, err)
+	require.Equal(t, []string{"first", "last"}, order)
+
+	// a failing hook aborts the send and skips the later hooks
+	order = nil
+	_, err = keeper.SendCoins(ctx, addr1, blocked, coins)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeUnauthorized, err.Code())
+	require.Equal(t, []string{"first"}, order)
+	require.Nil(t, ak.GetAccount(ctx, blocked))
+
+	// as well as multi sends with a blocked output
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)})},
+		[]sdkbank.Output{sdkbank.NewOutput(addr2, coins), sdkbank.NewOutput(blocked, coins)},
+	)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 8)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, coins, ak.GetAccount(ctx, addr2).GetCoins())
+}
+
+func TestKeeperDenomAlias(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	_, _, err := keeper.AddCoins(ctx, addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+	require.Nil(t, keeper.RegisterDenomAlias(ctx, NewDenomAlias("oldcoin", "foocoin")))
+	require.Equal(t, "foocoin", keeper.ResolveDenom(ctx, "oldcoin"))
+	require.Equal(t, "barcoin", keeper.ResolveDenom(ctx, "barcoin"))
+
+	// coins sent in the alias move in the canonical denom
+	_, err = keeper.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewInt64Coin("oldcoin", 4)})
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 6)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 4)}, ak.GetAccount(ctx, addr2).GetCoins())
+	require.True(t, keeper.HasCoins(ctx, addr2, sdk.Coins{sdk.NewInt64Coin("oldcoin", 4)}))
+
+	// as do multi sends, adding up both names of the denom
+	_, err = keeper.InputOutputCoins(ctx,
+		[]sdkbank.Input{sdkbank.NewInput(addr1, sdk.Coins{sdk.NewInt64Coin("foocoin", 1), sdk.NewInt64Coin("oldcoin", 2)})},
+		[]sdkbank.Output{sdkbank.NewOutput(addr2, sdk.Coins{sdk.NewInt64Coin("oldcoin", 3)})},
+	)
+	require.Nil(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)}, ak.GetAccount(ctx, addr1).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 7)}, ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}, keeper.GetSupply(ctx))
+
+	// aliases resolve through aliases of aliases
+	require.Nil(t, keeper.RegisterDenomAlias(ctx, NewDenomAlias("ancientcoin", "oldcoin")))
+	require.Equal(t, "foocoin", keeper.ResolveDenom(ctx, "ancientcoin"))
+
+	// an alias is registered once and can't create a cycle
+	require.NotNil(t, keeper.RegisterDenomAlias(ctx, NewDenomAlias("oldcoin", "barcoin")))
+	require.NotNil(t, keeper.RegisterDenomAlias(ctx, NewDenomAlias("foocoin", "ancientcoin")))
+	require.NotNil(t, keeper.RegisterDenomAlias(ctx, NewDenomAlias("foocoin", "foocoin")))
+	require.Equal(t, "foocoin", keeper.ResolveDenom(ctx, "foocoin"))
+
+	// the aliases are exported in alias order
+	require.Equal(t, []DenomAlias{NewDenomAlias("ancientcoin", "oldcoin"), NewDenomAlias("oldcoin", "foocoin")},
+		ExportGenesis(ctx, keeper).DenomAliases)
+}
+
+func TestValidateGenesisDenomAliases(t *testing.T) {
+	genesis := DefaultGenesis()
+	genesis.DenomAliases = []DenomAlias{NewDenomAlias("oldcoin", "foocoin"), NewDenomAlias("ancientcoin", "oldcoin")}
+	require.Nil(t, ValidateGenesis(genesis))
+
+	genesis.DenomAliases = []DenomAlias{NewDenomAlias("oldcoin", "foocoin"), NewDenomAlias("oldcoin", "barcoin")}
+	require.NotNil(t, ValidateGenesis(genesis))
+
+	genesis.DenomAliases = []DenomAlias{NewDenomAlias("oldcoin", "foocoin"), NewDenomAlias("foocoin", "oldcoin")}
+	require.NotNil(t, ValidateGenesis(genesis))
+}
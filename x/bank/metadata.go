@@ -0,0 +1,87 @@
+package bank
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MaxDecimals is the largest number of decimals a denom may declare
+const MaxDecimals = 18
+
+var reDenom = regexp.MustCompile(`^[a-z][a-z0-9]{2,15}$`)
+
+// DenomMetadata describes how clients should display a denom
+type DenomMetadata struct {
+	Denom       string `json:"denom"`
+	DisplayName string `json:"display_name"`
+	Decimals    uint32 `json:"decimals"`
+}
+
+// NewDenomMetadata - new denom metadata
+func NewDenomMetadata(denom, displayName string, decimals uint32) DenomMetadata {
+	return DenomMetadata{
+		Denom:       denom,
+		DisplayName: displayName,
+		Decimals:    decimals,
+	}
+}
+
+// Validate checks the denom, display name and decimals
+func (md DenomMetadata) Validate() error {
+	if !reDenom.MatchString(md.Denom) {
+		return fmt.Errorf("invalid denom: %q", md.Denom)
+	}
+	if strings.TrimSpace(md.DisplayName) == "" {
+		return fmt.Errorf("empty display name for denom %s", md.Denom)
+	}
+	if md.Decimals > MaxDecimals {
+		return fmt.Errorf("denom %s has %d decimals, at most %d are allowed", md.Denom, md.Decimals, MaxDecimals)
+	}
+	return nil
+}
+
+// String returns a human readable representation of the metadata
+func (md DenomMetadata) String() string {
+	return fmt.Sprintf("DenomMetadata{Denom: %s, DisplayName: %s, Decimals: %d}", md.Denom, md.DisplayName, md.Decimals)
+}
+
+var denomMetadataKeyPrefix = []byte("denom_metadata:")
+
+func getDenomMetadataKey(denom string) []byte {
+	return append(denomMetadataKeyPrefix, []byte(denom)...)
+}
+
+// GetDenomMetadata returns the metadata registered for the denom
+func (k Keeper) GetDenomMetadata(ctx sdk.Context, denom string) (md DenomMetadata, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(getDenomMetadataKey(denom))
+	if bz == nil {
+		return md, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &md)
+	return md, true
+}
+
+// SetDenomMetadata registers the metadata of its denom
+func (k Keeper) SetDenomMetadata(ctx sdk.Context, md DenomMetadata) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(md)
+	store.Set(getDenomMetadataKey(md.Denom), bz)
+}
+
+// IterateDenomMetadata iterates over the registered metadata in denom order
+func (k Keeper) IterateDenomMetadata(ctx sdk.Context, fn func(md DenomMetadata) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, denomMetadataKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var md DenomMetadata
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &md)
+		if fn(md) {
+			break
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// MaxMemoLength is the maximum length in bytes of a transfer memo
+const MaxMemoLength = 256
+
+// MsgSend - move coins from the sender to the recipient with an optional
+// memo
+type MsgSend struct {
+	From   sdk.AccAddress `json:"from"`
+	To     sdk.AccAddress `json:"to"`
+	Amount sdk.Coins      `json:"amount"`
+	Memo   string         `json:"memo,omitempty"`
+}
+
+// NewMsgSend - new send message
+func NewMsgSend(from, to sdk.AccAddress, amount sdk.Coins, memo string) MsgSend {
+	return MsgSend{From: from, To: to, Amount: amount, Memo: memo}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgSend{}
+
+// nolint
+func (msg MsgSend) Route() string                { return "bank" }
+func (msg MsgSend) Type() string                 { return "send" }
+func (msg MsgSend) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.From} }
+
+// ValidateBasic checks the addresses, the sent amount and the memo length
+func (msg MsgSend) ValidateBasic() sdk.Error {
+	if err := validateMemo(msg.Memo); err != nil {
+		return err
+	}
+	if len(msg.From) == 0 {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if len(msg.To) == 0 {
+		return sdk.ErrInvalidAddress(msg.To.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid amount to send: %s", msg.Amount))
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgSend) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// validateMemo checks the length of a transfer memo
+func validateMemo(memo string) sdk.Error {
+	if len(memo) > MaxMemoLength {
+		return sdk.ErrMemoTooLarge(fmt.Sprintf("memo length %d exceeds %d", len(memo), MaxMemoLength))
+	}
+	return nil
+}
+
+//_______________________________________________________________________
+
+// MsgMultiSend - move coins from a list of inputs to a list of outputs,
+// all or nothing, with an optional memo
+type MsgMultiSend struct {
+	Inputs  []sdkbank.Input  `json:"inputs"`
+	Outputs []sdkbank.Output `json:"outputs"`
+	Memo    string           `json:"memo,omitempty"`
+}
+
+// NewMsgMultiSend - new multi send message
+func NewMsgMultiSend(in []sdkbank.Input, out []sdkbank.Output) MsgMultiSend {
+	return MsgMultiSend{Inputs: in, Outputs: out}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgMultiSend{}
+
+// nolint
+func (msg MsgMultiSend) Route() string { return "bank" }
+func (msg MsgMultiSend) Type() string  { return "multisend" }
+
+// ValidateBasic checks the inputs and outputs, that their totals balance
+// and the memo length
+func (msg MsgMultiSend) ValidateBasic() sdk.Error {
+	if err := validateMemo(msg.Memo); err != nil {
+		return err
+	}
+	if len(msg.Inputs) == 0 {
+		return sdkbank.ErrNoInputs(sdkbank.DefaultCodespace).TraceSDK("")
+	}
+	if len(msg.Outputs) == 0 {
+		return sdkbank.ErrNoOutputs(sdkbank.DefaultCodespace).TraceSDK("")
+	}
+
+	var totalIn, totalOut sdk.Coins
+	for _, in := range msg.Inputs {
+		if err := in.ValidateBasic(); err != nil {
+			return err.TraceSDK("")
+		}
+		totalIn = totalIn.Plus(in.Coins)
+	}
+	for _, out := range msg.Outputs {
+		if err := out.ValidateBasic(); err != nil {
+			return err.TraceSDK("")
+		}
+		totalOut = totalOut.Plus(out.Coins)
+	}
+
+	if !totalIn.IsEqual(totalOut) {
+		return sdkbank.ErrInputOutputMismatch(sdkbank.DefaultCodespace,
+			fmt.Sprintf("inputs total %s does not match outputs total %s", totalIn, totalOut))
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgMultiSend) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners - every input signs
+func (msg MsgMultiSend) GetSigners() []sdk.AccAddress {
+	addrs := make([]sdk.AccAddress, len(msg.Inputs))
+	for i, in := range msg.Inputs {
+		addrs[i] = in.Address
+	}
+	return addrs
+}
+
+//_______________________________________________________________________
+
+// MsgBurn - destroy coins of the owner, reducing the total supply
+type MsgBurn struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Amount sdk.Coins      `json:"amount"`
+}
+
+// NewMsgBurn - new burn message
+func NewMsgBurn(owner sdk.AccAddress, amount sdk.Coins) MsgBurn {
+	return MsgBurn{Owner: owner, Amount: amount}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgBurn{}
+
+// nolint
+func (msg MsgBurn) Route() string                { return "bank" }
+func (msg MsgBurn) Type() string                 { return "burn" }
+func (msg MsgBurn) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Owner} }
+
+// ValidateBasic checks the owner and the burned amount
+func (msg MsgBurn) ValidateBasic() sdk.Error {
+	if len(msg.Owner) == 0 {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid amount to burn: %s", msg.Amount))
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgBurn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+//_______________________________________________________________________
+
+// MsgMintTo - create new coins for the recipient, increasing the total
+// supply, only the mint admin may send it
+type MsgMintTo struct {
+	Minter    sdk.AccAddress `json:"minter"`
+	Recipient sdk.AccAddress `json:"recipient"`
+	Amount    sdk.Coins      `json:"amount"`
+}
+
+// NewMsgMintTo - new mint message
+func NewMsgMintTo(minter, recipient sdk.AccAddress, amount sdk.Coins) MsgMintTo {
+	return MsgMintTo{Minter: minter, Recipient: recipient, Amount: amount}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgMintTo{}
+
+// nolint
+func (msg MsgMintTo) Route() string                { return "bank" }
+func (msg MsgMintTo) Type() string                 { return "mint_to" }
+func (msg MsgMintTo) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Minter} }
+
+// ValidateBasic checks the minter, the recipient and the minted amount
+func (msg MsgMintTo) ValidateBasic() sdk.Error {
+	if len(msg.Minter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Minter.String())
+	}
+	if len(msg.Recipient) == 0 {
+		return sdk.ErrInvalidAddress(msg.Recipient.String())
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid amount to mint: %s", msg.Amount))
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgMintTo) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+//_______________________________________________________________________
+
+// MsgSwap - exchange CoinsA of PartyA for CoinsB of PartyB, all or nothing,
+// signed by both parties
+type MsgSwap struct {
+	PartyA sdk.AccAddress `json:"party_a"`
+	CoinsA sdk.Coins      `json:"coins_a"`
+	PartyB sdk.AccAddress `json:"party_b"`
+	CoinsB sdk.Coins      `json:"coins_b"`
+}
+
+// NewMsgSwap - new swap message
+func NewMsgSwap(partyA sdk.AccAddress, coinsA sdk.Coins, partyB sdk.AccAddress, coinsB sdk.Coins) MsgSwap {
+	return MsgSwap{PartyA: partyA, CoinsA: coinsA, PartyB: partyB, CoinsB: coinsB}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgSwap{}
+
+// nolint
+func (msg MsgSwap) Route() string                { return "bank" }
+func (msg MsgSwap) Type() string                 { return "swap" }
+func (msg MsgSwap) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.PartyA, msg.PartyB} }
+
+// ValidateBasic checks the parties and the swapped amounts
+func (msg MsgSwap) ValidateBasic() sdk.Error {
+	if len(msg.PartyA) == 0 {
+		return sdk.ErrInvalidAddress(msg.PartyA.String())
+	}
+	if len(msg.PartyB) == 0 {
+		return sdk.ErrInvalidAddress(msg.PartyB.String())
+	}
+	if msg.PartyA.Equals(msg.PartyB) {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("cannot swap with self: %s", msg.PartyA))
+	}
+	if !msg.CoinsA.IsValid() || !msg.CoinsA.IsPositive() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid amount to swap: %s", msg.CoinsA))
+	}
+	if !msg.CoinsB.IsValid() || !msg.CoinsB.IsPositive() {
+		return sdk.ErrInvalidCoins(fmt.Sprintf("invalid amount to swap: %s", msg.CoinsB))
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgSwap) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
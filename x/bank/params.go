@@ -0,0 +1,92 @@
+package bank
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default bank module parameter subspace
+const DefaultParamspace = "bank"
+
+// Parameter store keys
+var (
+	KeyAccountCreationFee = []byte("AccountCreationFee")
+	KeyDenomWhitelist     = []byte("DenomWhitelist")
+	KeyFeeDenoms          = []byte("FeeDenoms")
+	KeyMintAdmin          = []byte("MintAdmin")
+	KeyTransferTaxRate    = []byte("TransferTaxRate")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the bank module
+type Params struct {
+	// fee paid by the sender the first time coins are sent to an address
+	// without an account, moved to the fee collector
+	AccountCreationFee sdk.Coins `json:"account_creation_fee"`
+
+	// denoms genesis accounts may hold besides the denoms with metadata
+	DenomWhitelist []string `json:"denom_whitelist"`
+
+	// denoms txs may pay fees in. Empty means any denom.
+	FeeDenoms []string `json:"fee_denoms"`
+
+	// account allowed to mint new coins with MsgMintTo, as a testnet faucet.
+	// Empty disables minting.
+	MintAdmin sdk.AccAddress `json:"mint_admin"`
+
+	// fraction of every transfer kept from the recipient and moved to the
+	// community pool, rounded down. Zero disables the tax.
+	TransferTaxRate sdk.Dec `json:"transfer_tax_rate"`
+}
+
+// ParamKeyTable for bank module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyAccountCreationFee, &p.AccountCreationFee},
+		{KeyDenomWhitelist, &p.DenomWhitelist},
+		{KeyFeeDenoms, &p.FeeDenoms},
+		{KeyMintAdmin, &p.MintAdmin},
+		{KeyTransferTaxRate, &p.TransferTaxRate},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		AccountCreationFee: sdk.Coins{},
+		DenomWhitelist:     []string{},
+		FeeDenoms:          []string{},
+		TransferTaxRate:    sdk.ZeroDec(),
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Account Creation Fee: %s
+  Denom Whitelist:      %s
+  Fee Denoms:           %s
+  Mint Admin:           %s
+  Transfer Tax Rate:    %s`, p.AccountCreationFee, strings.Join(p.DenomWhitelist, ", "),
+		strings.Join(p.FeeDenoms, ", "), p.MintAdmin, p.TransferTaxRate)
+}
+
+// GetParams returns the current bank parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the bank parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,71 @@
+package bank
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the bank Querier
+const (
+	QuerierRoute       = "bank"
+	QueryDenomMetadata = "denom_metadata"
+	QuerySupply        = "supply"
+	QuerySupplyAll     = "supply_all"
+)
+
+// NewQuerier returns a querier for the bank module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no bank query endpoint given")
+		}
+		switch path[0] {
+		case QueryDenomMetadata:
+			return queryDenomMetadata(ctx, path[1:], k)
+		case QuerySupply:
+			return querySupply(ctx, path[1:], k)
+		case QuerySupplyAll:
+			return marshalResult(k.GetSupply(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown bank query endpoint")
+		}
+	}
+}
+
+// queryDenomMetadata returns the metadata of the denom given as the query
+// path
+func queryDenomMetadata(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected a denom")
+	}
+
+	md, found := k.GetDenomMetadata(ctx, path[0])
+	if !found {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("no metadata registered for denom %s", path[0]))
+	}
+
+	return marshalResult(md)
+}
+
+// querySupply returns the total supply of the denom given as the query
+// path, zero for unknown denoms
+func querySupply(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected a denom")
+	}
+
+	denom := path[0]
+	return marshalResult(sdk.NewCoin(denom, k.GetSupply(ctx).AmountOf(denom)))
+}
+
+func marshalResult(res interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, res)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,85 @@
+package bank
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQueryDenomMetadata(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	steak := NewDenomMetadata("steak", "Steak", 6)
+
+	_, err := querier(ctx, []string{QueryDenomMetadata, "steak"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	keeper.SetDenomMetadata(ctx, steak)
+	bz, err := querier(ctx, []string{QueryDenomMetadata, "steak"}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var md DenomMetadata
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &md))
+	require.Equal(t, steak, md)
+
+	_, err = querier(ctx, []string{QueryDenomMetadata}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestDenomMetadataGenesis(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	steak := NewDenomMetadata("steak", "Steak", 6)
+	foo := NewDenomMetadata("foocoin", "Foo Coin", 0)
+
+	require.Nil(t, InitGenesis(ctx, keeper, Genesis{DenomMetadata: []DenomMetadata{steak, foo}, Params: DefaultParams()}))
+	md, found := keeper.GetDenomMetadata(ctx, "foocoin")
+	require.True(t, found)
+	require.Equal(t, foo, md)
+	require.Equal(t, []DenomMetadata{foo, steak}, ExportGenesis(ctx, keeper).DenomMetadata)
+
+	// duplicate denoms and invalid metadata are rejected
+	require.NotNil(t, ValidateGenesis(Genesis{DenomMetadata: []DenomMetadata{steak, NewDenomMetadata("steak", "Other", 2)}}))
+	require.NotNil(t, ValidateGenesis(Genesis{DenomMetadata: []DenomMetadata{NewDenomMetadata("Steak", "Steak", 6)}}))
+	require.NotNil(t, ValidateGenesis(Genesis{DenomMetadata: []DenomMetadata{NewDenomMetadata("steak", " ", 6)}}))
+	require.NotNil(t, ValidateGenesis(Genesis{DenomMetadata: []DenomMetadata{NewDenomMetadata("steak", "Steak", MaxDecimals+1)}}))
+}
+
+func TestQuerySupply(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	addr := sdk.AccAddress([]byte("addr"))
+
+	querySupply := func(denom string) sdk.Coin {
+		bz, err := querier(ctx, []string{QuerySupply, denom}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var supply sdk.Coin
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &supply))
+		return supply
+	}
+	querySupplyAll := func() sdk.Coins {
+		bz, err := querier(ctx, []string{QuerySupplyAll}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var supply sdk.Coins
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &supply))
+		return supply
+	}
+
+	require.Equal(t, sdk.NewInt64Coin("foocoin", 0), querySupply("foocoin"))
+	require.True(t, querySupplyAll().IsZero())
+
+	_, _, err := keeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("barcoin", 5), sdk.NewInt64Coin("foocoin", 10)})
+	require.Nil(t, err)
+	_, _, err = keeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 3)})
+	require.Nil(t, err)
+
+	require.Equal(t, sdk.NewInt64Coin("foocoin", 7), querySupply("foocoin"))
+	require.Equal(t, sdk.NewInt64Coin("barcoin", 5), querySupply("barcoin"))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("barcoin", 5), sdk.NewInt64Coin("foocoin", 7)}, querySupplyAll())
+
+	_, sdkErr := querier(ctx, []string{QuerySupply}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
@@ -0,0 +1,31 @@
+package bank
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbank "github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// Tag keys and values emitted for bank transfers
+var (
+	TagAction    = sdk.TagAction
+	TagSender    = "sender"
+	TagRecipient = "recipient"
+	TagAmount    = "amount"
+	TagMemo      = "memo"
+
+	ActionTransfer = []byte("transfer")
+)
+
+// transferTags returns the tags describing a transfer from the inputs to the
+// outputs
+func transferTags(inputs []sdkbank.Input, outputs []sdkbank.Output) sdk.Tags {
+	tags := sdk.NewTags(TagAction, ActionTransfer)
+	for _, in := range inputs {
+		tags = tags.AppendTag(TagSender, []byte(in.Address.String()))
+	}
+	for _, out := range outputs {
+		tags = tags.AppendTag(TagRecipient, []byte(out.Address.String()))
+		tags = tags.AppendTag(TagAmount, []byte(out.Coins.String()))
+	}
+	return tags
+}
@@ -0,0 +1,29 @@
+package blockgas
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BlockGas errors reserve 900 ~ 999.
+const (
+	DefaultCodespace sdk.CodespaceType = "blockgas"
+
+	CodeBlockGasExceeded  sdk.CodeType = 900
+	CodeTooManySignatures sdk.CodeType = 901
+)
+
+// ErrBlockGasExceeded - Error returned when a tx asks for more gas than is
+// left in the block
+func ErrBlockGasExceeded(codespace sdk.CodespaceType, gas, remaining uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeBlockGasExceeded,
+		fmt.Sprintf("tx gas %d exceeds the %d gas remaining in the block", gas, remaining))
+}
+
+// ErrTooManySignatures - Error returned when a tx carries more signatures
+// than the MaxSignatures param allows
+func ErrTooManySignatures(codespace sdk.CodespaceType, count int, max uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeTooManySignatures,
+		fmt.Sprintf("tx carries %d signatures, more than the %d allowed", count, max))
+}
@@ -0,0 +1,30 @@
+package blockgas
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state of the blockgas module
+type Genesis struct {
+	Params Params `json:"params"`
+}
+
+// DefaultGenesis returns the default genesis state for the blockgas module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis for the blockgas module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	k.SetParams(ctx, genesis.Params)
+	return nil
+}
+
+// ExportGenesis for the blockgas module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	return Genesis{
+		Params: k.GetParams(ctx),
+	}
+}
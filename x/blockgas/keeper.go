@@ -0,0 +1,159 @@
+package blockgas
+
+import (
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper of the block gas limit. The limit and the gas asked for by the txs
+// of the current block are kept in a transient store, cleared every block.
+type Keeper struct {
+	tkey       sdk.StoreKey
+	paramSpace params.Subspace
+	codespace  sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(tkey sdk.StoreKey, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		tkey:       tkey,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+}
+
+var (
+	blockLimitKey = []byte("blockLimit")
+	blockUsedKey  = []byte("blockUsed")
+)
+
+func (k Keeper) getUint64(ctx sdk.Context, key []byte) uint64 {
+	store := ctx.TransientStore(k.tkey)
+	stored := store.Get(key)
+	if stored == nil {
+		return 0
+	}
+	v, err := strconv.ParseUint(string(stored), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (k Keeper) setUint64(ctx sdk.Context, key []byte, v uint64) {
+	store := ctx.TransientStore(k.tkey)
+	store.Set(key, []byte(strconv.FormatUint(v, 10)))
+}
+
+// GetBlockLimit returns the gas limit of the current block, read from the
+// params at the beginning of the block. Zero means no limit.
+func (k Keeper) GetBlockLimit(ctx sdk.Context) uint64 {
+	return k.getUint64(ctx, blockLimitKey)
+}
+
+// GetBlockGasUsed returns the gas asked for by the txs of the current block
+func (k Keeper) GetBlockGasUsed(ctx sdk.Context) uint64 {
+	return k.getUint64(ctx, blockUsedKey)
+}
+
+// BeginBlocker sets the gas limit of the block from the MaxBlockGas param,
+// so param changes apply from the next block on
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	k.setUint64(ctx, blockLimitKey, k.GetParams(ctx).MaxBlockGas)
+	k.setUint64(ctx, blockUsedKey, 0)
+}
+
+// NewAnteHandler wraps an AnteHandler and rejects txs carrying more than
+// MaxSignatures signatures or asking for more gas than is left in the
+// block. The gas limit of a tx, not the gas it ends up using, counts against
+// the block once the wrapped handler accepted it. CheckTx runs outside of
+// blocks and only rejects txs asking for more than MaxBlockGas.
+func (k Keeper) NewAnteHandler(ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(auth.StdTx)
+		if !ok {
+			return ah(ctx, tx, simulate)
+		}
+		if max := k.GetParams(ctx).MaxSignatures; max != 0 {
+			if count := countSignatures(stdTx.Signatures); uint64(count) > max {
+				return ctx, ErrTooManySignatures(k.codespace, count, max).Result(), true
+			}
+		}
+		gas := stdTx.Fee.Gas
+
+		if ctx.IsCheckTx() {
+			limit := k.GetParams(ctx).MaxBlockGas
+			if limit != 0 && gas > limit {
+				return ctx, ErrBlockGasExceeded(k.codespace, gas, limit).Result(), true
+			}
+			return ah(ctx, tx, simulate)
+		}
+
+		limit := k.GetBlockLimit(ctx)
+		if limit == 0 {
+			return ah(ctx, tx, simulate)
+		}
+		used := k.GetBlockGasUsed(ctx)
+		if gas > limit-used {
+			return ctx, ErrBlockGasExceeded(k.codespace, gas, limit-used).Result(), true
+		}
+
+		newCtx, res, abort := ah(ctx, tx, simulate)
+		if !abort {
+			k.setUint64(ctx, blockUsedKey, used+gas)
+		}
+		return newCtx, res, abort
+	}
+}
+
+// countSignatures returns the number of signatures, counting every key of
+// a multisig as the verification cost grows with them
+func countSignatures(sigs []auth.StdSignature) int {
+	count := 0
+	for _, sig := range sigs {
+		count += countKeys(sig.PubKey)
+	}
+	return count
+}
+
+func countKeys(pubKey crypto.PubKey) int {
+	multi, ok := pubKey.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return 1
+	}
+	count := 0
+	for _, key := range multi.PubKeys {
+		count += countKeys(key)
+	}
+	return count
+}
+
+// GetMsgGasCost returns the base gas charged for the messages of the route,
+// DefaultMsgGas if the route has no cost of its own
+func (k Keeper) GetMsgGasCost(ctx sdk.Context, route string) uint64 {
+	params := k.GetParams(ctx)
+	for _, cost := range params.MsgGasCosts {
+		if cost.Route == route {
+			return cost.Gas
+		}
+	}
+	return params.DefaultMsgGas
+}
+
+// NewGasCostHandler wraps a Handler and charges the base gas of the message
+// route before handling the message, so operators can price spam prone
+// messages higher
+func (k Keeper) NewGasCostHandler(h sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if gas := k.GetMsgGasCost(ctx, msg.Route()); gas > 0 {
+			ctx.GasMeter().ConsumeGas(gas, "msg base cost")
+		}
+		return h(ctx, msg)
+	}
+}
@@ -0,0 +1,151 @@
+package blockgas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T, maxBlockGas uint64) (sdk.Context, Keeper) {
+	tkeyBlockGas := sdk.NewTransientStoreKey("transient_blockgas")
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(tkeyBlockGas, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	keeper := NewKeeper(tkeyBlockGas, pk.Subspace(DefaultParamspace), DefaultCodespace)
+
+	err := InitGenesis(ctx, keeper, Genesis{Params{MaxBlockGas: maxBlockGas}})
+	require.Nil(t, err)
+
+	return ctx, keeper
+}
+
+func passAnteHandler(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+	return ctx, sdk.Result{}, false
+}
+
+func newGasTx(gas uint64) auth.StdTx {
+	return auth.NewStdTx(nil, auth.NewStdFee(gas, nil), nil, "")
+}
+
+func TestBlockGasLimit(t *testing.T) {
+	ctx, keeper := createTestInput(t, 100000)
+	ah := keeper.NewAnteHandler(passAnteHandler)
+	BeginBlocker(ctx, keeper)
+
+	// txs fill the block up to the limit
+	for i := 0; i < 2; i++ {
+		_, res, abort := ah(ctx, newGasTx(50000), false)
+		require.False(t, abort, res.Log)
+	}
+	require.Equal(t, uint64(100000), keeper.GetBlockGasUsed(ctx))
+
+	// the next tx is rejected
+	_, res, abort := ah(ctx, newGasTx(1), false)
+	require.True(t, abort)
+	require.Equal(t, CodeBlockGasExceeded, res.Code)
+
+	// param changes apply from the next block on, which starts empty
+	keeper.SetParams(ctx, Params{MaxBlockGas: 150000})
+	_, _, abort = ah(ctx, newGasTx(1), false)
+	require.True(t, abort)
+	BeginBlocker(ctx, keeper)
+	_, res, abort = ah(ctx, newGasTx(150000), false)
+	require.False(t, abort, res.Log)
+	_, _, abort = ah(ctx, newGasTx(1), false)
+	require.True(t, abort)
+}
+
+func TestBlockGasLimitCheckTx(t *testing.T) {
+	ctx, keeper := createTestInput(t, 100000)
+	ah := keeper.NewAnteHandler(passAnteHandler)
+	ctx = ctx.WithIsCheckTx(true)
+
+	// CheckTx only rejects txs that can't fit in any block
+	for i := 0; i < 3; i++ {
+		_, res, abort := ah(ctx, newGasTx(100000), false)
+		require.False(t, abort, res.Log)
+	}
+	_, res, abort := ah(ctx, newGasTx(100001), false)
+	require.True(t, abort)
+	require.Equal(t, CodeBlockGasExceeded, res.Code)
+}
+
+func TestNoBlockGasLimit(t *testing.T) {
+	ctx, keeper := createTestInput(t, 0)
+	ah := keeper.NewAnteHandler(passAnteHandler)
+	BeginBlocker(ctx, keeper)
+
+	_, res, abort := ah(ctx, newGasTx(1<<62), false)
+	require.False(t, abort, res.Log)
+}
+
+func newSignedTx(pubKeys ...crypto.PubKey) auth.StdTx {
+	sigs := make([]auth.StdSignature, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		sigs[i] = auth.StdSignature{PubKey: pubKey}
+	}
+	return auth.NewStdTx(nil, auth.NewStdFee(1, nil), sigs, "")
+}
+
+func TestMaxSignatures(t *testing.T) {
+	ctx, keeper := createTestInput(t, 0)
+	ah := keeper.NewAnteHandler(passAnteHandler)
+	params := DefaultParams()
+	params.MaxSignatures = 3
+	keeper.SetParams(ctx, params)
+
+	keys := make([]crypto.PubKey, 4)
+	for i := range keys {
+		keys[i] = ed25519.GenPrivKey().PubKey()
+	}
+
+	// txs up to the cap pass
+	_, res, abort := ah(ctx, newSignedTx(keys[:3]...), false)
+	require.False(t, abort, res.Log)
+
+	// above it they are rejected, in CheckTx too
+	for _, ctx := range []sdk.Context{ctx, ctx.WithIsCheckTx(true)} {
+		_, res, abort = ah(ctx, newSignedTx(keys...), false)
+		require.True(t, abort)
+		require.Equal(t, DefaultCodespace, res.Codespace)
+		require.Equal(t, CodeTooManySignatures, res.Code)
+	}
+
+	// every key of a multisig counts
+	multi := multisig.NewPubKeyMultisigThreshold(2, keys[:3])
+	_, res, abort = ah(ctx, newSignedTx(multi), false)
+	require.False(t, abort, res.Log)
+	_, res, abort = ah(ctx, newSignedTx(multi, keys[3]), false)
+	require.True(t, abort)
+	require.Equal(t, CodeTooManySignatures, res.Code)
+
+	// zero lifts the cap
+	params.MaxSignatures = 0
+	keeper.SetParams(ctx, params)
+	_, res, abort = ah(ctx, newSignedTx(multi, keys[3]), false)
+	require.False(t, abort, res.Log)
+}
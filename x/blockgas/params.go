@@ -0,0 +1,88 @@
+package blockgas
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default blockgas module parameter subspace
+const DefaultParamspace = "blockgas"
+
+// Parameter store keys
+var (
+	KeyMaxBlockGas   = []byte("MaxBlockGas")
+	KeyMsgGasCosts   = []byte("MsgGasCosts")
+	KeyDefaultMsgGas = []byte("DefaultMsgGas")
+	KeyMaxSignatures = []byte("MaxSignatures")
+)
+
+// MsgGasCost is the base gas charged for the messages of a route
+type MsgGasCost struct {
+	Route string `json:"route"`
+	Gas   uint64 `json:"gas"`
+}
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the blockgas module
+type Params struct {
+	// total gas the txs of a block may ask for. Zero means no limit.
+	MaxBlockGas uint64 `json:"max_block_gas"`
+
+	// base gas charged before handling a message, per message route
+	MsgGasCosts []MsgGasCost `json:"msg_gas_costs"`
+
+	// base gas charged for the messages of the routes without a cost
+	DefaultMsgGas uint64 `json:"default_msg_gas"`
+
+	// signatures a tx may carry, counting every key of a multisig. Zero
+	// means no limit.
+	MaxSignatures uint64 `json:"max_signatures"`
+}
+
+// ParamKeyTable for blockgas module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyMaxBlockGas, &p.MaxBlockGas},
+		{KeyMsgGasCosts, &p.MsgGasCosts},
+		{KeyDefaultMsgGas, &p.DefaultMsgGas},
+		{KeyMaxSignatures, &p.MaxSignatures},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		MaxBlockGas:   0,
+		MsgGasCosts:   []MsgGasCost{},
+		DefaultMsgGas: 0,
+		MaxSignatures: 7,
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Max Block Gas:   %d
+  Msg Gas Costs:   %v
+  Default Msg Gas: %d
+  Max Signatures:  %d`, p.MaxBlockGas, p.MsgGasCosts, p.DefaultMsgGas, p.MaxSignatures)
+}
+
+// GetParams returns the current blockgas parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the blockgas parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,11 @@
+package cool
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgQuiz{}, "cool/Quiz", nil)
+	cdc.RegisterConcrete(MsgSetTrend{}, "cool/SetTrend", nil)
+}
@@ -0,0 +1,43 @@
+package cool
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Cool errors reserve 400 ~ 499. Clients may switch on the codes returned
+// in the "cool" codespace; malformed msgs and unknown requests keep the codes
+// of the sdk root codespace.
+const (
+	DefaultCodespace sdk.CodespaceType = "cool"
+
+	// Cool module reserves error 400-499 lawl
+	CodeIncorrectCoolAnswer sdk.CodeType = 400 // quiz answer isn't the trend
+	CodeNotTrendSetter      sdk.CodeType = 401 // too few trend setter coins
+	CodeInvalidTrendLength  sdk.CodeType = 402 // trend length out of bounds
+	CodeUncoolTrend         sdk.CodeType = 403 // trend is hot or warm
+)
+
+// ErrIncorrectCoolAnswer - Error returned upon an incorrect guess
+func ErrIncorrectCoolAnswer(codespace sdk.CodespaceType, answer string) sdk.Error {
+	return sdk.NewError(codespace, CodeIncorrectCoolAnswer, fmt.Sprintf("Incorrect cool answer: %v", answer))
+}
+
+// ErrNotTrendSetter - Error returned when the sender holds too few coins to
+// set the trend
+func ErrNotTrendSetter(codespace sdk.CodespaceType, min sdk.Int, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeNotTrendSetter, fmt.Sprintf("Setting the trend requires holding %s%s", min, denom))
+}
+
+// ErrInvalidTrendLength - Error returned when the trend is shorter or longer
+// than the bounds set in the params
+func ErrInvalidTrendLength(codespace sdk.CodespaceType, length int, min, max uint64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidTrendLength,
+		fmt.Sprintf("Trend length %d is out of the bounds [%d, %d]", length, min, max))
+}
+
+// ErrUncoolTrend - Error returned when the trend isn't cool enough to be set
+func ErrUncoolTrend(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeUncoolTrend, msg)
+}
@@ -0,0 +1,69 @@
+package cool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestSetTrendRequiresTrendSetterCoins(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	require.Nil(t, InitGenesis(ctx, keeper, DefaultGenesis()))
+
+	coolParams := keeper.GetParams(ctx)
+	coolParams.MinTrendSetterCoins = sdk.NewInt(10)
+	keeper.SetParams(ctx, coolParams)
+
+	holder := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("cool", 10)})
+	poor := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("cool", 9), sdk.NewInt64Coin("foocoin", 100)})
+	stranger := sdk.AccAddress([]byte("stranger"))
+
+	// holders of enough coins set the trend
+	require.True(t, handler(ctx, NewMsgSetTrend(holder, "frosty")).IsOK())
+	require.Equal(t, "frosty", keeper.GetTrend(ctx))
+
+	// others are rejected
+	res := handler(ctx, NewMsgSetTrend(poor, "chilly"))
+	require.Equal(t, CodeNotTrendSetter, res.Code)
+	res = handler(ctx, NewMsgSetTrend(stranger, "chilly"))
+	require.Equal(t, CodeNotTrendSetter, res.Code)
+	require.Equal(t, "frosty", keeper.GetTrend(ctx))
+
+	// the denom is configurable
+	coolParams.TrendSetterDenom = "foocoin"
+	keeper.SetParams(ctx, coolParams)
+	require.True(t, handler(ctx, NewMsgSetTrend(poor, "chilly")).IsOK())
+	require.Equal(t, "chilly", keeper.GetTrend(ctx))
+	require.Equal(t, CodeNotTrendSetter, handler(ctx, NewMsgSetTrend(holder, "icy")).Code)
+}
+
+func TestSetTrendLengthBounds(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	require.Nil(t, InitGenesis(ctx, keeper, DefaultGenesis()))
+	keeper.SetTrendLength(ctx, 4, 6)
+
+	holder := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("cool", 10)})
+
+	// trends at the bounds are accepted
+	require.True(t, handler(ctx, NewMsgSetTrend(holder, "icy!")).IsOK())
+	require.True(t, handler(ctx, NewMsgSetTrend(holder, "frosty")).IsOK())
+	require.Equal(t, "frosty", keeper.GetTrend(ctx))
+
+	// trends out of them are rejected
+	require.Equal(t, CodeInvalidTrendLength, handler(ctx, NewMsgSetTrend(holder, "icy")).Code)
+	require.Equal(t, CodeInvalidTrendLength, handler(ctx, NewMsgSetTrend(holder, "glacial")).Code)
+	require.Equal(t, "frosty", keeper.GetTrend(ctx))
+}
+
+func TestSetTrendUncool(t *testing.T) {
+	for _, trend := range []string{"hot", "lukewarm"} {
+		err := NewMsgSetTrend(sdk.AccAddress([]byte("sender")), trend).ValidateBasic()
+		require.NotNil(t, err)
+		require.Equal(t, DefaultCodespace, err.Codespace())
+		require.Equal(t, CodeUncoolTrend, err.Code())
+	}
+}
@@ -0,0 +1,132 @@
+package cool
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper - handlers sets/gets of custom variables for your module
+type Keeper struct {
+	ck bank.Keeper
+
+	storeKey sdk.StoreKey // The (unexposed) key used to access the store from the Context.
+
+	paramSpace params.Subspace
+
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper - Returns the Keeper
+func NewKeeper(key sdk.StoreKey, bankKeeper bank.Keeper, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+	return Keeper{bankKeeper, key, paramSpace.WithKeyTable(ParamKeyTable()), codespace}
+}
+
+// Key to knowing the trend on the streets!
+var (
+	trendKey        = []byte("TrendKey")
+	trendHistoryKey = []byte("TrendHistoryKey")
+)
+
+// GetTrend - returns the current cool trend
+func (k Keeper) GetTrend(ctx sdk.Context) string {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(trendKey)
+	return string(bz)
+}
+
+// Implements sdk.AccountMapper.
+func (k Keeper) setTrend(ctx sdk.Context, newTrend string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(trendKey, []byte(newTrend))
+	k.recordTrend(ctx, TrendEntry{newTrend, ctx.BlockHeight()})
+}
+
+// TrendEntry is a trend of the history with the height it was set at
+type TrendEntry struct {
+	Trend  string `json:"trend"`
+	Height int64  `json:"height"`
+}
+
+// trendHistory is a ring buffer of the last trends, Head being the slot of
+// the oldest entry once the buffer is full
+type trendHistory struct {
+	Entries []TrendEntry
+	Head    uint64
+}
+
+// ordered returns the entries from oldest to newest
+func (h trendHistory) ordered() []TrendEntry {
+	entries := make([]TrendEntry, 0, len(h.Entries))
+	entries = append(entries, h.Entries[h.Head:]...)
+	return append(entries, h.Entries[:h.Head]...)
+}
+
+func (k Keeper) getTrendHistory(ctx sdk.Context) (history trendHistory) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(trendHistoryKey)
+	if bz == nil {
+		return history
+	}
+	codec.Cdc.MustUnmarshalBinaryLengthPrefixed(bz, &history)
+	return history
+}
+
+func (k Keeper) setTrendHistory(ctx sdk.Context, history trendHistory) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(trendHistoryKey, codec.Cdc.MustMarshalBinaryLengthPrefixed(history))
+}
+
+// recordTrend adds the entry to the trend history, overwriting the oldest
+// entry once the history holds TrendHistorySize entries
+func (k Keeper) recordTrend(ctx sdk.Context, entry TrendEntry) {
+	size := k.GetParams(ctx).TrendHistorySize
+	history := k.getTrendHistory(ctx)
+
+	// unwrap the buffer if the size param changed since it was filled
+	if uint64(len(history.Entries)) > size || (history.Head != 0 && uint64(len(history.Entries)) < size) {
+		entries := history.ordered()
+		if uint64(len(entries)) > size {
+			entries = entries[uint64(len(entries))-size:]
+		}
+		history = trendHistory{Entries: entries}
+	}
+	if size == 0 {
+		k.setTrendHistory(ctx, history)
+		return
+	}
+
+	if uint64(len(history.Entries)) < size {
+		history.Entries = append(history.Entries, entry)
+	} else {
+		history.Entries[history.Head] = entry
+		history.Head = (history.Head + 1) % size
+	}
+	k.setTrendHistory(ctx, history)
+}
+
+// GetTrendHistory returns the last trends from oldest to newest
+func (k Keeper) GetTrendHistory(ctx sdk.Context) []TrendEntry {
+	return k.getTrendHistory(ctx).ordered()
+}
+
+// CheckTrend - Returns true or false based on whether guessedTrend is currently cool or not
+func (k Keeper) CheckTrend(ctx sdk.Context, guessedTrend string) bool {
+	return guessedTrend == k.GetTrend(ctx)
+}
+
+// InitGenesis - store the genesis trend and params
+func InitGenesis(ctx sdk.Context, k Keeper, data Genesis) error {
+	k.setTrend(ctx, data.Trend)
+	k.SetParams(ctx, data.Params)
+	return nil
+}
+
+// ExportGenesis - output the genesis trend and params
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	return Genesis{
+		Trend:  k.GetTrend(ctx),
+		Params: k.GetParams(ctx),
+	}
+}
@@ -0,0 +1,93 @@
+package cool
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default cool module parameter subspace
+const DefaultParamspace = "cool"
+
+// Parameter store keys
+var (
+	KeyTrendSetterDenom    = []byte("TrendSetterDenom")
+	KeyMinTrendSetterCoins = []byte("MinTrendSetterCoins")
+	KeyTrendHistorySize    = []byte("TrendHistorySize")
+	KeyMinTrendLength      = []byte("MinTrendLength")
+	KeyMaxTrendLength      = []byte("MaxTrendLength")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the cool module
+type Params struct {
+	// denom a sender must hold to set the trend
+	TrendSetterDenom string `json:"trend_setter_denom"`
+
+	// minimum amount of TrendSetterDenom a sender must hold to set the trend
+	MinTrendSetterCoins sdk.Int `json:"min_trend_setter_coins"`
+
+	// number of past trends kept in the trend history
+	TrendHistorySize uint64 `json:"trend_history_size"`
+
+	// bounds of the length of a trend, in bytes
+	MinTrendLength uint64 `json:"min_trend_length"`
+	MaxTrendLength uint64 `json:"max_trend_length"`
+}
+
+// ParamKeyTable for cool module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyTrendSetterDenom, &p.TrendSetterDenom},
+		{KeyMinTrendSetterCoins, &p.MinTrendSetterCoins},
+		{KeyTrendHistorySize, &p.TrendHistorySize},
+		{KeyMinTrendLength, &p.MinTrendLength},
+		{KeyMaxTrendLength, &p.MaxTrendLength},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		TrendSetterDenom:    "cool",
+		MinTrendSetterCoins: sdk.NewInt(1),
+		TrendHistorySize:    10,
+		MinTrendLength:      1,
+		MaxTrendLength:      64,
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Trend Setter Denom:     %s
+  Min Trend Setter Coins: %s
+  Trend History Size:     %d
+  Min Trend Length:       %d
+  Max Trend Length:       %d`, p.TrendSetterDenom, p.MinTrendSetterCoins, p.TrendHistorySize,
+		p.MinTrendLength, p.MaxTrendLength)
+}
+
+// GetParams returns the current cool parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the cool parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// SetTrendLength sets the bounds of the length of a trend
+func (k Keeper) SetTrendLength(ctx sdk.Context, min, max uint64) {
+	k.paramSpace.Set(ctx, KeyMinTrendLength, &min)
+	k.paramSpace.Set(ctx, KeyMaxTrendLength, &max)
+}
@@ -0,0 +1,50 @@
+package cool
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the cool Querier
+const (
+	QuerierRoute      = "cool"
+	QueryTrend        = "trend"
+	QueryTrendHistory = "trend_history"
+)
+
+// NewQuerier returns a querier for the cool module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no cool query endpoint given")
+		}
+		switch path[0] {
+		case QueryTrend:
+			return queryTrend(ctx, k)
+		case QueryTrendHistory:
+			return queryTrendHistory(ctx, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown cool query endpoint")
+		}
+	}
+}
+
+// queryTrend returns the current trend, which is empty if none has been set
+func queryTrend(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, k.GetTrend(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// queryTrendHistory returns the last trends from oldest to newest
+func queryTrendHistory(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, k.GetTrendHistory(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
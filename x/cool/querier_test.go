@@ -0,0 +1,117 @@
+package cool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyCool := sdk.NewKVStoreKey("cool")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyCool, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(ak)
+	keeper := NewKeeper(keyCool, ck, pk.Subspace(DefaultParamspace), DefaultCodespace)
+
+	keeper.SetParams(ctx, DefaultParams())
+
+	return ctx, ak, keeper
+}
+
+func fundedAddr(ctx sdk.Context, ak auth.AccountKeeper, coins sdk.Coins) sdk.AccAddress {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	acc := ak.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(coins)
+	ak.SetAccount(ctx, acc)
+	return addr
+}
+
+func queryTrendString(t *testing.T, ctx sdk.Context, querier sdk.Querier) string {
+	bz, err := querier(ctx, []string{QueryTrend}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var trend string
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &trend))
+	return trend
+}
+
+func TestQuerierTrend(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+
+	// no trend set yet
+	require.Equal(t, "", queryTrendString(t, ctx, querier))
+
+	err := InitGenesis(ctx, keeper, DefaultGenesis())
+	require.Nil(t, err)
+	require.Equal(t, "ice-cold", queryTrendString(t, ctx, querier))
+
+	handler := NewHandler(keeper)
+	sender := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("cool", 1)})
+	res := handler(ctx, NewMsgSetTrend(sender, "frosty"))
+	require.True(t, res.IsOK())
+	require.Equal(t, "frosty", queryTrendString(t, ctx, querier))
+
+	_, sdkErr := querier(ctx, []string{"foo"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
+
+func TestQuerierTrendHistory(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	handler := NewHandler(keeper)
+
+	size := 3
+	coolParams := keeper.GetParams(ctx)
+	coolParams.TrendHistorySize = uint64(size)
+	keeper.SetParams(ctx, coolParams)
+
+	sender := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("cool", 1)})
+	trends := []string{"frosty", "chilly", "icy", "polar", "glacial"}
+	require.Len(t, trends, size+2)
+	for i, trend := range trends {
+		ctx = ctx.WithBlockHeight(int64(i + 1))
+		require.True(t, handler(ctx, NewMsgSetTrend(sender, trend)).IsOK())
+	}
+
+	// only the last trends remain, oldest first
+	bz, err := querier(ctx, []string{QueryTrendHistory}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var history []TrendEntry
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &history))
+	require.Equal(t, []TrendEntry{{"icy", 3}, {"polar", 4}, {"glacial", 5}}, history)
+
+	// shrinking the history drops the oldest entries
+	coolParams.TrendHistorySize = 2
+	keeper.SetParams(ctx, coolParams)
+	ctx = ctx.WithBlockHeight(6)
+	require.True(t, handler(ctx, NewMsgSetTrend(sender, "arctic")).IsOK())
+	require.Equal(t, []TrendEntry{{"glacial", 5}, {"arctic", 6}}, keeper.GetTrendHistory(ctx))
+}
@@ -0,0 +1,11 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker distributes the fees collected in the previous block and
+// returns the coins burned in doing so
+func BeginBlocker(ctx sdk.Context, k Keeper) sdk.Coins {
+	return k.DistributeFees(ctx)
+}
@@ -0,0 +1,34 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state of the distribution module
+type Genesis struct {
+	Params        Params    `json:"params"`
+	CommunityPool sdk.Coins `json:"community_pool"`
+}
+
+// DefaultGenesis returns the default genesis state for the distribution module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		Params:        DefaultParams(),
+		CommunityPool: sdk.Coins{},
+	}
+}
+
+// InitGenesis for the distribution module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	k.SetParams(ctx, genesis.Params)
+	k.SetCommunityPool(ctx, genesis.CommunityPool)
+	return nil
+}
+
+// ExportGenesis for the distribution module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	return Genesis{
+		Params:        k.GetParams(ctx),
+		CommunityPool: k.GetCommunityPool(ctx),
+	}
+}
@@ -0,0 +1,81 @@
+package distribution
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper moves the collected fees to the community pool or burns them
+type Keeper struct {
+	fck auth.FeeCollectionKeeper
+
+	key        sdk.StoreKey
+	cdc        *codec.Codec
+	paramSpace params.Subspace
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, fck auth.FeeCollectionKeeper, paramSpace params.Subspace) Keeper {
+	return Keeper{
+		fck:        fck,
+		key:        key,
+		cdc:        cdc,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+	}
+}
+
+var communityPoolKey = []byte("community_pool")
+
+// GetCommunityPool returns the coins held by the community pool
+func (k Keeper) GetCommunityPool(ctx sdk.Context) (pool sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(communityPoolKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pool)
+	return pool
+}
+
+// SetCommunityPool sets the coins held by the community pool
+func (k Keeper) SetCommunityPool(ctx sdk.Context, pool sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(pool)
+	store.Set(communityPoolKey, bz)
+}
+
+// AddToCommunityPool credits the coins to the community pool
+func (k Keeper) AddToCommunityPool(ctx sdk.Context, coins sdk.Coins) {
+	k.SetCommunityPool(ctx, k.GetCommunityPool(ctx).Plus(coins))
+}
+
+// SubtractFromCommunityPool removes the coins from the community pool,
+// failing if the pool holds less
+func (k Keeper) SubtractFromCommunityPool(ctx sdk.Context, coins sdk.Coins) sdk.Error {
+	pool := k.GetCommunityPool(ctx)
+	if !pool.IsAllGTE(coins) {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("community pool holds %s, less than %s", pool, coins))
+	}
+	k.SetCommunityPool(ctx, pool.Minus(coins))
+	return nil
+}
+
+// DistributeFees clears the collected fees and moves them to the community
+// pool, or returns them to be burned if the params say so
+func (k Keeper) DistributeFees(ctx sdk.Context) (burned sdk.Coins) {
+	fees := k.fck.GetCollectedFees(ctx)
+	if fees.IsZero() {
+		return nil
+	}
+	k.fck.ClearCollectedFees(ctx)
+
+	if k.GetParams(ctx).BurnFees {
+		return fees
+	}
+	k.SetCommunityPool(ctx, k.GetCommunityPool(ctx).Plus(fees))
+	return nil
+}
@@ -0,0 +1,91 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.FeeCollectionKeeper, Keeper) {
+	keyDistr := sdk.NewKVStoreKey("distribution")
+	keyFee := sdk.NewKVStoreKey(auth.FeeStoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyFee, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	fck := auth.NewFeeCollectionKeeper(cdc, keyFee)
+	keeper := NewKeeper(keyDistr, cdc, fck, pk.Subspace(DefaultParamspace))
+
+	err := InitGenesis(ctx, keeper, DefaultGenesis())
+	require.Nil(t, err)
+
+	return ctx, fck, keeper
+}
+
+func TestBeginBlockerCommunityPool(t *testing.T) {
+	ctx, fck, keeper := createTestInput(t)
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 10)}
+
+	// nothing collected, nothing moves
+	require.True(t, BeginBlocker(ctx, keeper).IsZero())
+	require.Equal(t, sdk.Coins{}, keeper.GetCommunityPool(ctx))
+
+	fck.AddCollectedFees(ctx, fees)
+	require.True(t, BeginBlocker(ctx, keeper).IsZero())
+	require.Equal(t, fees, keeper.GetCommunityPool(ctx))
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+
+	fck.AddCollectedFees(ctx, fees)
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, fees.Plus(fees), keeper.GetCommunityPool(ctx))
+}
+
+func TestBeginBlockerBurnFees(t *testing.T) {
+	ctx, fck, keeper := createTestInput(t)
+	keeper.SetParams(ctx, Params{BurnFees: true})
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 10)}
+
+	fck.AddCollectedFees(ctx, fees)
+	require.Equal(t, fees, BeginBlocker(ctx, keeper))
+	require.Equal(t, sdk.Coins{}, keeper.GetCommunityPool(ctx))
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+}
+
+func TestQueryCommunityPool(t *testing.T) {
+	ctx, fck, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	fees := sdk.Coins{sdk.NewInt64Coin("steak", 10)}
+
+	fck.AddCollectedFees(ctx, fees)
+	BeginBlocker(ctx, keeper)
+
+	bz, err := querier(ctx, []string{QueryCommunityPool}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var pool sdk.Coins
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &pool))
+	require.Equal(t, fees, pool)
+
+	_, err = querier(ctx, []string{"other"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
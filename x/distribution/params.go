@@ -0,0 +1,60 @@
+package distribution
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default distribution module parameter subspace
+const DefaultParamspace = "distribution"
+
+// Parameter store keys
+var (
+	KeyBurnFees = []byte("BurnFees")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the distribution module
+type Params struct {
+	// burn the collected fees instead of moving them to the community pool
+	BurnFees bool `json:"burn_fees"`
+}
+
+// ParamKeyTable for distribution module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyBurnFees, &p.BurnFees},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		BurnFees: false,
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Burn Fees: %t`, p.BurnFees)
+}
+
+// GetParams returns the current distribution parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the distribution parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,37 @@
+package distribution
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the distribution Querier
+const (
+	QuerierRoute       = "distribution"
+	QueryCommunityPool = "community_pool"
+)
+
+// NewQuerier returns a querier for the distribution module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no distribution query endpoint given")
+		}
+		switch path[0] {
+		case QueryCommunityPool:
+			return marshalResult(k.GetCommunityPool(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown distribution query endpoint")
+		}
+	}
+}
+
+func marshalResult(res interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, res)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,10 @@
+package feegrant
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgGrantAllowance{}, "feegrant/GrantAllowance", nil)
+}
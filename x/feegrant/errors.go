@@ -0,0 +1,26 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeGrant errors reserve 800 ~ 899.
+const (
+	DefaultCodespace sdk.CodespaceType = "feegrant"
+
+	CodeSelfGrant         sdk.CodeType = 800
+	CodeInvalidSpendLimit sdk.CodeType = 801
+)
+
+// ErrSelfGrant - Error returned when an account grants an allowance to itself
+func ErrSelfGrant(codespace sdk.CodespaceType, addr sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeSelfGrant, fmt.Sprintf("%v cannot grant a fee allowance to itself", addr))
+}
+
+// ErrInvalidSpendLimit - Error returned when the spend limit of a grant is
+// invalid
+func ErrInvalidSpendLimit(codespace sdk.CodespaceType, limit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSpendLimit, fmt.Sprintf("invalid spend limit %v", limit))
+}
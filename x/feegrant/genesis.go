@@ -0,0 +1,33 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state, the fee grants
+type Genesis struct {
+	Grants []Grant `json:"grants"`
+}
+
+// DefaultGenesis returns the default genesis state for the feegrant module
+func DefaultGenesis() Genesis {
+	return Genesis{Grants: []Grant{}}
+}
+
+// InitGenesis for the feegrant module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	for _, grant := range genesis.Grants {
+		k.SetGrant(ctx, grant)
+	}
+	return nil
+}
+
+// ExportGenesis for the feegrant module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	grants := []Grant{}
+	k.IterateGrants(ctx, func(grant Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return Genesis{Grants: grants}
+}
@@ -0,0 +1,27 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "feegrant" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantAllowance:
+			return handleMsgGrantAllowance(ctx, k, msg)
+		default:
+			errMsg := fmt.Sprintf("Unrecognized feegrant Msg type: %v", msg.Type())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// Handle MsgGrantAllowance, replacing any previous grant between the
+// accounts
+func handleMsgGrantAllowance(ctx sdk.Context, k Keeper, msg MsgGrantAllowance) sdk.Result {
+	k.SetGrant(ctx, NewGrant(msg.Granter, msg.Grantee, msg.SpendLimit))
+	return sdk.Result{}
+}
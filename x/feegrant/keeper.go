@@ -0,0 +1,133 @@
+package feegrant
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// BankKeeper moves the granted fees from the granter to the grantee. The
+// fee must arrive in full, so the move is neither taxed nor charged.
+type BankKeeper interface {
+	MoveCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+}
+
+// Keeper of the fee grants
+type Keeper struct {
+	bk BankKeeper
+
+	key       sdk.StoreKey
+	cdc       *codec.Codec
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, bk BankKeeper, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		bk:        bk,
+		key:       key,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+// GetGrant returns the grant from the granter to the grantee
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) (grant Grant, found bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetGrantKey(granter, grantee))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &grant)
+	return grant, true
+}
+
+// SetGrant sets a grant, replacing any previous grant between the same
+// accounts
+func (k Keeper) SetGrant(ctx sdk.Context, grant Grant) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetGrantKey(grant.Granter, grant.Grantee), k.cdc.MustMarshalBinaryLengthPrefixed(grant))
+}
+
+func (k Keeper) deleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress) {
+	store := ctx.KVStore(k.key)
+	store.Delete(GetGrantKey(granter, grantee))
+}
+
+// IterateGrants iterates over all the grants by grantee then granter
+func (k Keeper) IterateGrants(ctx sdk.Context, fn func(grant Grant) (stop bool)) {
+	k.iterateGrants(ctx, GrantKeyPrefix, fn)
+}
+
+// IterateGranteeGrants iterates over the grants given to the grantee in
+// granter order
+func (k Keeper) IterateGranteeGrants(ctx sdk.Context, grantee sdk.AccAddress, fn func(grant Grant) (stop bool)) {
+	k.iterateGrants(ctx, GetGranteeGrantsKey(grantee), fn)
+}
+
+func (k Keeper) iterateGrants(ctx sdk.Context, prefix []byte, fn func(grant Grant) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &grant)
+		if fn(grant) {
+			return
+		}
+	}
+}
+
+// UseGrantedFee pays the fee of the grantee with the first grant covering
+// it, moving the fee from the granter to the grantee and reducing the spend
+// limit. A grant whose spend limit is used up is removed. It returns the
+// granter, or false if no grant could pay the fee.
+func (k Keeper) UseGrantedFee(ctx sdk.Context, grantee sdk.AccAddress, fee sdk.Coins) (granter sdk.AccAddress, ok bool) {
+	var used Grant
+	k.IterateGranteeGrants(ctx, grantee, func(grant Grant) bool {
+		if !grant.Covers(fee) {
+			return false
+		}
+		// skip granters who can't afford the fee
+		cacheCtx, write := ctx.CacheContext()
+		if err := k.bk.MoveCoins(cacheCtx, grant.Granter, grantee, fee); err != nil {
+			return false
+		}
+		write()
+		used, ok = grant, true
+		return true
+	})
+	if !ok {
+		return nil, false
+	}
+
+	if !used.SpendLimit.IsZero() {
+		used.SpendLimit = used.SpendLimit.Minus(fee)
+		if used.SpendLimit.IsZero() {
+			k.deleteGrant(ctx, used.Granter, grantee)
+		} else {
+			k.SetGrant(ctx, used)
+		}
+	}
+	return used.Granter, true
+}
+
+// NewAnteHandler wraps an AnteHandler and has the fee of a tx paid by a
+// granter of its first signer when a grant covers it. The wrapped handler
+// then deducts the fee from the signer as usual. Without a usable grant the
+// signer pays the fee itself.
+func (k Keeper) NewAnteHandler(ah sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+		stdTx, ok := tx.(auth.StdTx)
+		if !ok || stdTx.Fee.Amount.IsZero() {
+			return ah(ctx, tx, simulate)
+		}
+		signers := stdTx.GetSigners()
+		if len(signers) == 0 {
+			return ah(ctx, tx, simulate)
+		}
+
+		k.UseGrantedFee(ctx, signers[0], stdTx.Fee.Amount)
+		return ah(ctx, tx, simulate)
+	}
+}
@@ -0,0 +1,162 @@
+package feegrant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// testBankKeeper moves the fees with the plain transfers of the base keeper
+type testBankKeeper struct {
+	bank.Keeper
+}
+
+func (bk testBankKeeper) MoveCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	_, err := bk.SendCoins(ctx, fromAddr, toAddr, amt)
+	return err
+}
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, Keeper) {
+	keyFeeGrant := sdk.NewKVStoreKey("feegrant")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyFeeGrant, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak)
+	keeper := NewKeeper(keyFeeGrant, cdc, testBankKeeper{bk}, DefaultCodespace)
+
+	return ctx, bk, keeper
+}
+
+// deductFeeAnteHandler deducts the fee from the first signer like the auth
+// AnteHandler does
+func deductFeeAnteHandler(bk bank.Keeper) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+		stdTx := tx.(auth.StdTx)
+		_, _, err := bk.SubtractCoins(ctx, stdTx.GetSigners()[0], stdTx.Fee.Amount)
+		if err != nil {
+			return ctx, err.Result(), true
+		}
+		return ctx, sdk.Result{}, false
+	}
+}
+
+func TestAnteHandlerValidGrant(t *testing.T) {
+	ctx, bk, keeper := createTestInput(t)
+	granter := sdk.AccAddress([]byte("granter"))
+	grantee := sdk.AccAddress([]byte("grantee"))
+	ah := keeper.NewAnteHandler(deductFeeAnteHandler(bk))
+
+	_, _, err := bk.AddCoins(ctx, granter, sdk.Coins{sdk.NewInt64Coin("steak", 100)})
+	require.Nil(t, err)
+	keeper.SetGrant(ctx, NewGrant(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", 15)}))
+
+	fee := auth.NewStdFee(200000, sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	tx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(grantee)}, fee, nil, "")
+	_, res, abort := ah(ctx, tx, false)
+	require.False(t, abort, res.Log)
+
+	// the granter paid the fee and the allowance shrank
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 90)}, bk.GetCoins(ctx, granter))
+	require.True(t, bk.GetCoins(ctx, grantee).IsZero())
+	grant, found := keeper.GetGrant(ctx, granter, grantee)
+	require.True(t, found)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 5)}, grant.SpendLimit)
+
+	// an empty spend limit pays any fee and is kept
+	keeper.SetGrant(ctx, NewGrant(granter, grantee, nil))
+	_, res, abort = ah(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 80)}, bk.GetCoins(ctx, granter))
+	_, found = keeper.GetGrant(ctx, granter, grantee)
+	require.True(t, found)
+}
+
+func TestAnteHandlerExhaustedAllowance(t *testing.T) {
+	ctx, bk, keeper := createTestInput(t)
+	granter := sdk.AccAddress([]byte("granter"))
+	grantee := sdk.AccAddress([]byte("grantee"))
+	ah := keeper.NewAnteHandler(deductFeeAnteHandler(bk))
+
+	_, _, err := bk.AddCoins(ctx, granter, sdk.Coins{sdk.NewInt64Coin("steak", 100)})
+	require.Nil(t, err)
+	keeper.SetGrant(ctx, NewGrant(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", 10)}))
+
+	fee := auth.NewStdFee(200000, sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	tx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(grantee)}, fee, nil, "")
+	_, res, abort := ah(ctx, tx, false)
+	require.False(t, abort, res.Log)
+
+	// the used up grant is removed
+	_, found := keeper.GetGrant(ctx, granter, grantee)
+	require.False(t, found)
+
+	// the grantee can't pay the next fee itself
+	_, res, abort = ah(ctx, tx, false)
+	require.True(t, abort)
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 90)}, bk.GetCoins(ctx, granter))
+
+	// a limit below the fee doesn't pay it either
+	keeper.SetGrant(ctx, NewGrant(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", 5)}))
+	_, res, abort = ah(ctx, tx, false)
+	require.True(t, abort)
+	require.Equal(t, sdk.CodeInsufficientCoins, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 90)}, bk.GetCoins(ctx, granter))
+}
+
+func TestAnteHandlerNoGrant(t *testing.T) {
+	ctx, bk, keeper := createTestInput(t)
+	granter := sdk.AccAddress([]byte("granter"))
+	signer := sdk.AccAddress([]byte("signer"))
+	ah := keeper.NewAnteHandler(deductFeeAnteHandler(bk))
+
+	_, _, err := bk.AddCoins(ctx, granter, sdk.Coins{sdk.NewInt64Coin("steak", 100)})
+	require.Nil(t, err)
+	_, _, err = bk.AddCoins(ctx, signer, sdk.Coins{sdk.NewInt64Coin("steak", 20)})
+	require.Nil(t, err)
+
+	// the signer pays its own fee
+	fee := auth.NewStdFee(200000, sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	tx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(signer)}, fee, nil, "")
+	_, res, abort := ah(ctx, tx, false)
+	require.False(t, abort, res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 10)}, bk.GetCoins(ctx, signer))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 100)}, bk.GetCoins(ctx, granter))
+}
+
+func TestMsgGrantAllowanceValidateBasic(t *testing.T) {
+	granter := sdk.AccAddress([]byte("granter"))
+	grantee := sdk.AccAddress([]byte("grantee"))
+
+	require.Nil(t, NewMsgGrantAllowance(granter, grantee, nil).ValidateBasic())
+	require.Nil(t, NewMsgGrantAllowance(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", 5)}).ValidateBasic())
+	require.NotNil(t, NewMsgGrantAllowance(granter, granter, nil).ValidateBasic())
+	require.NotNil(t, NewMsgGrantAllowance(nil, grantee, nil).ValidateBasic())
+	require.NotNil(t, NewMsgGrantAllowance(granter, grantee, sdk.Coins{sdk.NewInt64Coin("steak", -5)}).ValidateBasic())
+}
@@ -0,0 +1,20 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Keys for feegrant store
+var (
+	GrantKeyPrefix = []byte{0x00}
+)
+
+// GetGranteeGrantsKey returns the prefix of the grants given to a grantee
+func GetGranteeGrantsKey(grantee sdk.AccAddress) []byte {
+	return append(GrantKeyPrefix, grantee.Bytes()...)
+}
+
+// GetGrantKey returns the key of the grant from a granter to a grantee
+func GetGrantKey(granter, grantee sdk.AccAddress) []byte {
+	return append(GetGranteeGrantsKey(grantee), granter.Bytes()...)
+}
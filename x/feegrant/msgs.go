@@ -0,0 +1,63 @@
+package feegrant
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgGrantAllowance - allows the grantee to have its tx fees paid by the
+// granter up to the spend limit, without limit if it is empty
+type MsgGrantAllowance struct {
+	Granter    sdk.AccAddress
+	Grantee    sdk.AccAddress
+	SpendLimit sdk.Coins
+}
+
+// NewMsgGrantAllowance - new grant allowance message
+func NewMsgGrantAllowance(granter, grantee sdk.AccAddress, spendLimit sdk.Coins) MsgGrantAllowance {
+	return MsgGrantAllowance{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+	}
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgGrantAllowance{}
+
+// nolint
+func (msg MsgGrantAllowance) Route() string                { return "feegrant" }
+func (msg MsgGrantAllowance) Type() string                 { return "grant_allowance" }
+func (msg MsgGrantAllowance) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Granter} }
+func (msg MsgGrantAllowance) String() string {
+	return fmt.Sprintf("MsgGrantAllowance{Granter: %v, Grantee: %v, SpendLimit: %v}",
+		msg.Granter, msg.Grantee, msg.SpendLimit)
+}
+
+// ValidateBasic is used to quickly disqualify obviously invalid messages quickly
+func (msg MsgGrantAllowance) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if msg.Granter.Equals(msg.Grantee) {
+		return ErrSelfGrant(DefaultCodespace, msg.Granter)
+	}
+	if !msg.SpendLimit.IsZero() && (!msg.SpendLimit.IsValid() || !msg.SpendLimit.IsPositive()) {
+		return ErrInvalidSpendLimit(DefaultCodespace, msg.SpendLimit)
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg MsgGrantAllowance) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
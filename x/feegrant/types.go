@@ -0,0 +1,34 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Grant allows the grantee to have its tx fees paid by the granter, up to
+// the spend limit. An empty spend limit allows any amount.
+type Grant struct {
+	Granter    sdk.AccAddress `json:"granter"`
+	Grantee    sdk.AccAddress `json:"grantee"`
+	SpendLimit sdk.Coins      `json:"spend_limit"`
+}
+
+// NewGrant creates a new grant
+func NewGrant(granter, grantee sdk.AccAddress, spendLimit sdk.Coins) Grant {
+	return Grant{
+		Granter:    granter,
+		Grantee:    grantee,
+		SpendLimit: spendLimit,
+	}
+}
+
+// Covers returns whether the grant allows paying the fee
+func (g Grant) Covers(fee sdk.Coins) bool {
+	return g.SpendLimit.IsZero() || g.SpendLimit.IsAllGTE(fee)
+}
+
+// String implements fmt.Stringer
+func (g Grant) String() string {
+	return fmt.Sprintf("Grant{Granter: %v, Grantee: %v, SpendLimit: %v}", g.Granter, g.Grantee, g.SpendLimit)
+}
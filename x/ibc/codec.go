@@ -0,0 +1,20 @@
+package ibc
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(IBCTransferMsg{}, "ibc/Transfer", nil)
+	cdc.RegisterConcrete(IBCReceiveMsg{}, "ibc/Receive", nil)
+	cdc.RegisterConcrete(IBCReceiptMsg{}, "ibc/Receipt", nil)
+	cdc.RegisterConcrete(IBCAckMsg{}, "ibc/Ack", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "ibc/Timeout", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
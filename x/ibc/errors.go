@@ -0,0 +1,81 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// IBC errors reserve 600 ~ 699.
+const (
+	DefaultCodespace sdk.CodespaceType = "ibc"
+
+	CodeInvalidSequence   sdk.CodeType = 600
+	CodeIdenticalChains   sdk.CodeType = 601
+	CodeUnknownPacket     sdk.CodeType = 602
+	CodeTimeoutNotReached sdk.CodeType = 603
+	CodeInvalidTimeout    sdk.CodeType = 604
+	CodeSendLimitExceeded sdk.CodeType = 605
+	CodeUnknownRelayer    sdk.CodeType = 606
+	CodeUnknownRequest    sdk.CodeType = sdk.CodeUnknownRequest
+)
+
+// NOTE: Don't stringer this, we'll put better messages in later.
+func codeToDefaultMsg(code sdk.CodeType) string {
+	switch code {
+	case CodeInvalidSequence:
+		return "invalid IBC packet sequence"
+	case CodeIdenticalChains:
+		return "source and destination chain cannot be identical"
+	case CodeUnknownPacket:
+		return "unknown pending IBC packet"
+	case CodeTimeoutNotReached:
+		return "IBC packet has not timed out yet"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodeSendLimitExceeded:
+		return "IBC send limit per block exceeded"
+	case CodeUnknownRelayer:
+		return "not a trusted IBC relayer"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+// nolint
+func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidSequence, "")
+}
+func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeIdenticalChains, "")
+}
+func ErrUnknownPacket(codespace sdk.CodespaceType, destChain string, sequence uint64) sdk.Error {
+	return newError(codespace, CodeUnknownPacket, fmt.Sprintf("no pending packet %d to %s", sequence, destChain))
+}
+func ErrTimeoutNotReached(codespace sdk.CodespaceType, timeoutHeight int64) sdk.Error {
+	return newError(codespace, CodeTimeoutNotReached, fmt.Sprintf("packet times out after height %d", timeoutHeight))
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, "")
+}
+func ErrSendLimitExceeded(codespace sdk.CodespaceType, addr sdk.AccAddress, max uint64) sdk.Error {
+	return newError(codespace, CodeSendLimitExceeded, fmt.Sprintf("%v already sent %d IBC transfers in this block", addr, max))
+}
+func ErrUnknownRelayer(codespace sdk.CodespaceType, addr sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeUnknownRelayer, fmt.Sprintf("%v is not a trusted relayer", addr))
+}
+
+// -------------------------
+// Helpers
+
+func newError(codespace sdk.CodespaceType, code sdk.CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
+
+func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
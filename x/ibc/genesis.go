@@ -0,0 +1,30 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state of the ibc module
+type Genesis struct {
+	Params Params `json:"params"`
+}
+
+// DefaultGenesis returns the default genesis state for the ibc module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		Params: DefaultParams(),
+	}
+}
+
+// InitGenesis for the ibc module
+func InitGenesis(ctx sdk.Context, ibcm Mapper, genesis Genesis) error {
+	ibcm.SetParams(ctx, genesis.Params)
+	return nil
+}
+
+// ExportGenesis for the ibc module
+func ExportGenesis(ctx sdk.Context, ibcm Mapper) Genesis {
+	return Genesis{
+		Params: ibcm.GetParams(ctx),
+	}
+}
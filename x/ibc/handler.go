@@ -0,0 +1,134 @@
+package ibc
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// NewHandler returns a handler for "ibc" type messages.
+func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case IBCTransferMsg:
+			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
+		case IBCReceiveMsg:
+			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+		case IBCReceiptMsg:
+			return handleIBCReceiptMsg(ctx, ibcm, msg)
+		case IBCAckMsg:
+			return handleIBCAckMsg(ctx, ibcm, msg)
+		case IBCTimeoutMsg:
+			return handleIBCTimeoutMsg(ctx, ibcm, ck, msg)
+		default:
+			errMsg := "Unrecognized IBC Msg type: " + msg.Type()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
+func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
+	packet := msg.IBCPacket
+
+	count := ibcm.getSendCount(ctx, packet.SrcAddr)
+	max := ibcm.GetParams(ctx).MaxSendsPerBlock
+	if max != 0 && count >= max {
+		return ErrSendLimitExceeded(ibcm.codespace, packet.SrcAddr, max).Result()
+	}
+
+	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.setSendCount(ctx, packet.SrcAddr, count+1)
+	sequence := ibcm.PostIBCPacket(ctx, packet, msg.TimeoutHeight)
+
+	return sdk.Result{
+		Tags: sdk.NewTags("sequence", []byte(strconv.FormatUint(sequence, 10))),
+	}
+}
+
+// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
+// Only trusted relayers may post packets.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
+	if !ibcm.IsRelayer(ctx, msg.Relayer) {
+		return ErrUnknownRelayer(ibcm.codespace, msg.Relayer).Result()
+	}
+	packet := msg.IBCPacket
+
+	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
+	if msg.Sequence != seq {
+		return ErrInvalidSequence(ibcm.codespace).Result()
+	}
+
+	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+
+	return sdk.Result{}
+}
+
+// IBCReceiptMsg settles an outgoing packet, it can no longer be refunded.
+// Only trusted relayers may report receipts.
+func handleIBCReceiptMsg(ctx sdk.Context, ibcm Mapper, msg IBCReceiptMsg) sdk.Result {
+	if !ibcm.IsRelayer(ctx, msg.Relayer) {
+		return ErrUnknownRelayer(ibcm.codespace, msg.Relayer).Result()
+	}
+	_, found := ibcm.getPendingPacket(ctx, msg.DestChain, msg.Sequence)
+	if !found {
+		return ErrUnknownPacket(ibcm.codespace, msg.DestChain, msg.Sequence).Result()
+	}
+
+	ibcm.deletePendingPacket(ctx, msg.DestChain, msg.Sequence)
+	ibcm.setPacketState(ctx, msg.DestChain, msg.Sequence, PacketReceived)
+
+	return sdk.Result{}
+}
+
+// IBCAckMsg marks an outgoing packet, pending or received, as processed by
+// the destination chain. Only trusted relayers may acknowledge packets.
+func handleIBCAckMsg(ctx sdk.Context, ibcm Mapper, msg IBCAckMsg) sdk.Result {
+	if !ibcm.IsRelayer(ctx, msg.Relayer) {
+		return ErrUnknownRelayer(ibcm.codespace, msg.Relayer).Result()
+	}
+	state, found := ibcm.GetPacketState(ctx, msg.DestChain, msg.Sequence)
+	if !found || (state != PacketPending && state != PacketReceived) {
+		return ErrUnknownPacket(ibcm.codespace, msg.DestChain, msg.Sequence).Result()
+	}
+
+	ibcm.deletePendingPacket(ctx, msg.DestChain, msg.Sequence)
+	ibcm.setPacketState(ctx, msg.DestChain, msg.Sequence, PacketAcknowledged)
+
+	return sdk.Result{}
+}
+
+// IBCTimeoutMsg refunds the sender of an outgoing packet that was not
+// received before its timeout height.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTimeoutMsg) sdk.Result {
+	pending, found := ibcm.getPendingPacket(ctx, msg.DestChain, msg.Sequence)
+	if !found {
+		return ErrUnknownPacket(ibcm.codespace, msg.DestChain, msg.Sequence).Result()
+	}
+	if !pending.Packet.SrcAddr.Equals(msg.Sender) {
+		return sdk.ErrUnauthorized("only the sender of the packet can claim a refund").Result()
+	}
+	if ctx.BlockHeight() <= pending.TimeoutHeight {
+		return ErrTimeoutNotReached(ibcm.codespace, pending.TimeoutHeight).Result()
+	}
+
+	_, _, err := ck.AddCoins(ctx, pending.Packet.SrcAddr, pending.Packet.Coins)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.deletePendingPacket(ctx, msg.DestChain, msg.Sequence)
+	ibcm.setPacketState(ctx, msg.DestChain, msg.Sequence, PacketTimedOut)
+
+	return sdk.Result{}
+}
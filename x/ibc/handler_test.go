@@ -0,0 +1,214 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Mapper, sdk.Handler) {
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	tkeyIBC := sdk.NewTransientStoreKey("transient_ibc")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyIBC, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(ak)
+	ibcm := NewMapper(cdc, keyIBC, tkeyIBC, pk.Subspace(DefaultParamspace), DefaultCodespace)
+	require.Nil(t, InitGenesis(ctx, ibcm, DefaultGenesis()))
+
+	return ctx, ak, ibcm, NewHandler(ibcm, ck)
+}
+
+func fundedAddr(ctx sdk.Context, ak auth.AccountKeeper, coins sdk.Coins) sdk.AccAddress {
+	addr := sdk.AccAddress([]byte("sender"))
+	acc := ak.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(coins)
+	ak.SetAccount(ctx, acc)
+	return addr
+}
+
+func TestIBCTimeoutRefund(t *testing.T) {
+	ctx, ak, _, handler := createTestInput(t)
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	sender := fundedAddr(ctx, ak, coins)
+	dest := sdk.AccAddress([]byte("dest"))
+
+	ctx = ctx.WithBlockHeight(1)
+	packet := NewIBCPacket(sender, dest, coins, "src-chain", "dest-chain")
+	msg := IBCTransferMsg{IBCPacket: packet, TimeoutHeight: 10}
+	require.Nil(t, msg.ValidateBasic())
+	require.True(t, handler(ctx, msg).IsOK())
+	require.True(t, ak.GetAccount(ctx, sender).GetCoins().IsZero())
+
+	// no refund before the timeout
+	timeout := IBCTimeoutMsg{DestChain: "dest-chain", Sequence: 0, Sender: sender}
+	res := handler(ctx.WithBlockHeight(10), timeout)
+	require.Equal(t, CodeTimeoutNotReached, res.Code)
+
+	// only the sender can claim the refund
+	res = handler(ctx.WithBlockHeight(11), IBCTimeoutMsg{DestChain: "dest-chain", Sequence: 0, Sender: dest})
+	require.Equal(t, sdk.CodeUnauthorized, res.Code)
+
+	res = handler(ctx.WithBlockHeight(11), timeout)
+	require.True(t, res.IsOK())
+	require.Equal(t, coins, ak.GetAccount(ctx, sender).GetCoins())
+
+	// the packet is refunded only once
+	res = handler(ctx.WithBlockHeight(11), timeout)
+	require.Equal(t, CodeUnknownPacket, res.Code)
+	require.Equal(t, coins, ak.GetAccount(ctx, sender).GetCoins())
+}
+
+func TestIBCReceiptPreventsTimeout(t *testing.T) {
+	ctx, ak, ibcm, handler := createTestInput(t)
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	sender := fundedAddr(ctx, ak, coins)
+	relayer := sdk.AccAddress([]byte("relayer"))
+	ibcm.SetParams(ctx, Params{MaxSendsPerBlock: 10, Relayers: []sdk.AccAddress{relayer}})
+
+	packet := NewIBCPacket(sender, sdk.AccAddress([]byte("dest")), coins, "src-chain", "dest-chain")
+	require.True(t, handler(ctx, IBCTransferMsg{IBCPacket: packet, TimeoutHeight: 10}).IsOK())
+
+	// only trusted relayers report receipts
+	for _, untrusted := range []sdk.AccAddress{sender, sdk.AccAddress([]byte("other"))} {
+		res := handler(ctx, IBCReceiptMsg{DestChain: "dest-chain", Sequence: 0, Relayer: untrusted})
+		require.Equal(t, CodeUnknownRelayer, res.Code)
+	}
+	require.Len(t, ibcm.GetPendingPackets(ctx, "dest-chain"), 1)
+
+	require.True(t, handler(ctx, IBCReceiptMsg{DestChain: "dest-chain", Sequence: 0, Relayer: relayer}).IsOK())
+	state, _ := ibcm.GetPacketState(ctx, "dest-chain", 0)
+	require.Equal(t, PacketReceived, state)
+
+	res := handler(ctx.WithBlockHeight(11), IBCTimeoutMsg{DestChain: "dest-chain", Sequence: 0, Sender: sender})
+	require.Equal(t, CodeUnknownPacket, res.Code)
+	require.True(t, ak.GetAccount(ctx, sender).GetCoins().IsZero())
+
+	// the received packet can still be acknowledged
+	require.True(t, handler(ctx, IBCAckMsg{DestChain: "dest-chain", Sequence: 0, Relayer: relayer}).IsOK())
+	state, _ = ibcm.GetPacketState(ctx, "dest-chain", 0)
+	require.Equal(t, PacketAcknowledged, state)
+}
+
+func TestIBCPacketState(t *testing.T) {
+	srcCtx, srcAk, srcIbcm, srcHandler := createTestInput(t)
+	destCtx, destAk, destIbcm, destHandler := createTestInput(t)
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	sender := fundedAddr(srcCtx, srcAk, sdk.Coins{sdk.NewInt64Coin("foocoin", 20)})
+	dest := sdk.AccAddress([]byte("dest"))
+	relayer := sdk.AccAddress([]byte("relayer"))
+	srcIbcm.SetParams(srcCtx, Params{MaxSendsPerBlock: 10, Relayers: []sdk.AccAddress{relayer}})
+	destIbcm.SetParams(destCtx, Params{MaxSendsPerBlock: 10, Relayers: []sdk.AccAddress{relayer}})
+
+	// send
+	packet := NewIBCPacket(sender, dest, coins, "src-chain", "dest-chain")
+	require.True(t, srcHandler(srcCtx, IBCTransferMsg{IBCPacket: packet, TimeoutHeight: 10}).IsOK())
+	require.True(t, srcHandler(srcCtx, IBCTransferMsg{IBCPacket: packet, TimeoutHeight: 10}).IsOK())
+	for seq := uint64(0); seq < 2; seq++ {
+		state, found := srcIbcm.GetPacketState(srcCtx, "dest-chain", seq)
+		require.True(t, found)
+		require.Equal(t, PacketPending, state)
+	}
+	_, found := srcIbcm.GetPacketState(srcCtx, "dest-chain", 2)
+	require.False(t, found)
+
+	// receive
+	receive := IBCReceiveMsg{IBCPacket: packet, Relayer: relayer, Sequence: 0}
+	require.True(t, destHandler(destCtx, receive).IsOK())
+	require.Equal(t, coins, destAk.GetAccount(destCtx, dest).GetCoins())
+	state, _ := srcIbcm.GetPacketState(srcCtx, "dest-chain", 0)
+	require.Equal(t, PacketPending, state)
+
+	// only trusted relayers acknowledge packets
+	ack := IBCAckMsg{DestChain: "dest-chain", Sequence: 0, Relayer: sender}
+	require.Equal(t, CodeUnknownRelayer, srcHandler(srcCtx, ack).Code)
+
+	// ack
+	ack.Relayer = relayer
+	require.Nil(t, ack.ValidateBasic())
+	require.True(t, srcHandler(srcCtx, ack).IsOK())
+	state, _ = srcIbcm.GetPacketState(srcCtx, "dest-chain", 0)
+	require.Equal(t, PacketAcknowledged, state)
+	require.Equal(t, CodeUnknownPacket, srcHandler(srcCtx, ack).Code)
+
+	// the acknowledged packet can't be refunded
+	res := srcHandler(srcCtx.WithBlockHeight(11), IBCTimeoutMsg{DestChain: "dest-chain", Sequence: 0, Sender: sender})
+	require.Equal(t, CodeUnknownPacket, res.Code)
+
+	// while the other one stays pending until it times out
+	require.Len(t, srcIbcm.GetPendingPackets(srcCtx, "dest-chain"), 1)
+	res = srcHandler(srcCtx.WithBlockHeight(11), IBCTimeoutMsg{DestChain: "dest-chain", Sequence: 1, Sender: sender})
+	require.True(t, res.IsOK())
+	require.Equal(t, coins, srcAk.GetAccount(srcCtx, sender).GetCoins())
+	state, _ = srcIbcm.GetPacketState(srcCtx, "dest-chain", 1)
+	require.Equal(t, PacketTimedOut, state)
+	ack.Sequence = 1
+	require.Equal(t, CodeUnknownPacket, srcHandler(srcCtx, ack).Code)
+}
+
+func TestIBCReceiveFromTrustedRelayer(t *testing.T) {
+	ctx, ak, ibcm, handler := createTestInput(t)
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	dest := sdk.AccAddress([]byte("dest"))
+	relayer := sdk.AccAddress([]byte("relayer"))
+	ibcm.SetParams(ctx, Params{MaxSendsPerBlock: 10, Relayers: []sdk.AccAddress{relayer}})
+
+	packet := NewIBCPacket(sdk.AccAddress([]byte("sender")), dest, coins, "src-chain", "dest-chain")
+	res := handler(ctx, IBCReceiveMsg{IBCPacket: packet, Relayer: sdk.AccAddress([]byte("other")), Sequence: 0})
+	require.Equal(t, CodeUnknownRelayer, res.Code)
+	require.Nil(t, ak.GetAccount(ctx, dest))
+	require.Equal(t, uint64(0), ibcm.GetIngressSequence(ctx, "src-chain"))
+
+	require.True(t, handler(ctx, IBCReceiveMsg{IBCPacket: packet, Relayer: relayer, Sequence: 0}).IsOK())
+	require.Equal(t, coins, ak.GetAccount(ctx, dest).GetCoins())
+	require.Equal(t, uint64(1), ibcm.GetIngressSequence(ctx, "src-chain"))
+}
+
+func TestIBCSendLimitPerBlock(t *testing.T) {
+	ctx, ak, ibcm, handler := createTestInput(t)
+	ibcm.SetParams(ctx, Params{MaxSendsPerBlock: 3})
+	coin := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+	sender := fundedAddr(ctx, ak, sdk.Coins{sdk.NewInt64Coin("foocoin", 10)})
+	msg := IBCTransferMsg{
+		IBCPacket:     NewIBCPacket(sender, sdk.AccAddress([]byte("dest")), coin, "src-chain", "dest-chain"),
+		TimeoutHeight: 10,
+	}
+
+	for i := 0; i < 3; i++ {
+		require.True(t, handler(ctx, msg).IsOK())
+	}
+	res := handler(ctx, msg)
+	require.Equal(t, CodeSendLimitExceeded, res.Code)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("foocoin", 7)}, ak.GetAccount(ctx, sender).GetCoins())
+
+	// the counter resets in the next block
+	ctx.MultiStore().(sdk.CommitMultiStore).Commit()
+	require.True(t, handler(ctx, msg).IsOK())
+}
@@ -0,0 +1,223 @@
+package ibc
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Mapper - IBC Mapper
+type Mapper struct {
+	key        sdk.StoreKey
+	tkey       sdk.StoreKey
+	cdc        *codec.Codec
+	paramSpace params.Subspace
+	codespace  sdk.CodespaceType
+}
+
+// NewMapper - XXX: The Mapper should not take a CoinKeeper. Rather have the
+// CoinKeeper take an Mapper.
+// The per block send counters are kept in the transient store of tkey.
+func NewMapper(cdc *codec.Codec, key, tkey sdk.StoreKey, paramSpace params.Subspace, codespace sdk.CodespaceType) Mapper {
+	// XXX: How are these codecs supposed to work?
+	return Mapper{
+		key:        key,
+		tkey:       tkey,
+		cdc:        cdc,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+}
+
+// PostIBCPacket appends the packet to the egress queue of its destination
+// chain and keeps it pending until its receipt is reported or it times out.
+// It returns the sequence of the packet.
+func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket, timeoutHeight int64) uint64 {
+	store := ctx.KVStore(ibcm.key)
+	index := ibcm.getEgressLength(store, packet.DestChain)
+
+	bz := marshalBinaryPanic(ibcm.cdc, packet)
+	store.Set(EgressKey(packet.DestChain, index), bz)
+
+	bz = marshalBinaryPanic(ibcm.cdc, index+1)
+	store.Set(EgressLengthKey(packet.DestChain), bz)
+
+	bz = marshalBinaryPanic(ibcm.cdc, pendingPacket{packet, timeoutHeight})
+	store.Set(PendingKey(packet.DestChain, index), bz)
+	ibcm.setPacketState(ctx, packet.DestChain, index, PacketPending)
+
+	return index
+}
+
+// ReceiveIBCPacket is a no-op, the packet is handled by the receive handler
+func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	return nil
+}
+
+// getPendingPacket returns the outgoing packet if it is still pending
+func (ibcm Mapper) getPendingPacket(ctx sdk.Context, destChain string, index uint64) (pending pendingPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(PendingKey(destChain, index))
+	if bz == nil {
+		return pending, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &pending)
+	return pending, true
+}
+
+// GetPendingPackets returns the outgoing packets to the destination chain
+// that are neither received, acknowledged nor timed out, in sequence order
+func (ibcm Mapper) GetPendingPackets(ctx sdk.Context, destChain string) []EgressPacket {
+	store := ctx.KVStore(ibcm.key)
+
+	var length uint64
+	if bz := store.Get(EgressLengthKey(destChain)); bz != nil {
+		unmarshalBinaryPanic(ibcm.cdc, bz, &length)
+	}
+
+	packets := []EgressPacket{}
+	for index := uint64(0); index < length; index++ {
+		pending, found := ibcm.getPendingPacket(ctx, destChain, index)
+		if !found {
+			continue
+		}
+		packets = append(packets, EgressPacket{
+			Sequence:      index,
+			Packet:        pending.Packet,
+			TimeoutHeight: pending.TimeoutHeight,
+		})
+	}
+	return packets
+}
+
+func (ibcm Mapper) deletePendingPacket(ctx sdk.Context, destChain string, index uint64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Delete(PendingKey(destChain, index))
+}
+
+// GetPacketState returns the delivery state of the outgoing packet, false if
+// no packet was sent with the sequence
+func (ibcm Mapper) GetPacketState(ctx sdk.Context, destChain string, index uint64) (state PacketState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(PacketStateKey(destChain, index))
+	if bz == nil {
+		return state, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &state)
+	return state, true
+}
+
+func (ibcm Mapper) setPacketState(ctx sdk.Context, destChain string, index uint64, state PacketState) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(PacketStateKey(destChain, index), marshalBinaryPanic(ibcm.cdc, state))
+}
+
+// getSendCount returns the number of transfers sent by the address in the
+// current block. The transient store starts every block empty.
+func (ibcm Mapper) getSendCount(ctx sdk.Context, addr sdk.AccAddress) uint64 {
+	store := ctx.TransientStore(ibcm.tkey)
+	bz := store.Get(SendCountKey(addr))
+	if bz == nil {
+		return 0
+	}
+	var count uint64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &count)
+	return count
+}
+
+func (ibcm Mapper) setSendCount(ctx sdk.Context, addr sdk.AccAddress, count uint64) {
+	store := ctx.TransientStore(ibcm.tkey)
+	store.Set(SendCountKey(addr), marshalBinaryPanic(ibcm.cdc, count))
+}
+
+// --------------------------
+// Functions for accessing the underlying KVStore.
+
+func marshalBinaryPanic(cdc *codec.Codec, value interface{}) []byte {
+	res, err := cdc.MarshalBinaryLengthPrefixed(value)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func unmarshalBinaryPanic(cdc *codec.Codec, bz []byte, ptr interface{}) {
+	err := cdc.UnmarshalBinaryLengthPrefixed(bz, ptr)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetIngressSequence - TODO add description
+func (ibcm Mapper) GetIngressSequence(ctx sdk.Context, srcChain string) uint64 {
+	store := ctx.KVStore(ibcm.key)
+	key := IngressSequenceKey(srcChain)
+
+	bz := store.Get(key)
+	if bz == nil {
+		zero := marshalBinaryPanic(ibcm.cdc, uint64(0))
+		store.Set(key, zero)
+		return 0
+	}
+
+	var res uint64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &res)
+	return res
+}
+
+// SetIngressSequence - TODO add description
+func (ibcm Mapper) SetIngressSequence(ctx sdk.Context, srcChain string, sequence uint64) {
+	store := ctx.KVStore(ibcm.key)
+	key := IngressSequenceKey(srcChain)
+
+	bz := marshalBinaryPanic(ibcm.cdc, sequence)
+	store.Set(key, bz)
+}
+
+// Retrieves the index of the currently stored outgoing IBC packets.
+func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) uint64 {
+	bz := store.Get(EgressLengthKey(destChain))
+	if bz == nil {
+		zero := marshalBinaryPanic(ibcm.cdc, uint64(0))
+		store.Set(EgressLengthKey(destChain), zero)
+		return 0
+	}
+	var res uint64
+	unmarshalBinaryPanic(ibcm.cdc, bz, &res)
+	return res
+}
+
+// EgressKey - Stores an outgoing IBC packet under "egress/chain_id/index".
+func EgressKey(destChain string, index uint64) []byte {
+	return []byte(fmt.Sprintf("egress/%s/%d", destChain, index))
+}
+
+// EgressLengthKey - Stores the number of outgoing IBC packets under "egress/index".
+func EgressLengthKey(destChain string) []byte {
+	return []byte(fmt.Sprintf("egress/%s", destChain))
+}
+
+// IngressSequenceKey - Stores the sequence number of incoming IBC packet under "ingress/index".
+func IngressSequenceKey(srcChain string) []byte {
+	return []byte(fmt.Sprintf("ingress/%s", srcChain))
+}
+
+// PendingKey - Stores an outgoing IBC packet awaiting receipt or timeout
+// under "pending/chain_id/index".
+func PendingKey(destChain string, index uint64) []byte {
+	return []byte(fmt.Sprintf("pending/%s/%d", destChain, index))
+}
+
+// PacketStateKey - Stores the delivery state of an outgoing IBC packet under
+// "state/chain_id/index".
+func PacketStateKey(destChain string, index uint64) []byte {
+	return []byte(fmt.Sprintf("state/%s/%d", destChain, index))
+}
+
+// SendCountKey - Stores the number of transfers sent by an address in the
+// current block under "sendcount/address" in the transient store.
+func SendCountKey(addr sdk.AccAddress) []byte {
+	return append([]byte("sendcount/"), addr.Bytes()...)
+}
@@ -0,0 +1,79 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default ibc module parameter subspace
+const DefaultParamspace = "ibc"
+
+// Parameter store keys
+var (
+	KeyMaxSendsPerBlock = []byte("MaxSendsPerBlock")
+	KeyRelayers         = []byte("Relayers")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the ibc module
+type Params struct {
+	// number of transfers an account may send in a single block, zero
+	// disables the limit
+	MaxSendsPerBlock uint64 `json:"max_sends_per_block"`
+
+	// accounts trusted to report the receipt of outgoing packets, which
+	// can then no longer be refunded
+	Relayers []sdk.AccAddress `json:"relayers"`
+}
+
+// ParamKeyTable for ibc module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyMaxSendsPerBlock, &p.MaxSendsPerBlock},
+		{KeyRelayers, &p.Relayers},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		MaxSendsPerBlock: 10,
+		Relayers:         []sdk.AccAddress{},
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Max Sends Per Block: %d
+  Relayers:            %v`, p.MaxSendsPerBlock, p.Relayers)
+}
+
+// GetParams returns the current ibc parameters
+func (ibcm Mapper) GetParams(ctx sdk.Context) (params Params) {
+	ibcm.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// IsRelayer returns whether the account is a trusted relayer
+func (ibcm Mapper) IsRelayer(ctx sdk.Context, addr sdk.AccAddress) bool {
+	for _, relayer := range ibcm.GetParams(ctx).Relayers {
+		if relayer.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetParams sets the ibc parameters
+func (ibcm Mapper) SetParams(ctx sdk.Context, params Params) {
+	ibcm.paramSpace.SetParamSet(ctx, &params)
+}
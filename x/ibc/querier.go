@@ -0,0 +1,43 @@
+package ibc
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the ibc Querier
+const (
+	QuerierRoute = "ibc"
+	QueryEgress  = "egress"
+)
+
+// NewQuerier returns a querier for the ibc module
+func NewQuerier(ibcm Mapper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no ibc query endpoint given")
+		}
+		switch path[0] {
+		case QueryEgress:
+			return queryEgress(ctx, path[1:], ibcm)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+// queryEgress returns the outgoing packets awaiting delivery to the chain
+// given as the query path
+func queryEgress(ctx sdk.Context, path []string, ibcm Mapper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected a destination chain")
+	}
+
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, ibcm.GetPendingPackets(ctx, path[0]))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,48 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQueryEgress(t *testing.T) {
+	ctx, ak, ibcm, handler := createTestInput(t)
+	querier := NewQuerier(ibcm)
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 10)}
+	sender := fundedAddr(ctx, ak, coins.Plus(coins))
+	relayer := sdk.AccAddress([]byte("relayer"))
+
+	queryEgress := func(chain string) []EgressPacket {
+		bz, err := querier(ctx, []string{QueryEgress, chain}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var packets []EgressPacket
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &packets))
+		return packets
+	}
+	require.Empty(t, queryEgress("dest-chain"))
+
+	first := NewIBCPacket(sender, sdk.AccAddress([]byte("dest1")), coins, "src-chain", "dest-chain")
+	second := NewIBCPacket(sender, sdk.AccAddress([]byte("dest2")), coins, "src-chain", "dest-chain")
+	require.True(t, handler(ctx, IBCTransferMsg{IBCPacket: first, TimeoutHeight: 10}).IsOK())
+	require.True(t, handler(ctx, IBCTransferMsg{IBCPacket: second, TimeoutHeight: 20}).IsOK())
+
+	packets := queryEgress("dest-chain")
+	require.Equal(t, []EgressPacket{
+		{Sequence: 0, Packet: first, TimeoutHeight: 10},
+		{Sequence: 1, Packet: second, TimeoutHeight: 20},
+	}, packets)
+	require.Empty(t, queryEgress("other-chain"))
+
+	// delivered packets are no longer listed
+	require.True(t, handler(ctx, IBCReceiptMsg{DestChain: "dest-chain", Sequence: 0, Relayer: relayer}).IsOK())
+	require.Equal(t, []EgressPacket{{Sequence: 1, Packet: second, TimeoutHeight: 20}}, queryEgress("dest-chain"))
+
+	_, err := querier(ctx, []string{QueryEgress}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
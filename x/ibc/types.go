@@ -0,0 +1,241 @@
+package ibc
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// IBCPacket defines a piece of data that can be sent between two separate
+// blockchains.
+type IBCPacket struct {
+	SrcAddr   sdk.AccAddress
+	DestAddr  sdk.AccAddress
+	Coins     sdk.Coins
+	SrcChain  string
+	DestChain string
+}
+
+// NewIBCPacket - new IBC packet
+func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
+	srcChain string, destChain string) IBCPacket {
+
+	return IBCPacket{
+		SrcAddr:   srcAddr,
+		DestAddr:  destAddr,
+		Coins:     coins,
+		SrcChain:  srcChain,
+		DestChain: destChain,
+	}
+}
+
+// GetSignBytes - Get the bytes for the packet
+func (p IBCPacket) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// ValidateBasic - validator for IBC packets
+func (p IBCPacket) ValidateBasic() sdk.Error {
+	if p.SrcChain == p.DestChain {
+		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
+	}
+	if !p.Coins.IsValid() {
+		return sdk.ErrInvalidCoins("")
+	}
+	return nil
+}
+
+// pendingPacket is an outgoing packet that may still be refunded
+type pendingPacket struct {
+	Packet        IBCPacket
+	TimeoutHeight int64
+}
+
+// EgressPacket is an outgoing packet awaiting delivery, as returned by the
+// querier
+type EgressPacket struct {
+	Sequence      uint64    `json:"sequence"`
+	Packet        IBCPacket `json:"packet"`
+	TimeoutHeight int64     `json:"timeout_height"`
+}
+
+// PacketState is the delivery state of an outgoing packet
+type PacketState string
+
+// nolint
+const (
+	PacketPending      PacketState = "pending"      // awaiting a receipt, acknowledgement or timeout
+	PacketReceived     PacketState = "received"     // received by the destination chain, can't time out
+	PacketAcknowledged PacketState = "acknowledged" // processed by the destination chain
+	PacketTimedOut     PacketState = "timed_out"    // refunded to the sender
+)
+
+//----------------------------------------
+// IBCTransferMsg
+
+// IBCTransferMsg - sends coins to another chain. The coins are refunded if
+// no receipt is recorded by TimeoutHeight.
+type IBCTransferMsg struct {
+	IBCPacket
+	TimeoutHeight int64
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = IBCTransferMsg{}
+
+// nolint
+func (msg IBCTransferMsg) Route() string                { return "ibc" }
+func (msg IBCTransferMsg) Type() string                 { return "transfer" }
+func (msg IBCTransferMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.SrcAddr} }
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg IBCTransferMsg) GetSignBytes() []byte {
+	return msg.IBCPacket.GetSignBytes()
+}
+
+// ValidateBasic - validate the packet and its timeout
+func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
+	if msg.TimeoutHeight <= 0 {
+		return ErrInvalidTimeout(DefaultCodespace)
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+//----------------------------------------
+// IBCReceiveMsg
+
+// IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
+// to the destination chain.
+type IBCReceiveMsg struct {
+	IBCPacket
+	Relayer  sdk.AccAddress
+	Sequence uint64
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = IBCReceiveMsg{}
+
+// nolint
+func (msg IBCReceiveMsg) Route() string                { return "ibc" }
+func (msg IBCReceiveMsg) Type() string                 { return "receive" }
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error     { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg IBCReceiveMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket json.RawMessage
+		Relayer   sdk.AccAddress
+		Sequence  uint64
+	}{
+		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:   msg.Relayer,
+		Sequence:  msg.Sequence,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+//----------------------------------------
+// IBCReceiptMsg
+
+// IBCReceiptMsg defines the message that a trusted relayer uses to report
+// that an outgoing packet was received by the destination chain, so it can
+// no longer time out.
+type IBCReceiptMsg struct {
+	DestChain string
+	Sequence  uint64
+	Relayer   sdk.AccAddress
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = IBCReceiptMsg{}
+
+// nolint
+func (msg IBCReceiptMsg) Route() string                { return "ibc" }
+func (msg IBCReceiptMsg) Type() string                 { return "receipt" }
+func (msg IBCReceiptMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// ValidateBasic - validate the receipt
+func (msg IBCReceiptMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg IBCReceiptMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+//----------------------------------------
+// IBCAckMsg
+
+// IBCAckMsg defines the message that a trusted relayer uses to report that
+// an outgoing packet was processed by the destination chain. Until then the
+// packet stays pending and may time out.
+type IBCAckMsg struct {
+	DestChain string
+	Sequence  uint64
+	Relayer   sdk.AccAddress
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = IBCAckMsg{}
+
+// nolint
+func (msg IBCAckMsg) Route() string                { return "ibc" }
+func (msg IBCAckMsg) Type() string                 { return "ack" }
+func (msg IBCAckMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// ValidateBasic - validate the acknowledgement
+func (msg IBCAckMsg) ValidateBasic() sdk.Error {
+	if len(msg.Relayer) == 0 {
+		return sdk.ErrInvalidAddress(msg.Relayer.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg IBCAckMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+//----------------------------------------
+// IBCTimeoutMsg
+
+// IBCTimeoutMsg refunds the sender of an outgoing packet that was not
+// received before its timeout height.
+type IBCTimeoutMsg struct {
+	DestChain string
+	Sequence  uint64
+	Sender    sdk.AccAddress
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = IBCTimeoutMsg{}
+
+// nolint
+func (msg IBCTimeoutMsg) Route() string                { return "ibc" }
+func (msg IBCTimeoutMsg) Type() string                 { return "timeout" }
+func (msg IBCTimeoutMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+
+// ValidateBasic - validate the timeout
+func (msg IBCTimeoutMsg) ValidateBasic() sdk.Error {
+	if len(msg.Sender) == 0 {
+		return sdk.ErrInvalidAddress(msg.Sender.String())
+	}
+	return nil
+}
+
+// GetSignBytes - Get the bytes for the message signer to sign on
+func (msg IBCTimeoutMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
@@ -0,0 +1,105 @@
+package pow
+
+import (
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// count of solutions mined since the last retarget
+var windowCountKey = []byte("windowCount")
+
+func (k Keeper) getWindowCount(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(windowCountKey)
+	if stored == nil {
+		return 0
+	}
+	cnt, err := strconv.ParseUint(string(stored), 0, 64)
+	if err != nil {
+		panic(err)
+	}
+	return cnt
+}
+
+func (k Keeper) setWindowCount(ctx sdk.Context, cnt uint64) {
+	store := ctx.KVStore(k.key)
+	store.Set(windowCountKey, []byte(strconv.FormatUint(cnt, 10)))
+}
+
+// BeginBlocker retargets the difficulty every RetargetInterval blocks. It is
+// raised if more solutions than TargetMined were mined since the last
+// retarget and lowered otherwise.
+func BeginBlocker(ctx sdk.Context, k Keeper) abci.ResponseBeginBlock {
+	params := k.GetParams(ctx)
+	if params.RetargetInterval > 1 && ctx.BlockHeight()%int64(params.RetargetInterval) != 0 {
+		return abci.ResponseBeginBlock{}
+	}
+
+	difficulty, err := k.GetLastDifficulty(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	mined := k.getWindowCount(ctx) > params.TargetMined
+	k.SetLastDifficulty(ctx, adjustDifficulty(difficulty, mined, params.DecayRate, params.MaxDifficulty))
+	k.setWindowCount(ctx, 0)
+
+	return abci.ResponseBeginBlock{}
+}
+
+// EndBlocker halves the reward once HalvingInterval blocks passed since the
+// last halving, never below the reward floor
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	params := k.GetParams(ctx)
+	if params.HalvingInterval == 0 || ctx.BlockHeight()-k.GetLastHalvingHeight(ctx) < int64(params.HalvingInterval) {
+		return
+	}
+
+	k.SetReward(ctx, halveReward(k.GetReward(ctx), params.RewardFloor))
+	k.SetLastHalvingHeight(ctx, ctx.BlockHeight())
+}
+
+// halveReward halves every coin of the reward, keeping at least the amount
+// of the floor. Coins already at or below the floor are left alone and coins
+// halved to zero are dropped.
+func halveReward(reward sdk.Coins, floor sdk.Coins) sdk.Coins {
+	halved := sdk.Coins{}
+	for _, coin := range reward {
+		min := floor.AmountOf(coin.Denom)
+		amount := coin.Amount
+		if amount.GT(min) {
+			amount = sdk.MaxInt(amount.DivRaw(2), min)
+		}
+		if !amount.IsZero() {
+			halved = append(halved, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return halved
+}
+
+// adjustDifficulty moves the difficulty by the given rate, always by at least
+// one step, never below one and never above max
+func adjustDifficulty(difficulty uint64, raise bool, rate sdk.Dec, max uint64) uint64 {
+	step := uint64(rate.MulInt64(int64(difficulty)).TruncateInt64())
+	if step == 0 {
+		step = 1
+	}
+
+	var adjusted uint64
+	switch {
+	case raise:
+		adjusted = difficulty + step
+	case difficulty <= step:
+		adjusted = 1
+	default:
+		adjusted = difficulty - step
+	}
+
+	if adjusted > max {
+		return max
+	}
+	return adjusted
+}
@@ -0,0 +1,176 @@
+package pow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBeginBlockerDecay(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	keeper.SetLastDifficulty(ctx, 100)
+
+	last := uint64(100)
+	for height := int64(1); height <= 10; height++ {
+		BeginBlocker(ctx.WithBlockHeight(height), keeper)
+		difficulty, err := keeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		require.True(t, difficulty < last, "difficulty did not decrease at height %d", height)
+		last = difficulty
+	}
+
+	// difficulty never drops below one
+	for height := int64(11); height <= 100; height++ {
+		BeginBlocker(ctx.WithBlockHeight(height), keeper)
+	}
+	difficulty, err := keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), difficulty)
+}
+
+func TestBeginBlockerRaise(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	keeper.SetLastDifficulty(ctx, 10)
+
+	msg := GenerateMsgMine(sdk.AccAddress([]byte("sender")), 1, 10)
+	require.True(t, keeper.Handler(ctx, msg).IsOK())
+
+	BeginBlocker(ctx.WithBlockHeight(1), keeper)
+	difficulty, err := keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(11), difficulty)
+
+	// the next empty block lowers it again
+	BeginBlocker(ctx.WithBlockHeight(2), keeper)
+	difficulty, err = keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), difficulty)
+}
+
+func TestBeginBlockerMaxDifficulty(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+
+	powParams := keeper.GetParams(ctx)
+	powParams.MaxDifficulty = 20
+	keeper.SetParams(ctx, powParams)
+	keeper.SetLastDifficulty(ctx, 10)
+
+	// mine a solution every block until well past the ceiling
+	for height := int64(1); height <= 20; height++ {
+		difficulty, err := keeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		count, err := keeper.GetLastCount(ctx)
+		require.Nil(t, err)
+
+		msg := GenerateMsgMine(addr, count+1, difficulty)
+		require.True(t, keeper.Handler(ctx, msg).IsOK())
+		BeginBlocker(ctx.WithBlockHeight(height), keeper)
+
+		difficulty, err = keeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		require.True(t, difficulty <= 20, "difficulty %d above the ceiling at height %d", difficulty, height)
+	}
+
+	difficulty, err := keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(20), difficulty)
+}
+
+func TestStaleDifficultyRejected(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+	keeper.SetLastDifficulty(ctx, 10)
+
+	// work computed against the difficulty before the block adjusted it
+	stale := GenerateMsgMine(addr, 1, 10)
+	BeginBlocker(ctx.WithBlockHeight(1), keeper)
+
+	result := keeper.Handler(ctx, stale)
+	require.False(t, result.IsOK())
+	require.Equal(t, CodeInvalidDifficulty, result.Code)
+}
+
+func TestBeginBlockerRetargetInterval(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+
+	powParams := keeper.GetParams(ctx)
+	powParams.RetargetInterval = 5
+	powParams.TargetMined = 2
+	keeper.SetParams(ctx, powParams)
+	keeper.SetLastDifficulty(ctx, 100)
+
+	// heavy mining within the window leaves the difficulty unchanged
+	for height := int64(1); height < 5; height++ {
+		BeginBlocker(ctx.WithBlockHeight(height), keeper)
+		difficulty, err := keeper.GetLastDifficulty(ctx)
+		require.Nil(t, err)
+		require.Equal(t, uint64(100), difficulty, "difficulty changed at height %d", height)
+
+		count, err := keeper.GetLastCount(ctx)
+		require.Nil(t, err)
+		msg := GenerateMsgMine(addr, count+1, difficulty)
+		require.True(t, keeper.Handler(ctx, msg).IsOK())
+	}
+
+	// raised at the interval boundary
+	BeginBlocker(ctx.WithBlockHeight(5), keeper)
+	difficulty, err := keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(110), difficulty)
+
+	// the window count was reset, an empty window lowers it at the next boundary
+	for height := int64(6); height < 10; height++ {
+		BeginBlocker(ctx.WithBlockHeight(height), keeper)
+	}
+	difficulty, err = keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(110), difficulty)
+
+	BeginBlocker(ctx.WithBlockHeight(10), keeper)
+	difficulty, err = keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, uint64(99), difficulty)
+}
+
+func TestEndBlockerHalving(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	powParams := keeper.GetParams(ctx)
+	powParams.Reward = sdk.Coins{sdk.NewInt64Coin("pow", 100)}
+	powParams.HalvingInterval = 10
+	powParams.RewardFloor = sdk.Coins{sdk.NewInt64Coin("pow", 20)}
+	keeper.SetParams(ctx, powParams)
+
+	// the reward holds until the halving boundary
+	for height := int64(1); height < 10; height++ {
+		EndBlocker(ctx.WithBlockHeight(height), keeper)
+	}
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 100)}, keeper.GetReward(ctx))
+
+	EndBlocker(ctx.WithBlockHeight(10), keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 50)}, keeper.GetReward(ctx))
+	require.Equal(t, int64(10), keeper.GetLastHalvingHeight(ctx))
+
+	// miners get the halved reward
+	addr := sdk.AccAddress([]byte("sender"))
+	require.True(t, keeper.Handler(ctx, GenerateMsgMine(addr, 1, 1)).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 50)}, keeper.GetTotalMinted(ctx))
+
+	// later halvings stop at the floor
+	for height := int64(11); height <= 40; height++ {
+		EndBlocker(ctx.WithBlockHeight(height), keeper)
+	}
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 20)}, keeper.GetReward(ctx))
+	require.Equal(t, int64(40), keeper.GetLastHalvingHeight(ctx))
+
+	// the halving survives an export and import
+	genesis := ExportGenesis(ctx, keeper)
+	require.Equal(t, int64(40), genesis.LastHalvingHeight)
+	ctx2, _, keeper2 := createTestInput(t)
+	require.Nil(t, InitGenesis(ctx2, keeper2, genesis))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 20)}, keeper2.GetReward(ctx2))
+	require.Equal(t, int64(40), keeper2.GetLastHalvingHeight(ctx2))
+}
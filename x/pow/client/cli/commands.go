@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/pow"
+)
+
+const flagMaxIterations = "max-iterations"
+
+// MineCmd - command to mine some pow!
+func MineCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "mine [difficulty] [count] [nonce] [solution]",
+		Short: "Mine some coins with proof-of-work!",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			difficulty, err := strconv.ParseUint(args[0], 0, 64)
+			if err != nil {
+				return err
+			}
+
+			count, err := strconv.ParseUint(args[1], 0, 64)
+			if err != nil {
+				return err
+			}
+
+			nonce, err := strconv.ParseUint(args[2], 0, 64)
+			if err != nil {
+				return err
+			}
+
+			solution := []byte(args[3])
+			msg := pow.NewMsgMine(from, difficulty, count, nonce, solution)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdMine queries the current difficulty and count, searches for a valid
+// nonce locally and broadcasts the resulting proof
+func GetCmdMine(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mine",
+		Short: "Solve the current proof-of-work puzzle and submit the solution",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			difficulty, err := queryUint64(cliCtx, cdc, queryRoute, pow.QueryDifficulty)
+			if err != nil {
+				return err
+			}
+
+			count, err := queryUint64(cliCtx, cdc, queryRoute, pow.QueryCount)
+			if err != nil {
+				return err
+			}
+
+			maxIterations := viper.GetInt64(flagMaxIterations)
+			if maxIterations <= 0 {
+				return fmt.Errorf("--%s must be positive", flagMaxIterations)
+			}
+
+			nonce, proof, ok := pow.FindNonce(from, count+1, difficulty, uint64(maxIterations))
+			if !ok {
+				return fmt.Errorf("no solution found for difficulty %d within %d iterations", difficulty, maxIterations)
+			}
+			fmt.Printf("nonce: %d\nhash: %s\n", nonce, proof)
+
+			msg := pow.NewMsgMine(from, difficulty, count+1, nonce, proof)
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Int64(flagMaxIterations, 10000000, "maximum number of nonces to try")
+	return cmd
+}
+
+func queryUint64(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute, path string) (uint64, error) {
+	res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, path), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var value uint64
+	if err := cdc.UnmarshalJSON(res, &value); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
@@ -0,0 +1,82 @@
+package pow
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CodeType - reuse type
+type CodeType = sdk.CodeType
+
+// POW errors reserve 200 ~ 299. Clients may switch on the codes returned
+// in the "pow" codespace; malformed msgs and unknown requests keep the codes
+// of the sdk root codespace.
+const (
+	DefaultCodespace sdk.CodespaceType = "pow"
+
+	CodeInvalidDifficulty     CodeType = 201 // difficulty isn't the current one
+	CodeNonexistentDifficulty CodeType = 202 // no difficulty in the store
+	CodeNonexistentReward     CodeType = 203 // no reward in the store
+	CodeNonexistentCount      CodeType = 204 // no count in the store
+	CodeInvalidProof          CodeType = 205 // proof isn't the hash of the msg
+	CodeNotBelowTarget        CodeType = 206 // proof isn't below the target
+	CodeInvalidCount          CodeType = 207 // count isn't the next one
+	CodeUnknownRequest        CodeType = sdk.CodeUnknownRequest
+)
+
+func codeToDefaultMsg(code CodeType) string {
+	switch code {
+	case CodeInvalidDifficulty:
+		return "insufficient difficulty"
+	case CodeNonexistentDifficulty:
+		return "nonexistent difficulty"
+	case CodeNonexistentReward:
+		return "nonexistent reward"
+	case CodeNonexistentCount:
+		return "nonexistent count"
+	case CodeInvalidProof:
+		return "invalid proof"
+	case CodeNotBelowTarget:
+		return "not below target"
+	case CodeInvalidCount:
+		return "invalid count"
+	case CodeUnknownRequest:
+		return "unknown request"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+// nolint
+func ErrInvalidDifficulty(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidDifficulty, msg)
+}
+func ErrNonexistentDifficulty(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNonexistentDifficulty, "")
+}
+func ErrNonexistentReward(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNonexistentReward, "")
+}
+func ErrNonexistentCount(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNonexistentCount, "")
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrNotBelowTarget(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeNotBelowTarget, msg)
+}
+func ErrInvalidCount(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidCount, msg)
+}
+
+func msgOrDefaultMsg(msg string, code CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
@@ -0,0 +1,82 @@
+package pow
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis info must specify starting difficulty and starting count
+type Genesis struct {
+	Difficulty uint64 `json:"difficulty"`
+	Count      uint64 `json:"count"`
+	Params     Params `json:"params"`
+
+	// coins minted by mining so far
+	TotalMinted sdk.Coins `json:"total_minted"`
+
+	// height of the last halving and the reward it set, both unset
+	// until the first halving
+	Reward            sdk.Coins `json:"reward"`
+	LastHalvingHeight int64     `json:"last_halving_height"`
+
+	// solutions mined by each address
+	Miners []MinerCount `json:"miners"`
+}
+
+// DefaultGenesis returns the default genesis state for the POW module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		Difficulty:  1,
+		Count:       0,
+		Params:      DefaultParams(),
+		TotalMinted: sdk.Coins{},
+		Miners:      []MinerCount{},
+	}
+}
+
+// InitGenesis for the POW module
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	k.SetLastDifficulty(ctx, genesis.Difficulty)
+	k.SetLastCount(ctx, genesis.Count)
+	k.setWindowCount(ctx, 0)
+	k.SetParams(ctx, genesis.Params)
+	k.SetTotalMinted(ctx, genesis.TotalMinted)
+	if genesis.LastHalvingHeight > 0 {
+		k.SetReward(ctx, genesis.Reward)
+		k.SetLastHalvingHeight(ctx, genesis.LastHalvingHeight)
+	}
+	for _, mc := range genesis.Miners {
+		k.SetMinerCount(ctx, mc.Miner, mc.Count)
+	}
+	return nil
+}
+
+// ExportGenesis for the PoW module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	difficulty, err := k.GetLastDifficulty(ctx)
+	if err != nil {
+		panic(err)
+	}
+	count, err := k.GetLastCount(ctx)
+	if err != nil {
+		panic(err)
+	}
+	var reward sdk.Coins
+	lastHalving := k.GetLastHalvingHeight(ctx)
+	if lastHalving > 0 {
+		reward = k.GetReward(ctx)
+	}
+	miners := []MinerCount{}
+	k.IterateMinerCounts(ctx, func(mc MinerCount) bool {
+		miners = append(miners, mc)
+		return false
+	})
+	return Genesis{
+		Difficulty:        difficulty,
+		Count:             count,
+		Params:            k.GetParams(ctx),
+		TotalMinted:       k.GetTotalMinted(ctx),
+		Reward:            reward,
+		LastHalvingHeight: lastHalving,
+		Miners:            miners,
+	}
+}
@@ -0,0 +1,46 @@
+package pow
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handler handles pow messages
+func (k Keeper) Handler(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgMine:
+		return handleMsgMine(ctx, k, msg)
+	default:
+		errMsg := "Unrecognized pow Msg type: " + msg.Type()
+		return sdk.ErrUnknownRequest(errMsg).Result()
+	}
+}
+
+func handleMsgMine(ctx sdk.Context, k Keeper, msg MsgMine) sdk.Result {
+
+	// precondition: msg has passed ValidateBasic
+
+	ctx.GasMeter().ConsumeGas(verificationGas(k.GetParams(ctx).GasPerDifficulty, msg.Difficulty), "pow verification")
+
+	newCount, err := k.CheckValid(ctx, msg.Difficulty, msg.Count)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = k.ApplyValid(ctx, msg.Sender, newCount)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// verificationGas returns the gas charged for verifying a solution of the
+// given difficulty, saturating instead of overflowing
+func verificationGas(gasPerDifficulty, difficulty uint64) uint64 {
+	if gasPerDifficulty != 0 && difficulty > math.MaxUint64/gasPerDifficulty {
+		return math.MaxUint64
+	}
+	return gasPerDifficulty * difficulty
+}
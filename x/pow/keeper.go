@@ -0,0 +1,213 @@
+package pow
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Keeper of the pow store
+type Keeper struct {
+	key        sdk.StoreKey
+	ck         bank.Keeper
+	paramSpace params.Subspace
+	codespace  sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(key sdk.StoreKey, ck bank.Keeper, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		key:        key,
+		ck:         ck,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+}
+
+var (
+	difficultyKey  = []byte("difficulty")
+	countKey       = []byte("count")
+	totalMintedKey = []byte("totalMinted")
+	rewardKey      = []byte("reward")
+	lastHalvingKey = []byte("lastHalving")
+	minerKeyPrefix = []byte("miner/")
+)
+
+// MinerCount is the number of solutions mined by an address
+type MinerCount struct {
+	Miner sdk.AccAddress `json:"miner"`
+	Count uint64         `json:"count"`
+}
+
+func getMinerKey(addr sdk.AccAddress) []byte {
+	return append(minerKeyPrefix, addr.Bytes()...)
+}
+
+// GetLastDifficulty returns the current mining difficulty
+func (k Keeper) GetLastDifficulty(ctx sdk.Context) (uint64, error) {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(difficultyKey)
+	if stored == nil {
+		panic("no stored difficulty")
+	}
+	return strconv.ParseUint(string(stored), 0, 64)
+}
+
+// SetLastDifficulty sets the current mining difficulty
+func (k Keeper) SetLastDifficulty(ctx sdk.Context, diff uint64) {
+	store := ctx.KVStore(k.key)
+	store.Set(difficultyKey, []byte(strconv.FormatUint(diff, 10)))
+}
+
+// GetLastCount returns the number of solutions mined so far
+func (k Keeper) GetLastCount(ctx sdk.Context) (uint64, error) {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(countKey)
+	if stored == nil {
+		return 0, nil
+	}
+	return strconv.ParseUint(string(stored), 0, 64)
+}
+
+// SetLastCount sets the number of solutions mined so far
+func (k Keeper) SetLastCount(ctx sdk.Context, cnt uint64) {
+	store := ctx.KVStore(k.key)
+	store.Set(countKey, []byte(strconv.FormatUint(cnt, 10)))
+}
+
+// GetTotalMinted returns the coins minted by mining so far
+func (k Keeper) GetTotalMinted(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(totalMintedKey)
+	if len(stored) == 0 {
+		return sdk.Coins{}
+	}
+	total, err := sdk.ParseCoins(string(stored))
+	if err != nil {
+		panic(err)
+	}
+	return total
+}
+
+// SetTotalMinted sets the coins minted by mining so far
+func (k Keeper) SetTotalMinted(ctx sdk.Context, total sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	store.Set(totalMintedKey, []byte(total.String()))
+}
+
+// GetReward returns the coins minted for a valid solution, the Reward param
+// until the first halving
+func (k Keeper) GetReward(ctx sdk.Context) sdk.Coins {
+	if k.GetLastHalvingHeight(ctx) == 0 {
+		return k.GetParams(ctx).Reward
+	}
+	store := ctx.KVStore(k.key)
+	reward, err := sdk.ParseCoins(string(store.Get(rewardKey)))
+	if err != nil {
+		panic(err)
+	}
+	return reward
+}
+
+// SetReward sets the coins minted for a valid solution, which override the
+// Reward param once a halving height is set
+func (k Keeper) SetReward(ctx sdk.Context, reward sdk.Coins) {
+	store := ctx.KVStore(k.key)
+	store.Set(rewardKey, []byte(reward.String()))
+}
+
+// GetLastHalvingHeight returns the height the reward was last halved at
+func (k Keeper) GetLastHalvingHeight(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(lastHalvingKey)
+	if stored == nil {
+		return 0
+	}
+	height, err := strconv.ParseInt(string(stored), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return height
+}
+
+// SetLastHalvingHeight sets the height the reward was last halved at
+func (k Keeper) SetLastHalvingHeight(ctx sdk.Context, height int64) {
+	store := ctx.KVStore(k.key)
+	store.Set(lastHalvingKey, []byte(strconv.FormatInt(height, 10)))
+}
+
+// GetMinerCount returns the number of solutions mined by the address
+func (k Keeper) GetMinerCount(ctx sdk.Context, addr sdk.AccAddress) uint64 {
+	store := ctx.KVStore(k.key)
+	stored := store.Get(getMinerKey(addr))
+	if stored == nil {
+		return 0
+	}
+	cnt, err := strconv.ParseUint(string(stored), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return cnt
+}
+
+// SetMinerCount sets the number of solutions mined by the address
+func (k Keeper) SetMinerCount(ctx sdk.Context, addr sdk.AccAddress, cnt uint64) {
+	store := ctx.KVStore(k.key)
+	store.Set(getMinerKey(addr), []byte(strconv.FormatUint(cnt, 10)))
+}
+
+// IterateMinerCounts iterates over the addresses that mined, in address
+// order
+func (k Keeper) IterateMinerCounts(ctx sdk.Context, fn func(mc MinerCount) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, minerKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		cnt, err := strconv.ParseUint(string(iter.Value()), 10, 64)
+		if err != nil {
+			panic(err)
+		}
+		if fn(MinerCount{sdk.AccAddress(iter.Key()[len(minerKeyPrefix):]), cnt}) {
+			break
+		}
+	}
+}
+
+// CheckValid checks the mined solution against the keeper state
+func (k Keeper) CheckValid(ctx sdk.Context, difficulty uint64, count uint64) (uint64, sdk.Error) {
+	lastDifficulty, err := k.GetLastDifficulty(ctx)
+	if err != nil {
+		return 0, ErrNonexistentDifficulty(k.codespace)
+	}
+	if difficulty != lastDifficulty {
+		return 0, ErrInvalidDifficulty(k.codespace, fmt.Sprintf("invalid difficulty; expected: %d, actual: %d", lastDifficulty, difficulty))
+	}
+
+	lastCount, err := k.GetLastCount(ctx)
+	if err != nil {
+		return 0, ErrNonexistentCount(k.codespace)
+	}
+	newCount := lastCount + 1
+	if count != newCount {
+		return 0, ErrInvalidCount(k.codespace, fmt.Sprintf("invalid count: was %d, should have been %d", count, newCount))
+	}
+
+	return newCount, nil
+}
+
+// ApplyValid adds some coins for a POW well done
+func (k Keeper) ApplyValid(ctx sdk.Context, sender sdk.AccAddress, newCount uint64) sdk.Error {
+	reward := k.GetReward(ctx)
+	_, _, ckErr := k.ck.AddCoins(ctx, sender, reward)
+	if ckErr != nil {
+		return ckErr
+	}
+	k.SetLastCount(ctx, newCount)
+	k.SetMinerCount(ctx, sender, k.GetMinerCount(ctx, sender)+1)
+	k.setWindowCount(ctx, k.getWindowCount(ctx)+1)
+	k.SetTotalMinted(ctx, k.GetTotalMinted(ctx).Plus(reward))
+	return nil
+}
@@ -0,0 +1,149 @@
+package pow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyPow := sdk.NewKVStoreKey("pow")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyPow, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(ak)
+	keeper := NewKeeper(keyPow, ck, pk.Subspace(DefaultParamspace), DefaultCodespace)
+
+	err := InitGenesis(ctx, keeper, DefaultGenesis())
+	require.Nil(t, err)
+
+	return ctx, ak, keeper
+}
+
+func TestPowKeeperGetSet(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+
+	genesis := ExportGenesis(ctx, keeper)
+	require.Equal(t, DefaultGenesis(), genesis)
+
+	res, err := keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, res, uint64(1))
+
+	keeper.SetLastDifficulty(ctx, 2)
+
+	res, err = keeper.GetLastDifficulty(ctx)
+	require.Nil(t, err)
+	require.Equal(t, res, uint64(2))
+}
+
+func TestPowKeeperMine(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+
+	msg := GenerateMsgMine(addr, 1, 1)
+	require.Nil(t, msg.ValidateBasic())
+	result := keeper.Handler(ctx, msg)
+	require.True(t, result.IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("pow", 1)}, ak.GetAccount(ctx, addr).GetCoins())
+
+	// replaying the same solution is rejected
+	result = keeper.Handler(ctx, msg)
+	require.False(t, result.IsOK())
+}
+
+func TestPowKeeperReward(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+
+	powParams := keeper.GetParams(ctx)
+	powParams.Reward = sdk.Coins{sdk.NewInt64Coin("nugget", 5)}
+	keeper.SetParams(ctx, powParams)
+
+	msg := GenerateMsgMine(addr, 1, 1)
+	result := keeper.Handler(ctx, msg)
+	require.True(t, result.IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("nugget", 5)}, ak.GetAccount(ctx, addr).GetCoins())
+}
+
+func TestPowKeeperTotalMinted(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr1 := sdk.AccAddress([]byte("sender1"))
+	addr2 := sdk.AccAddress([]byte("sender2"))
+	querier := NewQuerier(keeper)
+
+	for count, addr := range []sdk.AccAddress{addr1, addr2, addr1} {
+		msg := GenerateMsgMine(addr, uint64(count+1), 1)
+		require.True(t, keeper.Handler(ctx, msg).IsOK())
+	}
+
+	expected := sdk.Coins{sdk.NewInt64Coin("pow", 3)}
+	require.Equal(t, expected, keeper.GetTotalMinted(ctx))
+	minted := ak.GetAccount(ctx, addr1).GetCoins().Plus(ak.GetAccount(ctx, addr2).GetCoins())
+	require.Equal(t, expected, minted)
+
+	bz, err := querier(ctx, []string{QueryTotalMinted}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var res sdk.Coins
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+	require.Equal(t, expected, res)
+
+	// the counter survives export and import
+	genesis := ExportGenesis(ctx, keeper)
+	require.Equal(t, expected, genesis.TotalMinted)
+	ctx2, _, keeper2 := createTestInput(t)
+	require.Nil(t, InitGenesis(ctx2, keeper2, genesis))
+	require.Equal(t, expected, keeper2.GetTotalMinted(ctx2))
+}
+
+func TestPowVerificationGas(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	addr := sdk.AccAddress([]byte("sender"))
+
+	gasUsed := func(count, difficulty uint64) uint64 {
+		keeper.SetLastDifficulty(ctx, difficulty)
+		gasCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		result := keeper.Handler(gasCtx, GenerateMsgMine(addr, count, difficulty))
+		require.True(t, result.IsOK(), result.Log)
+		return gasCtx.GasMeter().GasConsumed()
+	}
+
+	low := gasUsed(1, 1)
+	high := gasUsed(2, 50)
+	require.True(t, high > low, "gas used %d at difficulty 50, %d at difficulty 1", high, low)
+
+	// the multiplier is a param
+	powParams := keeper.GetParams(ctx)
+	powParams.GasPerDifficulty = 0
+	keeper.SetParams(ctx, powParams)
+	require.True(t, gasUsed(3, 50) < high)
+
+	require.Equal(t, uint64(math.MaxUint64), verificationGas(2, math.MaxUint64))
+}
@@ -0,0 +1,43 @@
+package pow
+
+import (
+	"math"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenerateMsgMine generates the mine message
+func GenerateMsgMine(sender sdk.AccAddress, count uint64, difficulty uint64) MsgMine {
+	nonce, hash := mine(sender, count, difficulty)
+	return NewMsgMine(sender, difficulty, count, nonce, hash)
+}
+
+func mine(sender sdk.AccAddress, count uint64, difficulty uint64) (uint64, []byte) {
+	nonce, hash, _ := FindNonce(sender, count, difficulty, math.MaxUint64)
+	return nonce, hash
+}
+
+// Target returns the value the proof of a solution of the given positive
+// difficulty must be below, the proof being read as a hex uint64
+func Target(difficulty uint64) uint64 {
+	return math.MaxUint64 / difficulty
+}
+
+// FindNonce searches for a nonce whose hash is below the target of the given
+// difficulty, trying at most maxIterations nonces. It returns false if no
+// solution was found within the limit.
+func FindNonce(sender sdk.AccAddress, count uint64, difficulty uint64, maxIterations uint64) (uint64, []byte, bool) {
+	target := Target(difficulty)
+	for nonce := uint64(0); nonce < maxIterations; nonce++ {
+		hash := hash(sender, count, nonce)
+		hashuint, err := strconv.ParseUint(string(hash), 16, 64)
+		if err != nil {
+			panic(err)
+		}
+		if hashuint < target {
+			return nonce, hash, true
+		}
+	}
+	return 0, nil, false
+}
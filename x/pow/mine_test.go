@@ -0,0 +1,27 @@
+package pow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFindNonce(t *testing.T) {
+	sender := sdk.AccAddress([]byte("sender"))
+
+	nonce, proof, ok := FindNonce(sender, 1, 10, 1000000)
+	require.True(t, ok)
+	msg := NewMsgMine(sender, 10, 1, nonce, proof)
+	require.Nil(t, msg.ValidateBasic())
+
+	// the first nonce found is the smallest valid one
+	_, _, ok = FindNonce(sender, 1, 10, nonce)
+	require.False(t, ok)
+
+	// no iterations never finds a solution
+	_, proof, ok = FindNonce(sender, 1, 1, 0)
+	require.False(t, ok)
+	require.Nil(t, proof)
+}
@@ -0,0 +1,87 @@
+package pow
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgMine - mine some coins with PoW
+type MsgMine struct {
+	Sender     sdk.AccAddress `json:"sender"`
+	Difficulty uint64         `json:"difficulty"`
+	Count      uint64         `json:"count"`
+	Nonce      uint64         `json:"nonce"`
+	Proof      []byte         `json:"proof"`
+}
+
+// enforce the msg type at compile time
+var _ sdk.Msg = MsgMine{}
+
+// NewMsgMine - construct mine message
+func NewMsgMine(sender sdk.AccAddress, difficulty uint64, count uint64, nonce uint64, proof []byte) MsgMine {
+	return MsgMine{sender, difficulty, count, nonce, proof}
+}
+
+// nolint
+func (msg MsgMine) Route() string                { return "pow" }
+func (msg MsgMine) Type() string                 { return "mine" }
+func (msg MsgMine) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Sender} }
+func (msg MsgMine) String() string {
+	return fmt.Sprintf("MsgMine{Sender: %s, Difficulty: %d, Count: %d, Nonce: %d, Proof: %s}", msg.Sender, msg.Difficulty, msg.Count, msg.Nonce, msg.Proof)
+}
+
+// ValidateBasic validates the mine message
+func (msg MsgMine) ValidateBasic() sdk.Error {
+	// check hash
+	// hash must include sender, so no other users can race the tx
+	// hash must include count so proof-of-work solutions cannot be replayed
+	hashHex := hash(msg.Sender, msg.Count, msg.Nonce)
+	if !bytes.Equal(hashHex, msg.Proof) {
+		return ErrInvalidProof(DefaultCodespace, fmt.Sprintf("hashHex: %s, proof: %s", hashHex, msg.Proof))
+	}
+
+	// check proof below difficulty
+	// difficulty is linear - 1 = all hashes, 2 = half of hashes, 3 = third of hashes, etc
+	if msg.Difficulty == 0 {
+		return ErrInvalidDifficulty(DefaultCodespace, "difficulty must be positive")
+	}
+	target := Target(msg.Difficulty)
+	hashUint, err := strconv.ParseUint(string(msg.Proof), 16, 64)
+	if err != nil {
+		return ErrInvalidProof(DefaultCodespace, fmt.Sprintf("proof: %s", msg.Proof))
+	}
+	if hashUint >= target {
+		return ErrNotBelowTarget(DefaultCodespace, fmt.Sprintf("hashuint: %d, target: %d", hashUint, target))
+	}
+
+	return nil
+}
+
+// GetSignBytes gets the mine message sign bytes
+func (msg MsgMine) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func hash(sender sdk.AccAddress, count uint64, nonce uint64) []byte {
+	var bz []byte
+	bz = append(bz, []byte(sender)...)
+	bz = append(bz, strconv.FormatUint(count, 16)...)
+	bz = append(bz, strconv.FormatUint(nonce, 16)...)
+	hash := crypto.Sha256(bz)
+	// uint64, so we just use the first 8 bytes of the hash
+	// this limits the range of possible difficulty values (as compared to uint256), but fine for proof-of-concept
+	ret := make([]byte, hex.EncodedLen(len(hash)))
+	hex.Encode(ret, hash)
+	return ret[:16]
+}
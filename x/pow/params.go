@@ -0,0 +1,115 @@
+package pow
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default pow module parameter subspace
+const DefaultParamspace = "pow"
+
+// Parameter store keys
+var (
+	KeyDecayRate        = []byte("DecayRate")
+	KeyReward           = []byte("Reward")
+	KeyMaxDifficulty    = []byte("MaxDifficulty")
+	KeyRetargetInterval = []byte("RetargetInterval")
+	KeyTargetMined      = []byte("TargetMined")
+	KeyGasPerDifficulty = []byte("GasPerDifficulty")
+	KeyHalvingInterval  = []byte("HalvingInterval")
+	KeyRewardFloor      = []byte("RewardFloor")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the pow module
+type Params struct {
+	// fraction of the difficulty added after a mined block
+	// and removed after an empty one
+	DecayRate sdk.Dec `json:"decay_rate"`
+
+	// coins minted for each valid solution until the first halving
+	Reward sdk.Coins `json:"reward"`
+
+	// ceiling the difficulty is never raised above, so new miners
+	// can always catch up
+	MaxDifficulty uint64 `json:"max_difficulty"`
+
+	// number of blocks between difficulty adjustments
+	RetargetInterval uint64 `json:"retarget_interval"`
+
+	// solutions per retarget interval above which the difficulty
+	// is raised, it is lowered otherwise
+	TargetMined uint64 `json:"target_mined"`
+
+	// gas charged per unit of difficulty for verifying a solution, so
+	// harder solutions cost more to submit
+	GasPerDifficulty uint64 `json:"gas_per_difficulty"`
+
+	// number of blocks between halvings of the reward, zero disables
+	// halving
+	HalvingInterval uint64 `json:"halving_interval"`
+
+	// amounts the reward is never halved below
+	RewardFloor sdk.Coins `json:"reward_floor"`
+}
+
+// ParamKeyTable for pow module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeyDecayRate, &p.DecayRate},
+		{KeyReward, &p.Reward},
+		{KeyMaxDifficulty, &p.MaxDifficulty},
+		{KeyRetargetInterval, &p.RetargetInterval},
+		{KeyTargetMined, &p.TargetMined},
+		{KeyGasPerDifficulty, &p.GasPerDifficulty},
+		{KeyHalvingInterval, &p.HalvingInterval},
+		{KeyRewardFloor, &p.RewardFloor},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		DecayRate:        sdk.NewDecWithPrec(1, 1), // 10%
+		Reward:           sdk.Coins{sdk.NewInt64Coin("pow", 1)},
+		MaxDifficulty:    1000000,
+		RetargetInterval: 1,
+		TargetMined:      0,
+		GasPerDifficulty: 10,
+		HalvingInterval:  0,
+		RewardFloor:      sdk.Coins{},
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Decay Rate:         %s
+  Reward:             %s
+  Max Difficulty:     %d
+  Retarget Interval:  %d
+  Target Mined:       %d
+  Gas Per Difficulty: %d
+  Halving Interval:   %d
+  Reward Floor:       %s`, p.DecayRate, p.Reward, p.MaxDifficulty, p.RetargetInterval, p.TargetMined,
+		p.GasPerDifficulty, p.HalvingInterval, p.RewardFloor)
+}
+
+// GetParams returns the current pow parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the pow parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,106 @@
+package pow
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the pow Querier
+const (
+	QuerierRoute     = "pow"
+	QueryDifficulty  = "difficulty"
+	QueryCount       = "count"
+	QueryTotalMinted = "total_minted"
+	QueryMiner       = "miner"
+	QueryTarget      = "target"
+)
+
+// MiningTarget is what the next solution must satisfy: a MsgMine with the
+// difficulty and count whose proof, the first 16 hex digits of its hash, is
+// below the target. The target is given as 16 hex digits as well, so miners
+// can compare proofs to it as strings.
+type MiningTarget struct {
+	Difficulty uint64 `json:"difficulty"`
+	Count      uint64 `json:"count"`
+	Target     string `json:"target"`
+}
+
+// NewQuerier returns a querier for the pow module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no pow query endpoint given")
+		}
+		switch path[0] {
+		case QueryDifficulty:
+			return queryDifficulty(ctx, k)
+		case QueryCount:
+			return queryCount(ctx, k)
+		case QueryTotalMinted:
+			return marshalResult(k.GetTotalMinted(ctx))
+		case QueryMiner:
+			return queryMiner(ctx, path[1:], k)
+		case QueryTarget:
+			return queryTarget(ctx, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown pow query endpoint")
+		}
+	}
+}
+
+func queryDifficulty(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	difficulty, err := k.GetLastDifficulty(ctx)
+	if err != nil {
+		return nil, ErrNonexistentDifficulty(k.codespace)
+	}
+	return marshalResult(difficulty)
+}
+
+func queryCount(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	count, err := k.GetLastCount(ctx)
+	if err != nil {
+		return nil, ErrNonexistentCount(k.codespace)
+	}
+	return marshalResult(count)
+}
+
+func queryTarget(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	difficulty, err := k.GetLastDifficulty(ctx)
+	if err != nil {
+		return nil, ErrNonexistentDifficulty(k.codespace)
+	}
+	count, err := k.GetLastCount(ctx)
+	if err != nil {
+		return nil, ErrNonexistentCount(k.codespace)
+	}
+	return marshalResult(MiningTarget{
+		Difficulty: difficulty,
+		Count:      count + 1,
+		Target:     fmt.Sprintf("%016x", Target(difficulty)),
+	})
+}
+
+// queryMiner returns the number of solutions mined by the bech32 address
+// given as the query path
+func queryMiner(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected a miner address")
+	}
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+	return marshalResult(k.GetMinerCount(ctx, addr))
+}
+
+func marshalResult(res interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, res)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
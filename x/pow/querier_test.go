@@ -0,0 +1,105 @@
+package pow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func queryUint64(t *testing.T, ctx sdk.Context, querier sdk.Querier, path string) uint64 {
+	bz, err := querier(ctx, []string{path}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var res uint64
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+	return res
+}
+
+func TestQuerier(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+
+	require.Equal(t, uint64(1), queryUint64(t, ctx, querier, QueryDifficulty))
+	require.Equal(t, uint64(0), queryUint64(t, ctx, querier, QueryCount))
+
+	msg := GenerateMsgMine(sdk.AccAddress([]byte("sender")), 1, 1)
+	require.True(t, keeper.Handler(ctx, msg).IsOK())
+	BeginBlocker(ctx.WithBlockHeight(1), keeper)
+
+	require.Equal(t, uint64(2), queryUint64(t, ctx, querier, QueryDifficulty))
+	require.Equal(t, uint64(1), queryUint64(t, ctx, querier, QueryCount))
+
+	_, err := querier(ctx, []string{"foo"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
+
+func TestQuerierMiner(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	addr1 := sdk.AccAddress([]byte("miner1"))
+	addr2 := sdk.AccAddress([]byte("miner2"))
+
+	for i, addr := range []sdk.AccAddress{addr1, addr2, addr1} {
+		msg := GenerateMsgMine(addr, uint64(i+1), 1)
+		require.True(t, keeper.Handler(ctx, msg).IsOK())
+	}
+
+	queryMiner := func(addr sdk.AccAddress) uint64 {
+		bz, err := querier(ctx, []string{QueryMiner, addr.String()}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var res uint64
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+		return res
+	}
+	require.Equal(t, uint64(2), queryMiner(addr1))
+	require.Equal(t, uint64(1), queryMiner(addr2))
+	require.Equal(t, uint64(0), queryMiner(sdk.AccAddress([]byte("miner3"))))
+
+	_, err := querier(ctx, []string{QueryMiner, "foo"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+
+	// the counts survive an export and import
+	genesis := ExportGenesis(ctx, keeper)
+	require.Equal(t, []MinerCount{{addr1, 2}, {addr2, 1}}, genesis.Miners)
+	ctx, _, keeper = createTestInput(t)
+	require.Nil(t, InitGenesis(ctx, keeper, genesis))
+	require.Equal(t, uint64(2), keeper.GetMinerCount(ctx, addr1))
+	require.Equal(t, uint64(1), keeper.GetMinerCount(ctx, addr2))
+}
+
+func TestQuerierTarget(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	sender := sdk.AccAddress([]byte("sender"))
+	keeper.SetLastDifficulty(ctx, 1000)
+
+	bz, err := querier(ctx, []string{QueryTarget}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var target MiningTarget
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &target))
+	require.Equal(t, uint64(1000), target.Difficulty)
+	require.Equal(t, uint64(1), target.Count)
+	require.Equal(t, "004189374bc6a7ef", target.Target)
+
+	// a proof below the target as a hex string is accepted by the handler
+	nonce, proof, found := FindNonce(sender, target.Count, target.Difficulty, 1000000)
+	require.True(t, found)
+	require.True(t, string(proof) < target.Target)
+	msg := NewMsgMine(sender, target.Difficulty, target.Count, nonce, proof)
+	require.Nil(t, msg.ValidateBasic())
+	require.True(t, keeper.Handler(ctx, msg).IsOK())
+
+	// and one that isn't below it is rejected
+	for nonce = 0; ; nonce++ {
+		proof = hash(sender, target.Count+1, nonce)
+		if string(proof) >= target.Target {
+			break
+		}
+	}
+	msg = NewMsgMine(sender, target.Difficulty, target.Count+1, nonce, proof)
+	require.Equal(t, CodeNotBelowTarget, msg.ValidateBasic().Code())
+}
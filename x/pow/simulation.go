@@ -0,0 +1,66 @@
+package pow
+
+import (
+	"fmt"
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SimulateMsgMine returns an operation that submits a randomly valid or
+// invalid solution to the keeper, the way the app would: ValidateBasic
+// first, then the handler. It fails if the outcome does not match the
+// kind of solution submitted.
+func SimulateMsgMine(k Keeper) func(r *rand.Rand, ctx sdk.Context, sender sdk.AccAddress) (action string, err error) {
+	return func(r *rand.Rand, ctx sdk.Context, sender sdk.AccAddress) (action string, err error) {
+		difficulty, err := k.GetLastDifficulty(ctx)
+		if err != nil {
+			return "", err
+		}
+		count, err := k.GetLastCount(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		var msg MsgMine
+		valid := false
+		switch r.Intn(4) {
+		case 0:
+			action = "mine/valid"
+			msg = GenerateMsgMine(sender, count+1, difficulty)
+			valid = true
+		case 1:
+			action = "mine/replayed-count"
+			msg = GenerateMsgMine(sender, count, difficulty)
+		case 2:
+			action = "mine/stale-difficulty"
+			msg = GenerateMsgMine(sender, count+1, difficulty+1+uint64(r.Intn(5)))
+		default:
+			action = "mine/bad-proof"
+			msg = GenerateMsgMine(sender, count+1, difficulty)
+			msg.Nonce++
+		}
+
+		ok := msg.ValidateBasic() == nil && k.Handler(ctx, msg).IsOK()
+		if ok != valid {
+			return action, fmt.Errorf("%s: expected success %t, got %t", action, valid, ok)
+		}
+		return action, nil
+	}
+}
+
+// DifficultyInvariant checks that the difficulty stays between one and the
+// max difficulty param
+func DifficultyInvariant(k Keeper) func(ctx sdk.Context) error {
+	return func(ctx sdk.Context) error {
+		difficulty, err := k.GetLastDifficulty(ctx)
+		if err != nil {
+			return err
+		}
+		max := k.GetParams(ctx).MaxDifficulty
+		if difficulty < 1 || difficulty > max {
+			return fmt.Errorf("difficulty %d out of range [1, %d]", difficulty, max)
+		}
+		return nil
+	}
+}
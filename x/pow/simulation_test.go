@@ -0,0 +1,55 @@
+package pow
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	simSeed       = 42
+	simNumOps     = 3000
+	simMaxOpBlock = 5
+)
+
+func TestSimulateMine(t *testing.T) {
+	ctx, _, keeper := createTestInput(t)
+	r := rand.New(rand.NewSource(simSeed))
+
+	// keep the ceiling low so mining stays cheap
+	powParams := keeper.GetParams(ctx)
+	powParams.MaxDifficulty = 64
+	keeper.SetParams(ctx, powParams)
+
+	senders := []sdk.AccAddress{
+		sdk.AccAddress([]byte("miner1")),
+		sdk.AccAddress([]byte("miner2")),
+		sdk.AccAddress([]byte("miner3")),
+	}
+	operation := SimulateMsgMine(keeper)
+	invariant := DifficultyInvariant(keeper)
+
+	actions := make(map[string]int)
+	height := int64(1)
+	for ops := 0; ops < simNumOps; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		BeginBlocker(ctx, keeper)
+		require.Nil(t, invariant(ctx), "height %d", height)
+
+		// some blocks are empty so the difficulty also decays
+		for i := r.Intn(simMaxOpBlock + 1); i > 0 && ops < simNumOps; i-- {
+			action, err := operation(r, ctx, senders[r.Intn(len(senders))])
+			require.Nil(t, err, "height %d", height)
+			actions[action]++
+			ops++
+		}
+		require.Nil(t, invariant(ctx), "height %d", height)
+	}
+
+	for _, action := range []string{"mine/valid", "mine/replayed-count", "mine/stale-difficulty", "mine/bad-proof"} {
+		require.NotZero(t, actions[action], action)
+	}
+}
@@ -0,0 +1,74 @@
+package simplestaking
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker slashes the validators that double signed, as reported by
+// Tendermint, and those that missed too many blocks in the signed blocks
+// window, which are also jailed. It returns the burned coins.
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) sdk.Coins {
+	burned := sdk.Coins{}
+	fraction := k.GetParams(ctx).SlashFractionDoubleSign
+
+	for _, evidence := range req.ByzantineValidators {
+		if evidence.Type != tmtypes.ABCIEvidenceTypeDuplicateVote {
+			continue
+		}
+
+		// the validator may have unbonded since the infraction
+		val, found := k.getValidatorByConsAddr(ctx, sdk.ConsAddress(evidence.Validator.Address))
+		if !found {
+			continue
+		}
+
+		slashed, err := k.Slash(ctx, val.Address, fraction)
+		if err != nil {
+			panic(err)
+		}
+		ctx.Logger().Info("slashed validator for double sign", "validator", val.Address, "amount", slashed)
+		burned = burned.Plus(slashed)
+	}
+
+	for _, vote := range req.LastCommitInfo.Votes {
+		val, found := k.getValidatorByConsAddr(ctx, sdk.ConsAddress(vote.Validator.Address))
+		if !found || val.Jailed {
+			continue
+		}
+		burned = burned.Plus(k.handleValidatorSignature(ctx, val.Address, vote.SignedLastBlock))
+	}
+
+	return burned
+}
+
+// EndBlocker releases the matured unbondings and, if validators changed
+// during the block, returns the changes to the active validator set and
+// clears the changed set. Validators leaving the active set are reported
+// with zero power so Tendermint removes them.
+func EndBlocker(ctx sdk.Context, k Keeper) abci.ResponseEndBlock {
+	k.releaseMatureUnbondings(ctx)
+
+	store := ctx.KVStore(k.key)
+
+	changed := false
+	iter := sdk.KVStorePrefixIterator(store, ChangedKeyPrefix)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		changed = true
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	if !changed {
+		return abci.ResponseEndBlock{}
+	}
+	return abci.ResponseEndBlock{
+		ValidatorUpdates: k.updateValidatorSet(ctx),
+	}
+}
@@ -0,0 +1,292 @@
+package simplestaking
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestEndBlockerValidatorUpdates(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	addr1, addr2 := fundedAddr(ctx, ak, 100), fundedAddr(ctx, ak, 100)
+	pk1, pk2 := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr1, pk1, testDescription, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr2, pk2, testDescription, sdk.NewInt64Coin(stakingToken, 20))).IsOK())
+
+	updates := EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 2)
+	powers := map[string]int64{}
+	for _, update := range updates {
+		pubKey, err := tmtypes.PB2TM.PubKey(update.PubKey)
+		require.Nil(t, err)
+		powers[string(pubKey.Bytes())] = update.Power
+	}
+	require.Equal(t, int64(10), powers[string(pk1.Bytes())])
+	require.Equal(t, int64(20), powers[string(pk2.Bytes())])
+
+	// the changed set is cleared after EndBlock
+	require.Empty(t, EndBlocker(ctx, keeper).ValidatorUpdates)
+
+	require.True(t, handler(ctx, NewMsgUnbond(addr1)).IsOK())
+
+	updates = EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 1)
+	require.Equal(t, tmtypes.TM2PB.PubKey(pk1), updates[0].PubKey)
+	require.Equal(t, int64(0), updates[0].Power)
+}
+
+func TestBeginBlockerSlashDoubleSign(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 100))).IsOK())
+	EndBlocker(ctx, keeper)
+
+	evidence := abci.Evidence{
+		Type:      tmtypes.ABCIEvidenceTypeDuplicateVote,
+		Validator: abci.Validator{Address: pubKey.Address(), Power: 100},
+	}
+	burned := BeginBlocker(ctx, abci.RequestBeginBlock{ByzantineValidators: []abci.Evidence{evidence}}, keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 5)}, burned)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 95)}, keeper.GetBondedCoins(ctx))
+
+	updates := EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 1)
+	require.Equal(t, tmtypes.TM2PB.PubKey(pubKey), updates[0].PubKey)
+	require.Equal(t, int64(95), updates[0].Power)
+
+	// evidence against unknown validators is ignored
+	evidence.Validator.Address = ed25519.GenPrivKey().PubKey().Address()
+	burned = BeginBlocker(ctx, abci.RequestBeginBlock{ByzantineValidators: []abci.Evidence{evidence}}, keeper)
+	require.True(t, burned.IsZero())
+}
+
+func TestBeginBlockerJailDowntime(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.SignedBlocksWindow = 10
+	stakingParams.MaxMissedBlocks = 5
+	stakingParams.SlashFractionDowntime = sdk.NewDecWithPrec(1, 1)
+	stakingParams.DowntimeJailDuration = 0
+	keeper.SetParams(ctx, stakingParams)
+
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 100))).IsOK())
+	EndBlocker(ctx, keeper)
+
+	commit := func(signed bool) abci.RequestBeginBlock {
+		return abci.RequestBeginBlock{LastCommitInfo: abci.LastCommitInfo{Votes: []abci.VoteInfo{{
+			Validator:       abci.Validator{Address: pubKey.Address(), Power: 100},
+			SignedLastBlock: signed,
+		}}}}
+	}
+
+	// missed blocks that leave the window are forgotten
+	for i := 0; i < 5; i++ {
+		require.True(t, BeginBlocker(ctx, commit(false), keeper).IsZero())
+	}
+	for i := 0; i < 10; i++ {
+		require.True(t, BeginBlocker(ctx, commit(true), keeper).IsZero())
+	}
+	require.Equal(t, int64(0), keeper.GetMissedBlocks(ctx, addr))
+
+	// missing up to the threshold is tolerated
+	for i := 0; i < 5; i++ {
+		require.True(t, BeginBlocker(ctx, commit(false), keeper).IsZero())
+	}
+	require.Equal(t, int64(5), keeper.GetMissedBlocks(ctx, addr))
+	require.Empty(t, EndBlocker(ctx, keeper).ValidatorUpdates)
+
+	// one more is slashed and jailed
+	burned := BeginBlocker(ctx, commit(false), keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 10)}, burned)
+	val, found := keeper.GetValidator(ctx, addr)
+	require.True(t, found)
+	require.True(t, val.Jailed)
+	require.Equal(t, int64(90), val.Power)
+	require.Equal(t, int64(0), keeper.GetMissedBlocks(ctx, addr))
+
+	updates := EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 1)
+	require.Equal(t, tmtypes.TM2PB.PubKey(pubKey), updates[0].PubKey)
+	require.Equal(t, int64(0), updates[0].Power)
+
+	// jailed validators are not tracked
+	require.True(t, BeginBlocker(ctx, commit(false), keeper).IsZero())
+	require.Equal(t, int64(0), keeper.GetMissedBlocks(ctx, addr))
+
+	// unjailing returns it to the validator set
+	require.True(t, handler(ctx, NewMsgUnjail(addr)).IsOK())
+	updates = EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 1)
+	require.Equal(t, int64(90), updates[0].Power)
+
+	res := handler(ctx, NewMsgUnjail(addr))
+	require.Equal(t, CodeValidatorNotJailed, res.Code)
+}
+
+func TestUnjailAfterJailPeriod(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.SignedBlocksWindow = 10
+	stakingParams.MaxMissedBlocks = 0
+	stakingParams.SlashFractionDowntime = sdk.NewDecWithPrec(1, 1)
+	stakingParams.DowntimeJailDuration = 20
+	keeper.SetParams(ctx, stakingParams)
+
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 100))).IsOK())
+	EndBlocker(ctx, keeper)
+
+	// a single missed block jails the validator at height 10
+	ctx = ctx.WithBlockHeight(10)
+	BeginBlocker(ctx, abci.RequestBeginBlock{LastCommitInfo: abci.LastCommitInfo{Votes: []abci.VoteInfo{{
+		Validator:       abci.Validator{Address: pubKey.Address(), Power: 100},
+		SignedLastBlock: false,
+	}}}}, keeper)
+	val, _ := keeper.GetValidator(ctx, addr)
+	require.True(t, val.Jailed)
+	require.Equal(t, int64(30), val.JailedUntil)
+	EndBlocker(ctx, keeper)
+
+	// unjailing before the jail period ends fails
+	ctx = ctx.WithBlockHeight(29)
+	res := handler(ctx, NewMsgUnjail(addr))
+	require.Equal(t, CodeValidatorJailed, res.Code)
+	require.Contains(t, res.Log, "jailed until height 30")
+	val, _ = keeper.GetValidator(ctx, addr)
+	require.True(t, val.Jailed)
+	require.Empty(t, EndBlocker(ctx, keeper).ValidatorUpdates)
+
+	// afterwards the validator is back with its slashed power
+	ctx = ctx.WithBlockHeight(30)
+	require.True(t, handler(ctx, NewMsgUnjail(addr)).IsOK())
+	val, _ = keeper.GetValidator(ctx, addr)
+	require.False(t, val.Jailed)
+	require.Equal(t, int64(90), val.Power)
+
+	updates := EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Len(t, updates, 1)
+	require.Equal(t, tmtypes.TM2PB.PubKey(pubKey), updates[0].PubKey)
+	require.Equal(t, int64(90), updates[0].Power)
+
+	// unknown validators can't unjail
+	res = handler(ctx, NewMsgUnjail(sdk.AccAddress([]byte("unknown"))))
+	require.Equal(t, CodeUnknownValidator, res.Code)
+}
+
+func TestEndBlockerMaxValidators(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.MaxValidators = 3
+	keeper.SetParams(ctx, stakingParams)
+
+	// bond N+2 validators with increasing power
+	var addrs []sdk.AccAddress
+	var pubKeys []crypto.PubKey
+	for i := 0; i < 5; i++ {
+		addr := fundedAddr(ctx, ak, 100)
+		pubKey := ed25519.GenPrivKey().PubKey()
+		stake := sdk.NewInt64Coin(stakingToken, int64(10*(i+1)))
+		require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, stake)).IsOK())
+		addrs = append(addrs, addr)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	powers := func(updates []abci.ValidatorUpdate) map[string]int64 {
+		res := map[string]int64{}
+		for _, update := range updates {
+			pubKey, err := tmtypes.PB2TM.PubKey(update.PubKey)
+			require.Nil(t, err)
+			res[string(pubKey.Bytes())] = update.Power
+		}
+		return res
+	}
+
+	// only the top three are active, the others stay bonded
+	require.Equal(t, map[string]int64{
+		string(pubKeys[4].Bytes()): 50,
+		string(pubKeys[3].Bytes()): 40,
+		string(pubKeys[2].Bytes()): 30,
+	}, powers(EndBlocker(ctx, keeper).ValidatorUpdates))
+	require.Len(t, keeper.GetActiveValidators(ctx), 3)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 150)}, keeper.GetBondedCoins(ctx))
+
+	// an inactive validator bonding more pushes the weakest active one out
+	require.True(t, handler(ctx, NewMsgBond(addrs[0], sdk.NewInt64Coin(stakingToken, 50), pubKeys[0])).IsOK())
+	require.Equal(t, map[string]int64{
+		string(pubKeys[0].Bytes()): 60,
+		string(pubKeys[2].Bytes()): 0,
+	}, powers(EndBlocker(ctx, keeper).ValidatorUpdates))
+
+	active := keeper.GetActiveValidators(ctx)
+	require.Len(t, active, 3)
+	require.Equal(t, addrs[0], active[0].Address)
+	require.Equal(t, addrs[4], active[1].Address)
+	require.Equal(t, addrs[3], active[2].Address)
+
+	// an inactive validator changing is not reported
+	require.True(t, handler(ctx, NewMsgBond(addrs[1], sdk.NewInt64Coin(stakingToken, 5), pubKeys[1])).IsOK())
+	require.Empty(t, EndBlocker(ctx, keeper).ValidatorUpdates)
+}
+
+func TestEqualPowerValidatorOrder(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.MaxValidators = 1
+	keeper.SetParams(ctx, stakingParams)
+
+	lo, hi := fundedAddr(ctx, ak, 100), fundedAddr(ctx, ak, 100)
+	if bytes.Compare(lo, hi) > 0 {
+		lo, hi = hi, lo
+	}
+	loPubKey, hiPubKey := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+
+	// the higher address bonds first, the tie still goes to the lower one
+	require.True(t, handler(ctx, NewMsgCreateValidator(hi, hiPubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	require.True(t, handler(ctx, NewMsgCreateValidator(lo, loPubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	updates := EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Equal(t, []abci.ValidatorUpdate{{PubKey: tmtypes.TM2PB.PubKey(loPubKey), Power: 10}}, updates)
+
+	// recomputing the set keeps it
+	for i := 0; i < 3; i++ {
+		keeper.setChanged(ctx, lo)
+		keeper.setChanged(ctx, hi)
+		require.Empty(t, EndBlocker(ctx, keeper).ValidatorUpdates)
+		active := keeper.GetActiveValidators(ctx)
+		require.Len(t, active, 1)
+		require.Equal(t, lo, active[0].Address)
+	}
+
+	// with room for both, the higher address joins after the lower one
+	stakingParams.MaxValidators = 2
+	keeper.SetParams(ctx, stakingParams)
+	keeper.setChanged(ctx, hi)
+	updates = EndBlocker(ctx, keeper).ValidatorUpdates
+	require.Equal(t, []abci.ValidatorUpdate{{PubKey: tmtypes.TM2PB.PubKey(hiPubKey), Power: 10}}, updates)
+	active := keeper.GetActiveValidators(ctx)
+	require.Equal(t, []sdk.AccAddress{lo, hi}, []sdk.AccAddress{active[0].Address, active[1].Address})
+}
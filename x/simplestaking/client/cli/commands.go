@@ -0,0 +1,423 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+
+	"github.com/cosmos/cosmos-sdk/docs/examples/democoin/x/simplestaking"
+)
+
+const (
+	flagStake         = "stake"
+	flagValidator     = "validator"
+	flagValidatorAddr = "validator-addr"
+	flagMoniker       = "moniker"
+	flagWebsite       = "website"
+	flagDetails       = "details"
+	flagPage          = "page"
+	flagLimit         = "limit"
+)
+
+// CreateValidatorTxCmd - create a validator at the sender address
+func CreateValidatorTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-validator",
+		Short: "Create a validator with its metadata and initial self bond",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			stake, pubKey, err := stakeAndPubKeyFlags()
+			if err != nil {
+				return err
+			}
+
+			desc := simplestaking.Description{
+				Moniker: viper.GetString(flagMoniker),
+				Website: viper.GetString(flagWebsite),
+				Details: viper.GetString(flagDetails),
+			}
+			msg := simplestaking.NewMsgCreateValidator(from, pubKey, desc, stake)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagStake, "", "Amount of coins to self bond")
+	cmd.Flags().String(flagValidator, "", "Hex encoded pubkey of the validator")
+	cmd.Flags().String(flagMoniker, "", "Name of the validator")
+	cmd.Flags().String(flagWebsite, "", "Website of the validator")
+	cmd.Flags().String(flagDetails, "", "Description of the validator")
+
+	return cmd
+}
+
+// EditValidatorTxCmd - replace the metadata of a validator
+func EditValidatorTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit-validator",
+		Short: "Replace the moniker, website and details of a validator owned by the sender",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := validatorAddrFlag()
+			if err != nil {
+				return err
+			}
+
+			desc := simplestaking.Description{
+				Moniker: viper.GetString(flagMoniker),
+				Website: viper.GetString(flagWebsite),
+				Details: viper.GetString(flagDetails),
+			}
+			msg := simplestaking.NewMsgEditValidator(from, desc)
+			if valAddr != nil {
+				msg = simplestaking.MsgEditValidator{ValidatorAddr: valAddr, Description: desc, Owner: from}
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagValidatorAddr, "", "Address of a validator owned by the sender, defaults to the sender")
+	cmd.Flags().String(flagMoniker, "", "Name of the validator")
+	cmd.Flags().String(flagWebsite, "", "Website of the validator")
+	cmd.Flags().String(flagDetails, "", "Description of the validator")
+
+	return cmd
+}
+
+// BondTxCmd - simple bond tx
+func BondTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bond",
+		Short: "Bond more stake to an existing validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			stake, pubKey, err := stakeAndPubKeyFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := simplestaking.NewMsgBond(from, stake, pubKey)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagStake, "", "Amount of coins to stake")
+	cmd.Flags().String(flagValidator, "", "Validator address to stake")
+
+	return cmd
+}
+
+// UnbondTxCmd - simple unbond tx
+func UnbondTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbond",
+		Short: "Unbond from a validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := validatorAddrFlag()
+			if err != nil {
+				return err
+			}
+
+			msg := simplestaking.NewMsgUnbond(from)
+			if valAddr != nil {
+				msg = simplestaking.MsgUnbond{Address: valAddr, Owner: from}
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagValidatorAddr, "", "Address of a validator owned by the sender, defaults to the sender")
+
+	return cmd
+}
+
+// UnjailTxCmd - unjail a validator jailed for downtime
+func UnjailTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unjail",
+		Short: "Return a validator jailed for downtime to the validator set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := validatorAddrFlag()
+			if err != nil {
+				return err
+			}
+
+			msg := simplestaking.NewMsgUnjail(from)
+			if valAddr != nil {
+				msg = simplestaking.MsgUnjail{ValidatorAddr: valAddr, Owner: from}
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagValidatorAddr, "", "Address of a validator owned by the sender, defaults to the sender")
+
+	return cmd
+}
+
+// TransferValidatorOwnershipTxCmd - hand control of a validator to a new owner
+func TransferValidatorOwnershipTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-validator-ownership [new-owner]",
+		Short: "Hand control of a validator owned by the sender to a new owner",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			newOwner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := validatorAddrFlag()
+			if err != nil {
+				return err
+			}
+			if valAddr == nil {
+				valAddr = from
+			}
+
+			msg := simplestaking.NewMsgTransferValidatorOwnership(valAddr, from, newOwner)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(os.Stdout, txBldr, cliCtx, []sdk.Msg{msg}, true)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagValidatorAddr, "", "Address of a validator owned by the sender, defaults to the sender")
+
+	return cmd
+}
+
+// stakeAndPubKeyFlags parses the --stake and --validator flags
+func stakeAndPubKeyFlags() (stake sdk.Coin, pubKey crypto.PubKey, err error) {
+	stakeString := viper.GetString(flagStake)
+	if len(stakeString) == 0 {
+		return stake, nil, fmt.Errorf("specify coins to bond with --stake")
+	}
+
+	valString := viper.GetString(flagValidator)
+	if len(valString) == 0 {
+		return stake, nil, fmt.Errorf("specify pubkey to bond to with --validator")
+	}
+
+	stake, err = sdk.ParseCoin(stakeString)
+	if err != nil {
+		return stake, nil, err
+	}
+
+	// TODO: bech32 ...
+	rawPubKey, err := hex.DecodeString(valString)
+	if err != nil {
+		return stake, nil, err
+	}
+	var pubKeyEd ed25519.PubKeyEd25519
+	copy(pubKeyEd[:], rawPubKey)
+	return stake, pubKeyEd, nil
+}
+
+// validatorAddrFlag parses the --validator-addr flag, nil if unset
+func validatorAddrFlag() (sdk.AccAddress, error) {
+	valString := viper.GetString(flagValidatorAddr)
+	if len(valString) == 0 {
+		return nil, nil
+	}
+	return sdk.AccAddressFromBech32(valString)
+}
+
+// GetCmdQueryUnbonding queries the pending unbondings of an address.
+func GetCmdQueryUnbonding(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unbonding [address]",
+		Short: "Query the pending unbondings of an address and their completion heights",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, simplestaking.QueryUnbonding, addr)
+			res, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			var entries []simplestaking.UnbondingEntry
+			cdc.MustUnmarshalJSON(res, &entries)
+			out, err := codec.MarshalJSONIndent(cdc, entries)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryValidator queries the validator bonded by an address.
+func GetCmdQueryValidator(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validator [address]",
+		Short: "Query the power, bonded coins and pubkey of a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s/%s", queryRoute, simplestaking.QueryValidator, addr)
+			res, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryValidators queries a page of the bonded validators.
+func GetCmdQueryValidators(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validators",
+		Short: "Query the bonded validators by power, a page at a time",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := simplestaking.QueryValidatorsParams{
+				Page:  viper.GetInt(flagPage),
+				Limit: viper.GetInt(flagLimit),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, simplestaking.QueryValidators)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().Int(flagPage, 1, "Page of validators to return, starting at 1")
+	cmd.Flags().Int(flagLimit, simplestaking.DefaultValidatorsLimit, "Number of validators per page")
+	return cmd
+}
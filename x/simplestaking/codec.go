@@ -0,0 +1,17 @@
+package simplestaking
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on the codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreateValidator{}, "simplestaking/CreateValidatorMsg", nil)
+	cdc.RegisterConcrete(MsgBond{}, "simplestaking/BondMsg", nil)
+	cdc.RegisterConcrete(MsgUnbond{}, "simplestaking/UnbondMsg", nil)
+	cdc.RegisterConcrete(MsgDelegateMulti{}, "simplestaking/DelegateMultiMsg", nil)
+	cdc.RegisterConcrete(MsgRedelegate{}, "simplestaking/RedelegateMsg", nil)
+	cdc.RegisterConcrete(MsgUnjail{}, "simplestaking/UnjailMsg", nil)
+	cdc.RegisterConcrete(MsgTransferValidatorOwnership{}, "simplestaking/TransferValidatorOwnershipMsg", nil)
+	cdc.RegisterConcrete(MsgEditValidator{}, "simplestaking/EditValidatorMsg", nil)
+}
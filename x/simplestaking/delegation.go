@@ -0,0 +1,190 @@
+package simplestaking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// delegation is the shares of a validator's bond held by a delegator
+type delegation struct {
+	address sdk.AccAddress
+	shares  int64
+}
+
+func (k Keeper) getDelegationShares(ctx sdk.Context, valAddr, delAddr sdk.AccAddress) int64 {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetDelegationKey(valAddr, delAddr))
+	if bz == nil {
+		return 0
+	}
+	var shares int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &shares)
+	return shares
+}
+
+func (k Keeper) setDelegationShares(ctx sdk.Context, valAddr, delAddr sdk.AccAddress, shares int64) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetDelegationKey(valAddr, delAddr), k.cdc.MustMarshalBinaryLengthPrefixed(shares))
+}
+
+// removeDelegations deletes and returns the delegations to a validator
+func (k Keeper) removeDelegations(ctx sdk.Context, valAddr sdk.AccAddress) []delegation {
+	store := ctx.KVStore(k.key)
+	prefix := GetDelegationsKey(valAddr)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+
+	var dels []delegation
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var shares int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &shares)
+		dels = append(dels, delegation{sdk.AccAddress(iter.Key()[len(prefix):]), shares})
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return dels
+}
+
+// iterateDelegations iterates over the delegations to every validator, in
+// validator then delegator address order
+func (k Keeper) iterateDelegations(ctx sdk.Context, fn func(valAddr sdk.AccAddress, del delegation) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, DelegationKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var shares int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &shares)
+		key := iter.Key()[len(DelegationKey):]
+		valAddr := sdk.AccAddress(key[:sdk.AddrLen])
+		if fn(valAddr, delegation{sdk.AccAddress(key[sdk.AddrLen:]), shares}) {
+			break
+		}
+	}
+}
+
+// GetDelegation returns the stake the delegator holds in the validator's
+// bond
+func (k Keeper) GetDelegation(ctx sdk.Context, valAddr, delAddr sdk.AccAddress) int64 {
+	bi := k.getBondInfo(ctx, valAddr)
+	if bi.isEmpty() {
+		return 0
+	}
+	return bi.powerOf(k.getDelegationShares(ctx, valAddr, delAddr))
+}
+
+// GetDelegatorDelegations returns the stake the delegator holds in the bond
+// of each validator, in validator address order
+func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delAddr sdk.AccAddress) []DelegationEntry {
+	entries := []DelegationEntry{}
+	k.IterateValidators(ctx, func(val Validator) bool {
+		if power := k.GetDelegation(ctx, val.Address, delAddr); power > 0 {
+			entries = append(entries, DelegationEntry{val.Address, sdk.NewInt64Coin(stakingToken, power)})
+		}
+		return false
+	})
+	return entries
+}
+
+// Delegate adds the delegator's stake to the bond of an existing validator.
+// The stake is returned to the delegator when the validator unbonds.
+func (k Keeper) Delegate(ctx sdk.Context, delAddr, valAddr sdk.AccAddress, stake sdk.Coin) (int64, sdk.Error) {
+	if stake.Denom != stakingToken {
+		return 0, ErrIncorrectStakingToken(k.codespace)
+	}
+
+	bi := k.getBondInfo(ctx, valAddr)
+	if bi.isEmpty() {
+		return 0, ErrUnknownValidator(k.codespace)
+	}
+	if err := k.checkBondLimits(ctx, valAddr, stake); err != nil {
+		return 0, err
+	}
+
+	_, _, err := k.ck.SubtractCoins(ctx, delAddr, []sdk.Coin{stake})
+	if err != nil {
+		return 0, err
+	}
+
+	shares := bi.sharesFor(stake.Amount.Int64())
+	bi.Shares = bi.Shares + shares
+	bi.Power = bi.Power + stake.Amount.Int64()
+
+	k.setDelegationShares(ctx, valAddr, delAddr, k.getDelegationShares(ctx, valAddr, delAddr)+shares)
+	k.setBondInfo(ctx, valAddr, bi)
+	k.setChanged(ctx, valAddr)
+	return bi.Power, nil
+}
+
+// getRedelegationCompletion returns the height at which the last
+// redelegation of the delegator completes, zero if it never redelegated
+func (k Keeper) getRedelegationCompletion(ctx sdk.Context, delAddr sdk.AccAddress) int64 {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetRedelegationKey(delAddr))
+	if bz == nil {
+		return 0
+	}
+	var height int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &height)
+	return height
+}
+
+func (k Keeper) setRedelegationCompletion(ctx sdk.Context, delAddr sdk.AccAddress, height int64) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetRedelegationKey(delAddr), k.cdc.MustMarshalBinaryLengthPrefixed(height))
+}
+
+// Redelegate moves the delegator's stake from the bond of one validator to
+// another without waiting out the unbonding time. The delegator may not
+// redelegate again until the unbonding time has passed, so stake cannot hop
+// between validators to dodge slashing.
+func (k Keeper) Redelegate(ctx sdk.Context, delAddr, srcAddr, dstAddr sdk.AccAddress, stake sdk.Coin) sdk.Error {
+	if stake.Denom != stakingToken {
+		return ErrIncorrectStakingToken(k.codespace)
+	}
+	if srcAddr.Equals(dstAddr) {
+		return ErrSelfRedelegation(k.codespace)
+	}
+	if until := k.getRedelegationCompletion(ctx, delAddr); ctx.BlockHeight() < until {
+		return ErrRedelegationInProgress(k.codespace, until)
+	}
+
+	src := k.getBondInfo(ctx, srcAddr)
+	dst := k.getBondInfo(ctx, dstAddr)
+	if src.isEmpty() || dst.isEmpty() {
+		return ErrUnknownValidator(k.codespace)
+	}
+	if err := k.checkBondLimits(ctx, dstAddr, stake); err != nil {
+		return err
+	}
+
+	amount := stake.Amount.Int64()
+	delShares := k.getDelegationShares(ctx, srcAddr, delAddr)
+	if amount > src.powerOf(delShares) {
+		return ErrInsufficientDelegation(k.codespace, srcAddr, stake)
+	}
+
+	// the last of the delegation takes all the remaining shares so no
+	// rounding dust is left behind
+	srcShares := src.sharesFor(amount)
+	if amount == src.powerOf(delShares) {
+		srcShares = delShares
+	}
+	src.Shares = src.Shares - srcShares
+	src.Power = src.Power - amount
+	k.setDelegationShares(ctx, srcAddr, delAddr, delShares-srcShares)
+	k.setBondInfo(ctx, srcAddr, src)
+	k.setChanged(ctx, srcAddr)
+
+	dstShares := dst.sharesFor(amount)
+	dst.Shares = dst.Shares + dstShares
+	dst.Power = dst.Power + amount
+	k.setDelegationShares(ctx, dstAddr, delAddr, k.getDelegationShares(ctx, dstAddr, delAddr)+dstShares)
+	k.setBondInfo(ctx, dstAddr, dst)
+	k.setChanged(ctx, dstAddr)
+
+	k.setRedelegationCompletion(ctx, delAddr, ctx.BlockHeight()+k.GetParams(ctx).UnbondingTime)
+	return nil
+}
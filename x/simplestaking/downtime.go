@@ -0,0 +1,120 @@
+package simplestaking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (k Keeper) getSigningInfo(ctx sdk.Context, addr sdk.AccAddress) (info signingInfo) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetSigningInfoKey(addr))
+	if bz == nil {
+		return signingInfo{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &info)
+	return info
+}
+
+func (k Keeper) setSigningInfo(ctx sdk.Context, addr sdk.AccAddress, info signingInfo) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetSigningInfoKey(addr), k.cdc.MustMarshalBinaryLengthPrefixed(info))
+}
+
+// deleteSigningInfo forgets the blocks missed by the validator
+func (k Keeper) deleteSigningInfo(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.key)
+	store.Delete(GetSigningInfoKey(addr))
+
+	iter := sdk.KVStorePrefixIterator(store, GetMissedBlocksKey(addr))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetMissedBlocks returns the number of blocks the validator missed in the
+// signed blocks window
+func (k Keeper) GetMissedBlocks(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	return k.getSigningInfo(ctx, addr).MissedBlocks
+}
+
+// handleValidatorSignature records whether the validator signed the last
+// block. Once it missed more than MaxMissedBlocks in the window it is
+// slashed and jailed, and the burned coins are returned.
+func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr sdk.AccAddress, signed bool) sdk.Coins {
+	params := k.GetParams(ctx)
+	if params.SignedBlocksWindow <= 0 {
+		return sdk.Coins{}
+	}
+
+	store := ctx.KVStore(k.key)
+	info := k.getSigningInfo(ctx, addr)
+
+	// the window is a ring buffer, the oldest block is replaced
+	key := GetMissedBlockKey(addr, info.IndexOffset%params.SignedBlocksWindow)
+	info.IndexOffset++
+
+	missedBefore := store.Has(key)
+	switch {
+	case !missedBefore && !signed:
+		store.Set(key, []byte{0x01})
+		info.MissedBlocks++
+	case missedBefore && signed:
+		store.Delete(key)
+		info.MissedBlocks--
+	}
+
+	if info.MissedBlocks <= params.MaxMissedBlocks {
+		k.setSigningInfo(ctx, addr, info)
+		return sdk.Coins{}
+	}
+
+	slashed, err := k.Slash(ctx, addr, params.SlashFractionDowntime)
+	if err != nil {
+		panic(err)
+	}
+	ctx.Logger().Info("slashed and jailed validator for downtime", "validator", addr,
+		"missed", info.MissedBlocks, "amount", slashed)
+
+	// the validator starts over with a clean window once unjailed
+	k.deleteSigningInfo(ctx, addr)
+	k.jail(ctx, addr)
+	return slashed
+}
+
+// jail removes the validator from the validator set for the downtime jail
+// duration while keeping its bond
+func (k Keeper) jail(ctx sdk.Context, addr sdk.AccAddress) {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return
+	}
+	bi.Jailed = true
+	bi.JailedUntil = ctx.BlockHeight() + k.GetParams(ctx).DowntimeJailDuration
+	k.setBondInfo(ctx, addr, bi)
+	k.setChanged(ctx, addr)
+}
+
+// Unjail returns a jailed validator to the validator set with the power left
+// after slashing, once its jail period has elapsed
+func (k Keeper) Unjail(ctx sdk.Context, addr sdk.AccAddress) sdk.Error {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return ErrUnknownValidator(k.codespace)
+	}
+	if !bi.Jailed {
+		return ErrValidatorNotJailed(k.codespace)
+	}
+	if ctx.BlockHeight() < bi.JailedUntil {
+		return ErrValidatorJailed(k.codespace, bi.JailedUntil)
+	}
+	bi.Jailed = false
+	bi.JailedUntil = 0
+	k.setBondInfo(ctx, addr, bi)
+	k.setChanged(ctx, addr)
+	return nil
+}
@@ -0,0 +1,94 @@
+package simplestaking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// simple stake errors reserve 300 ~ 399. Clients may switch on the codes
+// returned in the "simplestaking" codespace; malformed addresses and unknown
+// requests keep the codes of the sdk root codespace.
+const (
+	DefaultCodespace sdk.CodespaceType = "simplestaking"
+
+	// simplestake errors reserve 300 - 399.
+	CodeEmpty                  sdk.CodeType = 300 // validator has no bond
+	CodeInvalidUnbond          sdk.CodeType = 301 // nothing to unbond
+	CodeEmptyStake             sdk.CodeType = 302 // stake is zero
+	CodeIncorrectStakingToken  sdk.CodeType = 303 // stake isn't the bond denom
+	CodeUnknownValidator       sdk.CodeType = 304 // validator doesn't exist
+	CodeDuplicateValidator     sdk.CodeType = 305 // validator already exists
+	CodeValidatorNotJailed     sdk.CodeType = 306 // unjailing a free validator
+	CodeValidatorJailed        sdk.CodeType = 307 // validator still jailed
+	CodeNotValidatorOwner      sdk.CodeType = 308 // sender doesn't own the validator
+	CodeBondTooSmall           sdk.CodeType = 309 // below the minimum self bond
+	CodeBondTooLarge           sdk.CodeType = 310 // above the maximum power
+	CodeRedelegationInProgress sdk.CodeType = 311 // stake still redelegating
+	CodeSelfRedelegation       sdk.CodeType = 312 // same source and destination
+	CodeInvalidDescription     sdk.CodeType = 313 // description fails validation
+	CodeInsufficientDelegation sdk.CodeType = 314 // delegation smaller than the stake
+	CodeEmptyPubKey            sdk.CodeType = 315 // validator pubkey missing
+	CodeStakeOverflow          sdk.CodeType = 316 // stake overflows the bond
+)
+
+// nolint
+func ErrIncorrectStakingToken(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeIncorrectStakingToken, "")
+}
+func ErrEmptyValidator(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeEmpty, "")
+}
+func ErrInvalidUnbond(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidUnbond, "")
+}
+func ErrEmptyStake(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeEmptyStake, "")
+}
+func ErrUnknownValidator(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeUnknownValidator, "")
+}
+func ErrDuplicateValidator(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeDuplicateValidator, "")
+}
+func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeValidatorNotJailed, "")
+}
+func ErrValidatorJailed(codespace sdk.CodespaceType, until int64) sdk.Error {
+	return newError(codespace, CodeValidatorJailed, fmt.Sprintf("validator is jailed until height %d", until))
+}
+func ErrNotValidatorOwner(codespace sdk.CodespaceType, owner sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeNotValidatorOwner, fmt.Sprintf("validator is owned by %s", owner))
+}
+func ErrBondTooSmall(codespace sdk.CodespaceType, min int64) sdk.Error {
+	return newError(codespace, CodeBondTooSmall, fmt.Sprintf("new validators must bond at least %d", min))
+}
+func ErrBondTooLarge(codespace sdk.CodespaceType, max int64) sdk.Error {
+	return newError(codespace, CodeBondTooLarge, fmt.Sprintf("validator power may not exceed %d", max))
+}
+func ErrRedelegationInProgress(codespace sdk.CodespaceType, until int64) sdk.Error {
+	return newError(codespace, CodeRedelegationInProgress, fmt.Sprintf("redelegation in progress until height %d", until))
+}
+func ErrSelfRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeSelfRedelegation, "source and destination validators must differ")
+}
+func ErrInvalidDescription(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidDescription, msg)
+}
+func ErrInsufficientDelegation(codespace sdk.CodespaceType, validator sdk.AccAddress, stake sdk.Coin) sdk.Error {
+	return newError(codespace, CodeInsufficientDelegation, fmt.Sprintf("delegation to %s is less than %s", validator, stake))
+}
+func ErrEmptyPubKey(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeEmptyPubKey, msg)
+}
+func ErrStakeOverflow(codespace sdk.CodespaceType, stake sdk.Coin) sdk.Error {
+	return newError(codespace, CodeStakeOverflow, fmt.Sprintf("stake %s overflows the validator's bond", stake))
+}
+
+// -----------------------------
+// Helpers
+
+// nolint: unparam
+func newError(codespace sdk.CodespaceType, code sdk.CodeType, msg string) sdk.Error {
+	return sdk.NewError(codespace, code, msg)
+}
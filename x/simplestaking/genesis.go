@@ -0,0 +1,135 @@
+package simplestaking
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Genesis - genesis state of the simplestaking module
+type Genesis struct {
+	Params      Params              `json:"params"`
+	Bonds       []GenesisBond       `json:"bonds"`
+	Delegations []GenesisDelegation `json:"delegations"`
+	Unbondings  []UnbondingEntry    `json:"unbondings"`
+	LastPowers  []GenesisLastPower  `json:"last_powers"`
+}
+
+// GenesisBond is the bond of a validator, with its jail state, and the
+// shares issued for it
+type GenesisBond struct {
+	Validator
+	Shares int64 `json:"shares"`
+}
+
+// GenesisDelegation is the shares of a validator's bond held by a delegator
+type GenesisDelegation struct {
+	Validator sdk.AccAddress `json:"validator"`
+	Delegator sdk.AccAddress `json:"delegator"`
+	Shares    int64          `json:"shares"`
+}
+
+// GenesisLastPower is a validator as last reported to Tendermint
+type GenesisLastPower struct {
+	Address sdk.AccAddress `json:"address"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
+	Power   int64          `json:"power"`
+}
+
+// DefaultGenesis returns the default genesis state for the simplestaking module
+func DefaultGenesis() Genesis {
+	return Genesis{
+		Params:      DefaultParams(),
+		Bonds:       []GenesisBond{},
+		Delegations: []GenesisDelegation{},
+		Unbondings:  []UnbondingEntry{},
+		LastPowers:  []GenesisLastPower{},
+	}
+}
+
+// ValidateGenesis checks the bonds and rejects duplicate bonds and
+// delegations to validators without a bond
+func ValidateGenesis(genesis Genesis) error {
+	bonded := make(map[string]bool)
+	for _, bond := range genesis.Bonds {
+		if bond.PubKey == nil {
+			return fmt.Errorf("missing pubkey for validator %s", bond.Address)
+		}
+		if bond.Power <= 0 || bond.Shares <= 0 {
+			return fmt.Errorf("non-positive power or shares for validator %s", bond.Address)
+		}
+		if bonded[bond.Address.String()] {
+			return fmt.Errorf("duplicate bond for validator %s", bond.Address)
+		}
+		bonded[bond.Address.String()] = true
+	}
+	for _, del := range genesis.Delegations {
+		if !bonded[del.Validator.String()] {
+			return fmt.Errorf("delegation of %s to unbonded validator %s", del.Delegator, del.Validator)
+		}
+		if del.Shares <= 0 {
+			return fmt.Errorf("non-positive shares delegated by %s to %s", del.Delegator, del.Validator)
+		}
+	}
+	for _, entry := range genesis.Unbondings {
+		if entry.Amount.Denom != stakingToken || !entry.Amount.IsPositive() {
+			return fmt.Errorf("invalid unbonding of %s: %s", entry.Address, entry.Amount)
+		}
+	}
+	return nil
+}
+
+// InitGenesis for the simplestaking module. The bonded and unbonding coins
+// are not held by any account, the caller adds them to the total supply.
+func InitGenesis(ctx sdk.Context, k Keeper, genesis Genesis) error {
+	if err := ValidateGenesis(genesis); err != nil {
+		return err
+	}
+	k.SetParams(ctx, genesis.Params)
+	for _, bond := range genesis.Bonds {
+		k.setBondInfo(ctx, bond.Address, bondInfo{
+			PubKey:      bond.PubKey,
+			Power:       bond.Power,
+			Shares:      bond.Shares,
+			Jailed:      bond.Jailed,
+			JailedUntil: bond.JailedUntil,
+			Owner:       bond.Owner,
+			Description: bond.Description,
+		})
+	}
+	for _, del := range genesis.Delegations {
+		k.setDelegationShares(ctx, del.Validator, del.Delegator, del.Shares)
+	}
+	for _, entry := range genesis.Unbondings {
+		k.queueUnbonding(ctx, entry.Address, entry.Amount, entry.CompletionHeight)
+	}
+	for _, lp := range genesis.LastPowers {
+		k.setLastValidator(ctx, lp.Address, lastValidator{lp.PubKey, lp.Power})
+	}
+	return nil
+}
+
+// ExportGenesis for the simplestaking module
+func ExportGenesis(ctx sdk.Context, k Keeper) Genesis {
+	genesis := DefaultGenesis()
+	genesis.Params = k.GetParams(ctx)
+	k.IterateValidators(ctx, func(val Validator) bool {
+		genesis.Bonds = append(genesis.Bonds, GenesisBond{val, k.getBondInfo(ctx, val.Address).Shares})
+		return false
+	})
+	k.iterateDelegations(ctx, func(valAddr sdk.AccAddress, del delegation) bool {
+		genesis.Delegations = append(genesis.Delegations, GenesisDelegation{valAddr, del.address, del.shares})
+		return false
+	})
+	k.IterateUnbondings(ctx, func(entry UnbondingEntry) bool {
+		genesis.Unbondings = append(genesis.Unbondings, entry)
+		return false
+	})
+	k.iterateLastValidators(ctx, func(addr sdk.AccAddress, lv lastValidator) bool {
+		genesis.LastPowers = append(genesis.LastPowers, GenesisLastPower{addr, lv.PubKey, lv.Power})
+		return false
+	})
+	return genesis
+}
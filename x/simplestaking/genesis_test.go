@@ -0,0 +1,70 @@
+package simplestaking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	val1 := fundedAddr(ctx, ak, 100)
+	val2 := fundedAddr(ctx, ak, 100)
+	leaving := fundedAddr(ctx, ak, 100)
+	del := fundedAddr(ctx, ak, 100)
+
+	for _, addr := range []sdk.AccAddress{val1, val2, leaving} {
+		require.Nil(t, keeper.CreateValidator(ctx, addr, ed25519.GenPrivKey().PubKey(), testDescription,
+			sdk.NewInt64Coin(stakingToken, 10)))
+	}
+	_, err := keeper.Delegate(ctx, del, val1, sdk.NewInt64Coin(stakingToken, 5))
+	require.Nil(t, err)
+	EndBlocker(ctx, keeper)
+
+	// one validator is jailed, another is unbonding
+	bi := keeper.getBondInfo(ctx, val2)
+	bi.Jailed, bi.JailedUntil = true, 42
+	keeper.setBondInfo(ctx, val2, bi)
+	_, _, err = keeper.Unbond(ctx, leaving)
+	require.Nil(t, err)
+
+	exported := ExportGenesis(ctx, keeper)
+	require.Len(t, exported.Bonds, 2)
+	require.Len(t, exported.Delegations, 1)
+	require.Len(t, exported.Unbondings, 1)
+	require.Len(t, exported.LastPowers, 3)
+	require.Nil(t, ValidateGenesis(exported))
+
+	// the imported state matches the exported one
+	ctx2, _, keeper2 := createTestInput(t)
+	require.Nil(t, InitGenesis(ctx2, keeper2, exported))
+	require.Equal(t, exported, ExportGenesis(ctx2, keeper2))
+	require.Equal(t, keeper.GetBondedCoins(ctx), keeper2.GetBondedCoins(ctx2))
+	require.Equal(t, keeper.GetUnbondingCoins(ctx), keeper2.GetUnbondingCoins(ctx2))
+	require.Equal(t, int64(5), keeper2.GetDelegation(ctx2, val1, del))
+
+	val, found := keeper2.GetValidator(ctx2, val2)
+	require.True(t, found)
+	require.True(t, val.Jailed)
+	require.Equal(t, int64(42), val.JailedUntil)
+
+	// the active validator is unchanged, the jailed and the unbonded ones
+	// are removed from the validator set
+	keeper2.setChanged(ctx2, val1)
+	res := EndBlocker(ctx2, keeper2)
+	require.Len(t, res.ValidatorUpdates, 2)
+	for _, update := range res.ValidatorUpdates {
+		require.Equal(t, int64(0), update.Power)
+	}
+
+	// invalid bonds are rejected
+	invalid := ExportGenesis(ctx, keeper)
+	invalid.Bonds = append(invalid.Bonds, invalid.Bonds[0])
+	require.NotNil(t, ValidateGenesis(invalid))
+	invalid = ExportGenesis(ctx, keeper)
+	invalid.Delegations[0].Validator = leaving
+	require.NotNil(t, ValidateGenesis(invalid))
+}
@@ -0,0 +1,137 @@
+package simplestaking
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "simplestaking" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgCreateValidator:
+			return handleMsgCreateValidator(ctx, k, msg)
+		case MsgBond:
+			return handleMsgBond(ctx, k, msg)
+		case MsgUnbond:
+			return handleMsgUnbond(ctx, k, msg)
+		case MsgDelegateMulti:
+			return handleMsgDelegateMulti(ctx, k, msg)
+		case MsgRedelegate:
+			return handleMsgRedelegate(ctx, k, msg)
+		case MsgUnjail:
+			return handleMsgUnjail(ctx, k, msg)
+		case MsgTransferValidatorOwnership:
+			return handleMsgTransferValidatorOwnership(ctx, k, msg)
+		case MsgEditValidator:
+			return handleMsgEditValidator(ctx, k, msg)
+		default:
+			return sdk.ErrUnknownRequest("No match for message type.").Result()
+		}
+	}
+}
+
+func handleMsgCreateValidator(ctx sdk.Context, k Keeper, msg MsgCreateValidator) sdk.Result {
+	err := k.CreateValidator(ctx, msg.Operator, msg.PubKey, msg.Description, msg.InitialSelfBond)
+	if err != nil {
+		return err.Result()
+	}
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+// handleMsgBond adds stake to an existing validator, validators are created
+// by MsgCreateValidator
+func handleMsgBond(ctx sdk.Context, k Keeper, msg MsgBond) sdk.Result {
+	if _, found := k.GetValidator(ctx, msg.Address); !found {
+		return ErrUnknownValidator(k.codespace).Result()
+	}
+	if err := k.checkOwner(ctx, msg.Address, msg.signer()); err != nil {
+		return err.Result()
+	}
+	if err := k.checkBondLimits(ctx, msg.Address, msg.Stake); err != nil {
+		return err.Result()
+	}
+
+	_, err := k.Bond(ctx, msg.Address, msg.PubKey, msg.Stake)
+	if err != nil {
+		return err.Result()
+	}
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+func handleMsgUnbond(ctx sdk.Context, k Keeper, msg MsgUnbond) sdk.Result {
+	if err := k.checkOwner(ctx, msg.Address, msg.signer()); err != nil {
+		return err.Result()
+	}
+
+	_, _, err := k.Unbond(ctx, msg.Address)
+	if err != nil {
+		return err.Result()
+	}
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+// handleMsgDelegateMulti delegates to every validator in a cached context
+// that is only written once all the delegations succeeded
+func handleMsgDelegateMulti(ctx sdk.Context, k Keeper, msg MsgDelegateMulti) sdk.Result {
+	cacheCtx, write := ctx.CacheContext()
+	for _, entry := range msg.Entries {
+		_, err := k.Delegate(cacheCtx, msg.Delegator, entry.Validator, entry.Amount)
+		if err != nil {
+			return err.Result()
+		}
+	}
+	write()
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+func handleMsgRedelegate(ctx sdk.Context, k Keeper, msg MsgRedelegate) sdk.Result {
+	err := k.Redelegate(ctx, msg.Delegator, msg.Src, msg.Dst, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+func handleMsgUnjail(ctx sdk.Context, k Keeper, msg MsgUnjail) sdk.Result {
+	if err := k.checkOwner(ctx, msg.ValidatorAddr, msg.signer()); err != nil {
+		return err.Result()
+	}
+
+	err := k.Unjail(ctx, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	// validator set changes are returned in EndBlocker
+	return sdk.Result{}
+}
+
+func handleMsgTransferValidatorOwnership(ctx sdk.Context, k Keeper, msg MsgTransferValidatorOwnership) sdk.Result {
+	err := k.TransferOwnership(ctx, msg.ValidatorAddr, msg.signer(), msg.NewOwner)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+func handleMsgEditValidator(ctx sdk.Context, k Keeper, msg MsgEditValidator) sdk.Result {
+	if err := k.checkOwner(ctx, msg.ValidatorAddr, msg.signer()); err != nil {
+		return err.Result()
+	}
+
+	err := k.EditValidator(ctx, msg.ValidatorAddr, msg.Description)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
@@ -0,0 +1,365 @@
+package simplestaking
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const stakingToken = "steak"
+
+// Keeper - simple stake keeper
+type Keeper struct {
+	ck bank.Keeper
+
+	key        sdk.StoreKey
+	cdc        *codec.Codec
+	paramSpace params.Subspace
+	codespace  sdk.CodespaceType
+}
+
+// NewKeeper constructs a new keeper
+func NewKeeper(key sdk.StoreKey, bankKeeper bank.Keeper, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	return Keeper{
+		key:        key,
+		cdc:        cdc,
+		ck:         bankKeeper,
+		paramSpace: paramSpace.WithKeyTable(ParamKeyTable()),
+		codespace:  codespace,
+	}
+}
+
+func (k Keeper) getBondInfo(ctx sdk.Context, addr sdk.AccAddress) bondInfo {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetBondInfoKey(addr))
+	if bz == nil {
+		return bondInfo{}
+	}
+	var bi bondInfo
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &bi)
+	return bi
+}
+
+// setBondInfo stores the bond along with the index of the validator by the
+// consensus address of its pubkey
+func (k Keeper) setBondInfo(ctx sdk.Context, addr sdk.AccAddress, bi bondInfo) {
+	store := ctx.KVStore(k.key)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(bi)
+	store.Set(GetBondInfoKey(addr), bz)
+	store.Set(GetConsAddrKey(sdk.ConsAddress(bi.PubKey.Address())), addr.Bytes())
+}
+
+func (k Keeper) deleteBondInfo(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.key)
+	if bi := k.getBondInfo(ctx, addr); !bi.isEmpty() {
+		store.Delete(GetConsAddrKey(sdk.ConsAddress(bi.PubKey.Address())))
+	}
+	store.Delete(GetBondInfoKey(addr))
+}
+
+// IterateValidators iterates over the bonded validators in address order
+func (k Keeper) IterateValidators(ctx sdk.Context, fn func(val Validator) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, BondInfoKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var bi bondInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &bi)
+		addr := sdk.AccAddress(iter.Key()[len(BondInfoKeyPrefix):])
+		val := Validator{
+			Address:     addr,
+			PubKey:      bi.PubKey,
+			Power:       bi.Power,
+			Jailed:      bi.Jailed,
+			JailedUntil: bi.JailedUntil,
+			Owner:       bi.ownerOf(addr),
+			Description: bi.Description,
+		}
+		if fn(val) {
+			break
+		}
+	}
+}
+
+// GetValidator returns the validator bonded by the address
+func (k Keeper) GetValidator(ctx sdk.Context, addr sdk.AccAddress) (Validator, bool) {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return Validator{}, false
+	}
+	return Validator{addr, bi.PubKey, bi.Power, bi.Jailed, bi.JailedUntil, bi.ownerOf(addr), bi.Description}, true
+}
+
+// GetOwner returns the account controlling the validator at addr. Addresses
+// without a validator are their own owner.
+func (k Keeper) GetOwner(ctx sdk.Context, addr sdk.AccAddress) sdk.AccAddress {
+	return k.getBondInfo(ctx, addr).ownerOf(addr)
+}
+
+// checkOwner returns an error unless signer controls the validator at addr
+func (k Keeper) checkOwner(ctx sdk.Context, addr, signer sdk.AccAddress) sdk.Error {
+	owner := k.GetOwner(ctx, addr)
+	if !owner.Equals(signer) {
+		return ErrNotValidatorOwner(k.codespace, owner)
+	}
+	return nil
+}
+
+// TransferOwnership hands control of the validator at addr from its current
+// owner to newOwner
+func (k Keeper) TransferOwnership(ctx sdk.Context, addr, owner, newOwner sdk.AccAddress) sdk.Error {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return ErrUnknownValidator(k.codespace)
+	}
+	if err := k.checkOwner(ctx, addr, owner); err != nil {
+		return err
+	}
+	bi.Owner = newOwner
+	k.setBondInfo(ctx, addr, bi)
+	return nil
+}
+
+// EditValidator replaces the metadata of the validator at addr
+func (k Keeper) EditValidator(ctx sdk.Context, addr sdk.AccAddress, desc Description) sdk.Error {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return ErrUnknownValidator(k.codespace)
+	}
+	bi.Description = desc
+	k.setBondInfo(ctx, addr, bi)
+	return nil
+}
+
+// GetBondedCoins returns the total amount of coins bonded to validators
+func (k Keeper) GetBondedCoins(ctx sdk.Context) sdk.Coins {
+	power := int64(0)
+	k.IterateValidators(ctx, func(val Validator) bool {
+		power += val.Power
+		return false
+	})
+	if power == 0 {
+		return sdk.Coins{}
+	}
+	return sdk.Coins{sdk.NewInt64Coin(stakingToken, power)}
+}
+
+// setChanged marks the validator as changed in the current block, so the
+// validator set is recomputed in EndBlocker
+func (k Keeper) setChanged(ctx sdk.Context, addr sdk.AccAddress) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetChangedKey(addr), []byte{0x01})
+}
+
+// Bond registers a bond with the keeper, the stake is paid by the owner of
+// the validator
+func (k Keeper) Bond(ctx sdk.Context, addr sdk.AccAddress, pubKey crypto.PubKey, stake sdk.Coin) (int64, sdk.Error) {
+	if stake.Denom != stakingToken {
+		return 0, ErrIncorrectStakingToken(k.codespace)
+	}
+
+	bi := k.getBondInfo(ctx, addr)
+	if !bi.fits(stake) {
+		return 0, ErrStakeOverflow(k.codespace, stake)
+	}
+	_, _, err := k.ck.SubtractCoins(ctx, bi.ownerOf(addr), []sdk.Coin{stake})
+	if err != nil {
+		return 0, err
+	}
+
+	if bi.isEmpty() {
+		bi = bondInfo{
+			PubKey: pubKey,
+			Power:  0,
+		}
+	}
+
+	bi.Shares = bi.Shares + bi.sharesFor(stake.Amount.Int64())
+	bi.Power = bi.Power + stake.Amount.Int64()
+
+	k.setBondInfo(ctx, addr, bi)
+	k.setChanged(ctx, addr)
+	return bi.Power, nil
+}
+
+// CreateValidator registers a new validator with its metadata, self bonding
+// stake paid by addr
+func (k Keeper) CreateValidator(ctx sdk.Context, addr sdk.AccAddress, pubKey crypto.PubKey, desc Description,
+	stake sdk.Coin) sdk.Error {
+	if !k.getBondInfo(ctx, addr).isEmpty() {
+		return ErrDuplicateValidator(k.codespace)
+	}
+	if _, found := k.getValidatorByConsAddr(ctx, sdk.ConsAddress(pubKey.Address())); found {
+		return ErrDuplicateValidator(k.codespace)
+	}
+	if err := k.checkBondLimits(ctx, addr, stake); err != nil {
+		return err
+	}
+	if _, err := k.Bond(ctx, addr, pubKey, stake); err != nil {
+		return err
+	}
+
+	bi := k.getBondInfo(ctx, addr)
+	bi.Description = desc
+	k.setBondInfo(ctx, addr, bi)
+	return nil
+}
+
+// checkBondLimits returns an error if the stake would overflow the bond of
+// a validator, create a validator bonding less than MinSelfBond or push a
+// validator's power above MaxBond
+func (k Keeper) checkBondLimits(ctx sdk.Context, addr sdk.AccAddress, stake sdk.Coin) sdk.Error {
+	params := k.GetParams(ctx)
+	bi := k.getBondInfo(ctx, addr)
+	if !bi.fits(stake) {
+		return ErrStakeOverflow(k.codespace, stake)
+	}
+	amount := stake.Amount.Int64()
+	if bi.isEmpty() && amount < params.MinSelfBond {
+		return ErrBondTooSmall(k.codespace, params.MinSelfBond)
+	}
+	if params.MaxBond > 0 && bi.Power+amount > params.MaxBond {
+		return ErrBondTooLarge(k.codespace, params.MaxBond)
+	}
+	return nil
+}
+
+// Unbond registers an unbond with the keeper. The stake of the validator
+// and of its delegators is returned once the unbonding time has passed, the
+// validator's own stake to its owner.
+func (k Keeper) Unbond(ctx sdk.Context, addr sdk.AccAddress) (crypto.PubKey, int64, sdk.Error) {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return nil, 0, ErrInvalidUnbond(k.codespace)
+	}
+	k.deleteBondInfo(ctx, addr)
+	k.deleteSigningInfo(ctx, addr)
+	k.setChanged(ctx, addr)
+
+	completionHeight := ctx.BlockHeight() + k.GetParams(ctx).UnbondingTime
+	selfPower := bi.Power
+	for _, del := range k.removeDelegations(ctx, addr) {
+		power := bi.powerOf(del.shares)
+		if power > 0 {
+			k.queueUnbonding(ctx, del.address, sdk.NewInt64Coin(stakingToken, power), completionHeight)
+		}
+		selfPower -= power
+	}
+	if selfPower > 0 {
+		k.queueUnbonding(ctx, bi.ownerOf(addr), sdk.NewInt64Coin(stakingToken, selfPower), completionHeight)
+	}
+
+	return bi.PubKey, bi.Power, nil
+}
+
+// queueUnbonding adds the stake to the unbondings of the address completing
+// at the given height
+func (k Keeper) queueUnbonding(ctx sdk.Context, addr sdk.AccAddress, amount sdk.Coin, completionHeight int64) {
+	store := ctx.KVStore(k.key)
+	key := GetUnbondingKey(completionHeight, addr)
+
+	entry := UnbondingEntry{addr, amount, completionHeight}
+	if bz := store.Get(key); bz != nil {
+		var queued UnbondingEntry
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &queued)
+		entry.Amount = queued.Amount.Plus(amount)
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(entry))
+}
+
+// IterateUnbondings iterates over the pending unbondings in completion order
+func (k Keeper) IterateUnbondings(ctx sdk.Context, fn func(entry UnbondingEntry) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, UnbondingQueueKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var entry UnbondingEntry
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &entry)
+		if fn(entry) {
+			break
+		}
+	}
+}
+
+// GetUnbondings returns the pending unbondings of an address
+func (k Keeper) GetUnbondings(ctx sdk.Context, addr sdk.AccAddress) []UnbondingEntry {
+	entries := []UnbondingEntry{}
+	k.IterateUnbondings(ctx, func(entry UnbondingEntry) bool {
+		if entry.Address.Equals(addr) {
+			entries = append(entries, entry)
+		}
+		return false
+	})
+	return entries
+}
+
+// GetUnbondingCoins returns the total amount of coins waiting to be unbonded
+func (k Keeper) GetUnbondingCoins(ctx sdk.Context) sdk.Coins {
+	total := sdk.Coins{}
+	k.IterateUnbondings(ctx, func(entry UnbondingEntry) bool {
+		total = total.Plus(sdk.Coins{entry.Amount})
+		return false
+	})
+	return total
+}
+
+// releaseMatureUnbondings returns the stake of all unbondings completed at
+// or before the current height to their owners
+func (k Keeper) releaseMatureUnbondings(ctx sdk.Context) {
+	store := ctx.KVStore(k.key)
+	end := sdk.PrefixEndBytes(GetUnbondingHeightKey(ctx.BlockHeight()))
+	iter := store.Iterator(UnbondingQueueKey, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var entry UnbondingEntry
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &entry)
+
+		_, _, err := k.ck.AddCoins(ctx, entry.Address, sdk.Coins{entry.Amount})
+		if err != nil {
+			panic(err)
+		}
+		store.Delete(iter.Key())
+	}
+}
+
+// Slash burns a fraction of the validator's bond. The validator is removed
+// once its power drops to zero. The burned coins are returned.
+func (k Keeper) Slash(ctx sdk.Context, addr sdk.AccAddress, fraction sdk.Dec) (sdk.Coins, sdk.Error) {
+	bi := k.getBondInfo(ctx, addr)
+	if bi.isEmpty() {
+		return nil, ErrUnknownValidator(k.codespace)
+	}
+
+	slashed := sdk.NewDec(bi.Power).Mul(fraction).TruncateInt64()
+	if slashed == 0 {
+		return sdk.Coins{}, nil
+	}
+
+	bi.Power = bi.Power - slashed
+	if bi.Power <= 0 {
+		k.deleteBondInfo(ctx, addr)
+		k.deleteSigningInfo(ctx, addr)
+		k.removeDelegations(ctx, addr)
+	} else {
+		k.setBondInfo(ctx, addr, bi)
+	}
+	k.setChanged(ctx, addr)
+
+	return sdk.Coins{sdk.NewInt64Coin(stakingToken, slashed)}, nil
+}
+
+// getValidatorByConsAddr returns the bonded validator whose pubkey has the
+// given consensus address
+func (k Keeper) getValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) (Validator, bool) {
+	store := ctx.KVStore(k.key)
+	bz := store.Get(GetConsAddrKey(consAddr))
+	if bz == nil {
+		return Validator{}, false
+	}
+	return k.GetValidator(ctx, sdk.AccAddress(bz))
+}
@@ -0,0 +1,91 @@
+package simplestaking
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Keys for simplestaking store
+var (
+	BondInfoKeyPrefix = []byte{0x00}
+	ChangedKeyPrefix  = []byte{0x01}
+	UnbondingQueueKey = []byte{0x02}
+	DelegationKey     = []byte{0x03}
+	SigningInfoKey    = []byte{0x04}
+	MissedBlockKey    = []byte{0x05}
+	LastPowerKey      = []byte{0x06}
+	RedelegationKey   = []byte{0x07}
+	ConsAddrKey       = []byte{0x08}
+)
+
+// GetBondInfoKey returns the key for the bond of an address
+func GetBondInfoKey(addr sdk.AccAddress) []byte {
+	return append(BondInfoKeyPrefix, addr.Bytes()...)
+}
+
+// GetChangedKey returns the key marking the validator of an address as changed
+func GetChangedKey(addr sdk.AccAddress) []byte {
+	return append(ChangedKeyPrefix, addr.Bytes()...)
+}
+
+// GetUnbondingHeightKey returns the prefix of the unbondings completing at
+// the given height
+func GetUnbondingHeightKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(UnbondingQueueKey, bz...)
+}
+
+// GetUnbondingKey returns the key of the unbonding of an address completing
+// at the given height
+func GetUnbondingKey(height int64, addr sdk.AccAddress) []byte {
+	return append(GetUnbondingHeightKey(height), addr.Bytes()...)
+}
+
+// GetDelegationsKey returns the prefix of the delegations to a validator
+func GetDelegationsKey(valAddr sdk.AccAddress) []byte {
+	return append(DelegationKey, valAddr.Bytes()...)
+}
+
+// GetDelegationKey returns the key of the shares delegated by an address to
+// a validator
+func GetDelegationKey(valAddr, delAddr sdk.AccAddress) []byte {
+	return append(GetDelegationsKey(valAddr), delAddr.Bytes()...)
+}
+
+// GetSigningInfoKey returns the key of the signing info of a validator
+func GetSigningInfoKey(valAddr sdk.AccAddress) []byte {
+	return append(SigningInfoKey, valAddr.Bytes()...)
+}
+
+// GetMissedBlocksKey returns the prefix of the missed blocks of a validator
+func GetMissedBlocksKey(valAddr sdk.AccAddress) []byte {
+	return append(MissedBlockKey, valAddr.Bytes()...)
+}
+
+// GetMissedBlockKey returns the key marking a block missed by a validator at
+// the given index of the signed blocks window
+func GetMissedBlockKey(valAddr sdk.AccAddress, index int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(index))
+	return append(GetMissedBlocksKey(valAddr), bz...)
+}
+
+// GetLastPowerKey returns the key of the power last reported to Tendermint
+// for a validator
+func GetLastPowerKey(valAddr sdk.AccAddress) []byte {
+	return append(LastPowerKey, valAddr.Bytes()...)
+}
+
+// GetRedelegationKey returns the key of the height at which the last
+// redelegation of a delegator completes
+func GetRedelegationKey(delAddr sdk.AccAddress) []byte {
+	return append(RedelegationKey, delAddr.Bytes()...)
+}
+
+// GetConsAddrKey returns the key of the address of the validator bonded with
+// the pubkey of a consensus address
+func GetConsAddrKey(consAddr sdk.ConsAddress) []byte {
+	return append(ConsAddrKey, consAddr.Bytes()...)
+}
@@ -0,0 +1,456 @@
+package simplestaking
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyStake := sdk.NewKVStoreKey("simplestaking")
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyStake, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.Nil(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(ak)
+	keeper := NewKeeper(keyStake, ck, pk.Subspace(DefaultParamspace), DefaultCodespace)
+
+	err := InitGenesis(ctx, keeper, DefaultGenesis())
+	require.Nil(t, err)
+
+	return ctx, ak, keeper
+}
+
+func fundedAddr(ctx sdk.Context, ak auth.AccountKeeper, amt int64) sdk.AccAddress {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	acc := ak.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(sdk.Coins{sdk.NewInt64Coin(stakingToken, amt)})
+	ak.SetAccount(ctx, acc)
+	return addr
+}
+
+var testDescription = Description{Moniker: "validator"}
+
+func TestKeeperBondUnbond(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	_, err := keeper.Bond(ctx, addr, pubKey, sdk.NewInt64Coin("foocoin", 10))
+	require.NotNil(t, err)
+
+	power, err := keeper.Bond(ctx, addr, pubKey, sdk.NewInt64Coin(stakingToken, 10))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), power)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 90)}, ak.GetAccount(ctx, addr).GetCoins())
+
+	_, power, err = keeper.Unbond(ctx, addr)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), power)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 90)}, ak.GetAccount(ctx, addr).GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 10)}, keeper.GetUnbondingCoins(ctx))
+
+	_, _, err = keeper.Unbond(ctx, addr)
+	require.NotNil(t, err)
+}
+
+func TestUnbondingPeriod(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.UnbondingTime = 10
+	keeper.SetParams(ctx, stakingParams)
+
+	// bond
+	ctx = ctx.WithBlockHeight(1)
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	EndBlocker(ctx, keeper)
+
+	// unbond
+	ctx = ctx.WithBlockHeight(2)
+	require.True(t, handler(ctx, NewMsgUnbond(addr)).IsOK())
+	EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 90)}, ak.GetAccount(ctx, addr).GetCoins())
+
+	bz, err := querier(ctx, []string{QueryUnbonding, addr.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var entries []UnbondingEntry
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &entries))
+	require.Equal(t, []UnbondingEntry{{addr, sdk.NewInt64Coin(stakingToken, 10), 12}}, entries)
+
+	// wait
+	ctx = ctx.WithBlockHeight(11)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 90)}, ak.GetAccount(ctx, addr).GetCoins())
+
+	// release
+	ctx = ctx.WithBlockHeight(12)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 100)}, ak.GetAccount(ctx, addr).GetCoins())
+	require.Empty(t, keeper.GetUnbondings(ctx, addr))
+	require.True(t, keeper.GetUnbondingCoins(ctx).IsZero())
+}
+
+func TestDelegateMulti(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	delegator := fundedAddr(ctx, ak, 100)
+
+	var vals []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		val := fundedAddr(ctx, ak, 10)
+		_, err := keeper.Bond(ctx, val, ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin(stakingToken, 10))
+		require.Nil(t, err)
+		vals = append(vals, val)
+	}
+
+	// duplicate validators are rejected
+	msg := NewMsgDelegateMulti(delegator, []DelegationEntry{
+		{vals[0], sdk.NewInt64Coin(stakingToken, 5)},
+		{vals[0], sdk.NewInt64Coin(stakingToken, 5)},
+	})
+	require.NotNil(t, msg.ValidateBasic())
+
+	// delegate to all three validators
+	msg = NewMsgDelegateMulti(delegator, []DelegationEntry{
+		{vals[0], sdk.NewInt64Coin(stakingToken, 10)},
+		{vals[1], sdk.NewInt64Coin(stakingToken, 20)},
+		{vals[2], sdk.NewInt64Coin(stakingToken, 30)},
+	})
+	require.Nil(t, msg.ValidateBasic())
+	require.True(t, handler(ctx, msg).IsOK())
+
+	for i, val := range vals {
+		stake := int64(10 * (i + 1))
+		validator, found := keeper.GetValidator(ctx, val)
+		require.True(t, found)
+		require.Equal(t, 10+stake, validator.Power)
+		require.Equal(t, stake, keeper.GetDelegation(ctx, val, delegator))
+	}
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 40)}, ak.GetAccount(ctx, delegator).GetCoins())
+
+	// the last entry exceeds the balance, nothing is delegated
+	msg = NewMsgDelegateMulti(delegator, []DelegationEntry{
+		{vals[0], sdk.NewInt64Coin(stakingToken, 10)},
+		{vals[1], sdk.NewInt64Coin(stakingToken, 10)},
+		{vals[2], sdk.NewInt64Coin(stakingToken, 30)},
+	})
+	require.Nil(t, msg.ValidateBasic())
+	require.False(t, handler(ctx, msg).IsOK())
+
+	for i, val := range vals {
+		stake := int64(10 * (i + 1))
+		validator, _ := keeper.GetValidator(ctx, val)
+		require.Equal(t, 10+stake, validator.Power)
+		require.Equal(t, stake, keeper.GetDelegation(ctx, val, delegator))
+	}
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 40)}, ak.GetAccount(ctx, delegator).GetCoins())
+
+	// unbonding the validator returns the delegated stake to the delegator
+	_, _, err := keeper.Unbond(ctx, vals[2])
+	require.Nil(t, err)
+	require.Equal(t, int64(0), keeper.GetDelegation(ctx, vals[2], delegator))
+	require.Equal(t, []UnbondingEntry{{delegator, sdk.NewInt64Coin(stakingToken, 30), keeper.GetParams(ctx).UnbondingTime}},
+		keeper.GetUnbondings(ctx, delegator))
+	require.Equal(t, []UnbondingEntry{{vals[2], sdk.NewInt64Coin(stakingToken, 10), keeper.GetParams(ctx).UnbondingTime}},
+		keeper.GetUnbondings(ctx, vals[2]))
+}
+
+func TestTransferValidatorOwnership(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	val := fundedAddr(ctx, ak, 100)
+	newOwner := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	require.True(t, handler(ctx, NewMsgCreateValidator(val, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	require.Equal(t, val, keeper.GetOwner(ctx, val))
+
+	// only the current owner may transfer
+	res := handler(ctx, NewMsgTransferValidatorOwnership(val, newOwner, newOwner))
+	require.Equal(t, CodeNotValidatorOwner, res.Code)
+	require.True(t, handler(ctx, NewMsgTransferValidatorOwnership(val, nil, newOwner)).IsOK())
+	require.Equal(t, newOwner, keeper.GetOwner(ctx, val))
+	validator, found := keeper.GetValidator(ctx, val)
+	require.True(t, found)
+	require.Equal(t, newOwner, validator.Owner)
+
+	// the old owner can no longer bond or unbond
+	res = handler(ctx, NewMsgBond(val, sdk.NewInt64Coin(stakingToken, 10), pubKey))
+	require.Equal(t, CodeNotValidatorOwner, res.Code)
+	res = handler(ctx, NewMsgUnbond(val))
+	require.Equal(t, CodeNotValidatorOwner, res.Code)
+	res = handler(ctx, NewMsgTransferValidatorOwnership(val, nil, val))
+	require.Equal(t, CodeNotValidatorOwner, res.Code)
+
+	// while the new owner bonds from its own coins and gets the stake back
+	bond := MsgBond{Address: val, Stake: sdk.NewInt64Coin(stakingToken, 5), PubKey: pubKey, Owner: newOwner}
+	require.Equal(t, []sdk.AccAddress{newOwner}, bond.GetSigners())
+	require.True(t, handler(ctx, bond).IsOK())
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 95)}, ak.GetAccount(ctx, newOwner).GetCoins())
+
+	require.True(t, handler(ctx, MsgUnbond{Address: val, Owner: newOwner}).IsOK())
+	require.Equal(t, []UnbondingEntry{}, keeper.GetUnbondings(ctx, val))
+	require.Len(t, keeper.GetUnbondings(ctx, newOwner), 1)
+	require.Equal(t, sdk.NewInt64Coin(stakingToken, 15), keeper.GetUnbondings(ctx, newOwner)[0].Amount)
+}
+
+func TestBondLimits(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.MinSelfBond = 10
+	stakingParams.MaxBond = 50
+	keeper.SetParams(ctx, stakingParams)
+
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	// new validators bond at least the minimum
+	res := handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 9)))
+	require.Equal(t, CodeBondTooSmall, res.Code)
+	require.Equal(t, int64(0), keeper.getBondInfo(ctx, addr).Power)
+	res = handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// later bonds may be smaller
+	res = handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 1), pubKey))
+	require.True(t, res.IsOK(), res.Log)
+
+	// up to the maximum power
+	res = handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 40), pubKey))
+	require.Equal(t, CodeBondTooLarge, res.Code)
+	require.Equal(t, int64(11), keeper.getBondInfo(ctx, addr).Power)
+	res = handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 39), pubKey))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(50), keeper.getBondInfo(ctx, addr).Power)
+
+	// delegations count towards the maximum as well
+	delegator := fundedAddr(ctx, ak, 100)
+	res = handler(ctx, NewMsgDelegateMulti(delegator, []DelegationEntry{{addr, sdk.NewInt64Coin(stakingToken, 1)}}))
+	require.Equal(t, CodeBondTooLarge, res.Code)
+	require.Equal(t, int64(50), keeper.getBondInfo(ctx, addr).Power)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 100)}, ak.GetAccount(ctx, delegator).GetCoins())
+
+	// a zero maximum lifts the limit
+	stakingParams.MaxBond = 0
+	keeper.SetParams(ctx, stakingParams)
+	res = handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 1), pubKey))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(51), keeper.getBondInfo(ctx, addr).Power)
+}
+
+func TestBondOverflow(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.MinSelfBond = 0
+	stakingParams.MaxBond = 0
+	keeper.SetParams(ctx, stakingParams)
+
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	tooLarge := sdk.NewCoin(stakingToken, sdk.NewInt(math.MaxInt64).AddRaw(1))
+	res := handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, tooLarge))
+	require.Equal(t, CodeStakeOverflow, res.Code)
+	res = handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// stakes above an int64 or pushing the power past it are rejected even
+	// without a maximum bond
+	maxStake := sdk.NewInt64Coin(stakingToken, math.MaxInt64)
+	for _, stake := range []sdk.Coin{tooLarge, maxStake} {
+		res = handler(ctx, NewMsgBond(addr, stake, pubKey))
+		require.Equal(t, CodeStakeOverflow, res.Code)
+		_, err := keeper.Bond(ctx, addr, pubKey, stake)
+		require.Equal(t, CodeStakeOverflow, err.Code())
+		_, err = keeper.Delegate(ctx, fundedAddr(ctx, ak, 100), addr, stake)
+		require.Equal(t, CodeStakeOverflow, err.Code())
+	}
+	require.Equal(t, int64(10), keeper.getBondInfo(ctx, addr).Power)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 90)}, ak.GetAccount(ctx, addr).GetCoins())
+}
+
+func TestValidatorByConsAddr(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	consAddr := sdk.ConsAddress(pubKey.Address())
+	require.Nil(t, keeper.CreateValidator(ctx, addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10)))
+
+	val, found := keeper.getValidatorByConsAddr(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, addr, val.Address)
+
+	// a pubkey validates for a single validator
+	other := fundedAddr(ctx, ak, 100)
+	err := keeper.CreateValidator(ctx, other, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10))
+	require.Equal(t, CodeDuplicateValidator, err.Code())
+
+	// the index follows the bond until it unbonds
+	_, err = keeper.Delegate(ctx, other, addr, sdk.NewInt64Coin(stakingToken, 5))
+	require.Nil(t, err)
+	val, found = keeper.getValidatorByConsAddr(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, int64(15), val.Power)
+
+	_, _, err = keeper.Unbond(ctx, addr)
+	require.Nil(t, err)
+	_, found = keeper.getValidatorByConsAddr(ctx, consAddr)
+	require.False(t, found)
+}
+
+func TestRedelegate(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	delegator := fundedAddr(ctx, ak, 100)
+
+	var vals []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		val := fundedAddr(ctx, ak, 10)
+		_, err := keeper.Bond(ctx, val, ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin(stakingToken, 10))
+		require.Nil(t, err)
+		vals = append(vals, val)
+	}
+	_, err := keeper.Delegate(ctx, delegator, vals[0], sdk.NewInt64Coin(stakingToken, 30))
+	require.Nil(t, err)
+	EndBlocker(ctx, keeper)
+
+	// the source and destination must differ
+	msg := NewMsgRedelegate(delegator, vals[0], vals[0], sdk.NewInt64Coin(stakingToken, 10))
+	require.NotNil(t, msg.ValidateBasic())
+
+	// more than the delegation cannot be moved
+	res := handler(ctx, NewMsgRedelegate(delegator, vals[0], vals[1], sdk.NewInt64Coin(stakingToken, 31)))
+	require.Equal(t, DefaultCodespace, res.Codespace)
+	require.Equal(t, CodeInsufficientDelegation, res.Code)
+
+	// the stake moves at once, without an unbonding
+	msg = NewMsgRedelegate(delegator, vals[0], vals[1], sdk.NewInt64Coin(stakingToken, 20))
+	require.Nil(t, msg.ValidateBasic())
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+
+	require.Equal(t, int64(10), keeper.GetDelegation(ctx, vals[0], delegator))
+	require.Equal(t, int64(20), keeper.GetDelegation(ctx, vals[1], delegator))
+	require.Equal(t, int64(20), keeper.getBondInfo(ctx, vals[0]).Power)
+	require.Equal(t, int64(30), keeper.getBondInfo(ctx, vals[1]).Power)
+	require.Equal(t, []UnbondingEntry{}, keeper.GetUnbondings(ctx, delegator))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 70)}, ak.GetAccount(ctx, delegator).GetCoins())
+
+	// both validators are updated in the same block
+	require.Len(t, EndBlocker(ctx, keeper).ValidatorUpdates, 2)
+
+	// the redelegated stake is returned to the delegator when the
+	// destination unbonds
+	_, _, err = keeper.Unbond(ctx, vals[1])
+	require.Nil(t, err)
+	require.Len(t, keeper.GetUnbondings(ctx, delegator), 1)
+	require.Equal(t, sdk.NewInt64Coin(stakingToken, 20), keeper.GetUnbondings(ctx, delegator)[0].Amount)
+}
+
+func TestRedelegateInProgress(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	stakingParams := keeper.GetParams(ctx)
+	stakingParams.UnbondingTime = 10
+	keeper.SetParams(ctx, stakingParams)
+	delegator := fundedAddr(ctx, ak, 100)
+
+	var vals []sdk.AccAddress
+	for i := 0; i < 3; i++ {
+		val := fundedAddr(ctx, ak, 10)
+		_, err := keeper.Bond(ctx, val, ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin(stakingToken, 10))
+		require.Nil(t, err)
+		vals = append(vals, val)
+	}
+	_, err := keeper.Delegate(ctx, delegator, vals[0], sdk.NewInt64Coin(stakingToken, 30))
+	require.Nil(t, err)
+
+	res := handler(ctx, NewMsgRedelegate(delegator, vals[0], vals[1], sdk.NewInt64Coin(stakingToken, 10)))
+	require.True(t, res.IsOK(), res.Log)
+
+	// neither the rest of the stake nor the redelegated stake may move until
+	// the redelegation completes
+	ctx = ctx.WithBlockHeight(9)
+	res = handler(ctx, NewMsgRedelegate(delegator, vals[0], vals[2], sdk.NewInt64Coin(stakingToken, 10)))
+	require.Equal(t, CodeRedelegationInProgress, res.Code)
+	res = handler(ctx, NewMsgRedelegate(delegator, vals[1], vals[2], sdk.NewInt64Coin(stakingToken, 10)))
+	require.Equal(t, CodeRedelegationInProgress, res.Code)
+	require.Equal(t, int64(20), keeper.GetDelegation(ctx, vals[0], delegator))
+	require.Equal(t, int64(10), keeper.GetDelegation(ctx, vals[1], delegator))
+	require.Equal(t, int64(0), keeper.GetDelegation(ctx, vals[2], delegator))
+
+	ctx = ctx.WithBlockHeight(10)
+	res = handler(ctx, NewMsgRedelegate(delegator, vals[1], vals[2], sdk.NewInt64Coin(stakingToken, 10)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(0), keeper.GetDelegation(ctx, vals[1], delegator))
+	require.Equal(t, int64(10), keeper.GetDelegation(ctx, vals[2], delegator))
+}
+
+func TestCreateValidator(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+	desc := Description{Moniker: "frosty", Website: "https://example.com"}
+
+	require.NotNil(t, NewMsgCreateValidator(addr, pubKey, Description{}, sdk.NewInt64Coin(stakingToken, 10)).ValidateBasic())
+	require.NotNil(t, NewMsgCreateValidator(addr, pubKey, desc, sdk.NewInt64Coin(stakingToken, 0)).ValidateBasic())
+
+	// bonding requires an existing validator
+	res := handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 10), pubKey))
+	require.Equal(t, CodeUnknownValidator, res.Code)
+
+	res = handler(ctx, NewMsgCreateValidator(addr, pubKey, desc, sdk.NewInt64Coin(stakingToken, 10)))
+	require.True(t, res.IsOK(), res.Log)
+	validator, found := keeper.GetValidator(ctx, addr)
+	require.True(t, found)
+	require.Equal(t, desc, validator.Description)
+	require.Equal(t, int64(10), validator.Power)
+
+	// a validator is created once
+	res = handler(ctx, NewMsgCreateValidator(addr, pubKey, testDescription, sdk.NewInt64Coin(stakingToken, 10)))
+	require.Equal(t, CodeDuplicateValidator, res.Code)
+	validator, _ = keeper.GetValidator(ctx, addr)
+	require.Equal(t, desc, validator.Description)
+
+	// then bonds add to its power
+	res = handler(ctx, NewMsgBond(addr, sdk.NewInt64Coin(stakingToken, 5), pubKey))
+	require.True(t, res.IsOK(), res.Log)
+	validator, _ = keeper.GetValidator(ctx, addr)
+	require.Equal(t, int64(15), validator.Power)
+	require.Equal(t, desc, validator.Description)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 85)}, ak.GetAccount(ctx, addr).GetCoins())
+}
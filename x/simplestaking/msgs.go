@@ -0,0 +1,417 @@
+package simplestaking
+
+import (
+	"encoding/json"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const moduleName = "simplestaking"
+
+//_________________________________________________________----
+
+// MsgCreateValidator - creates a validator at the operator address with its
+// metadata and initial self bond, signed by the operator
+type MsgCreateValidator struct {
+	Operator        sdk.AccAddress `json:"operator"`
+	PubKey          crypto.PubKey  `json:"pub_key"`
+	Description     Description    `json:"description"`
+	InitialSelfBond sdk.Coin       `json:"initial_self_bond"`
+}
+
+// NewMsgCreateValidator constructs a new MsgCreateValidator
+func NewMsgCreateValidator(operator sdk.AccAddress, pubKey crypto.PubKey, desc Description,
+	selfBond sdk.Coin) MsgCreateValidator {
+	return MsgCreateValidator{
+		Operator:        operator,
+		PubKey:          pubKey,
+		Description:     desc,
+		InitialSelfBond: selfBond,
+	}
+}
+
+// nolint
+func (msg MsgCreateValidator) Route() string                { return moduleName }
+func (msg MsgCreateValidator) Type() string                 { return "create_validator" }
+func (msg MsgCreateValidator) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Operator} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
+	if len(msg.Operator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Operator.String())
+	}
+	if msg.PubKey == nil {
+		return ErrEmptyPubKey(DefaultCodespace, "MsgCreateValidator.PubKey must not be empty")
+	}
+	if err := msg.Description.Validate(); err != nil {
+		return ErrInvalidDescription(DefaultCodespace, err.Error())
+	}
+	if !msg.InitialSelfBond.IsPositive() {
+		return ErrEmptyStake(DefaultCodespace)
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreateValidator) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgBond - adds stake to an existing validator, signed by the owner of the
+// validator
+type MsgBond struct {
+	Address sdk.AccAddress `json:"address"`
+	Stake   sdk.Coin       `json:"coins"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
+
+	// owner of the validator if ownership was transferred
+	Owner sdk.AccAddress `json:"owner,omitempty"`
+}
+
+// NewMsgBond constructs a new MsgBond
+func NewMsgBond(addr sdk.AccAddress, stake sdk.Coin, pubKey crypto.PubKey) MsgBond {
+	return MsgBond{
+		Address: addr,
+		Stake:   stake,
+		PubKey:  pubKey,
+	}
+}
+
+// nolint
+func (msg MsgBond) Route() string                { return moduleName }
+func (msg MsgBond) Type() string                 { return "bond" }
+func (msg MsgBond) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.signer()} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgBond) ValidateBasic() sdk.Error {
+	if msg.Stake.IsZero() {
+		return ErrEmptyStake(DefaultCodespace)
+	}
+
+	if msg.PubKey == nil {
+		return ErrEmptyPubKey(DefaultCodespace, "MsgBond.PubKey must not be empty")
+	}
+
+	return nil
+}
+
+func (msg MsgBond) signer() sdk.AccAddress {
+	return ownerOrValidator(msg.Owner, msg.Address)
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgBond) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgUnbond - simple unbond message, signed by the owner of the validator
+type MsgUnbond struct {
+	Address sdk.AccAddress `json:"address"`
+
+	// owner of the validator if ownership was transferred
+	Owner sdk.AccAddress `json:"owner,omitempty"`
+}
+
+// NewMsgUnbond constructs a new MsgUnbond
+func NewMsgUnbond(addr sdk.AccAddress) MsgUnbond {
+	return MsgUnbond{
+		Address: addr,
+	}
+}
+
+// nolint
+func (msg MsgUnbond) Route() string                { return moduleName }
+func (msg MsgUnbond) Type() string                 { return "unbond" }
+func (msg MsgUnbond) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.signer()} }
+func (msg MsgUnbond) ValidateBasic() sdk.Error     { return nil }
+
+func (msg MsgUnbond) signer() sdk.AccAddress {
+	return ownerOrValidator(msg.Owner, msg.Address)
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUnbond) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgDelegateMulti - delegates stake to several validators at once, either
+// every delegation succeeds or none does
+type MsgDelegateMulti struct {
+	Delegator sdk.AccAddress    `json:"delegator"`
+	Entries   []DelegationEntry `json:"entries"`
+}
+
+// NewMsgDelegateMulti constructs a new MsgDelegateMulti
+func NewMsgDelegateMulti(delegator sdk.AccAddress, entries []DelegationEntry) MsgDelegateMulti {
+	return MsgDelegateMulti{
+		Delegator: delegator,
+		Entries:   entries,
+	}
+}
+
+// nolint
+func (msg MsgDelegateMulti) Route() string                { return moduleName }
+func (msg MsgDelegateMulti) Type() string                 { return "delegate_multi" }
+func (msg MsgDelegateMulti) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Delegator} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgDelegateMulti) ValidateBasic() sdk.Error {
+	if len(msg.Delegator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	if len(msg.Entries) == 0 {
+		return ErrEmptyStake(DefaultCodespace)
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range msg.Entries {
+		if len(entry.Validator) == 0 {
+			return ErrEmptyValidator(DefaultCodespace)
+		}
+		if seen[entry.Validator.String()] {
+			return ErrDuplicateValidator(DefaultCodespace)
+		}
+		seen[entry.Validator.String()] = true
+
+		if !entry.Amount.IsPositive() {
+			return ErrEmptyStake(DefaultCodespace)
+		}
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgDelegateMulti) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgRedelegate - moves delegated stake from one validator to another
+// without the unbonding delay, signed by the delegator
+type MsgRedelegate struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Src       sdk.AccAddress `json:"src"`
+	Dst       sdk.AccAddress `json:"dst"`
+	Amount    sdk.Coin       `json:"amount"`
+}
+
+// NewMsgRedelegate constructs a new MsgRedelegate
+func NewMsgRedelegate(delegator, src, dst sdk.AccAddress, amount sdk.Coin) MsgRedelegate {
+	return MsgRedelegate{
+		Delegator: delegator,
+		Src:       src,
+		Dst:       dst,
+		Amount:    amount,
+	}
+}
+
+// nolint
+func (msg MsgRedelegate) Route() string                { return moduleName }
+func (msg MsgRedelegate) Type() string                 { return "redelegate" }
+func (msg MsgRedelegate) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Delegator} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRedelegate) ValidateBasic() sdk.Error {
+	if len(msg.Delegator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	if len(msg.Src) == 0 || len(msg.Dst) == 0 {
+		return ErrEmptyValidator(DefaultCodespace)
+	}
+	if msg.Src.Equals(msg.Dst) {
+		return ErrSelfRedelegation(DefaultCodespace)
+	}
+	if !msg.Amount.IsPositive() {
+		return ErrEmptyStake(DefaultCodespace)
+	}
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRedelegate) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgUnjail - returns a validator jailed for downtime to the validator set
+// once its jail period has elapsed, signed by the owner of the validator
+type MsgUnjail struct {
+	ValidatorAddr sdk.AccAddress `json:"validator_addr"`
+
+	// owner of the validator if ownership was transferred
+	Owner sdk.AccAddress `json:"owner,omitempty"`
+}
+
+// NewMsgUnjail constructs a new MsgUnjail
+func NewMsgUnjail(valAddr sdk.AccAddress) MsgUnjail {
+	return MsgUnjail{
+		ValidatorAddr: valAddr,
+	}
+}
+
+// nolint
+func (msg MsgUnjail) Route() string                { return moduleName }
+func (msg MsgUnjail) Type() string                 { return "unjail" }
+func (msg MsgUnjail) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.signer()} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgUnjail) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) == 0 {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddr.String())
+	}
+	return nil
+}
+
+func (msg MsgUnjail) signer() sdk.AccAddress {
+	return ownerOrValidator(msg.Owner, msg.ValidatorAddr)
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgUnjail) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+//_______________________________________________________________
+
+// MsgTransferValidatorOwnership - hands control of a validator to a new
+// owner, signed by the current owner
+type MsgTransferValidatorOwnership struct {
+	ValidatorAddr sdk.AccAddress `json:"validator_addr"`
+	NewOwner      sdk.AccAddress `json:"new_owner"`
+
+	// current owner of the validator if ownership was transferred before
+	Owner sdk.AccAddress `json:"owner,omitempty"`
+}
+
+// NewMsgTransferValidatorOwnership constructs a new MsgTransferValidatorOwnership
+func NewMsgTransferValidatorOwnership(valAddr, owner, newOwner sdk.AccAddress) MsgTransferValidatorOwnership {
+	return MsgTransferValidatorOwnership{
+		ValidatorAddr: valAddr,
+		NewOwner:      newOwner,
+		Owner:         owner,
+	}
+}
+
+// nolint
+func (msg MsgTransferValidatorOwnership) Route() string { return moduleName }
+func (msg MsgTransferValidatorOwnership) Type() string  { return "transfer_validator_ownership" }
+func (msg MsgTransferValidatorOwnership) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.signer()}
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgTransferValidatorOwnership) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) == 0 {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddr.String())
+	}
+	if len(msg.NewOwner) == 0 {
+		return sdk.ErrInvalidAddress(msg.NewOwner.String())
+	}
+	return nil
+}
+
+func (msg MsgTransferValidatorOwnership) signer() sdk.AccAddress {
+	return ownerOrValidator(msg.Owner, msg.ValidatorAddr)
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgTransferValidatorOwnership) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// ownerOrValidator returns the owner signing a validator message, the
+// validator address itself if no owner is given
+func ownerOrValidator(owner, valAddr sdk.AccAddress) sdk.AccAddress {
+	if len(owner) == 0 {
+		return valAddr
+	}
+	return owner
+}
+
+//_______________________________________________________________
+
+// MsgEditValidator - replaces the metadata of a validator, signed by the
+// owner of the validator
+type MsgEditValidator struct {
+	ValidatorAddr sdk.AccAddress `json:"validator_addr"`
+	Description   Description    `json:"description"`
+
+	// owner of the validator if ownership was transferred
+	Owner sdk.AccAddress `json:"owner,omitempty"`
+}
+
+// NewMsgEditValidator constructs a new MsgEditValidator
+func NewMsgEditValidator(valAddr sdk.AccAddress, desc Description) MsgEditValidator {
+	return MsgEditValidator{
+		ValidatorAddr: valAddr,
+		Description:   desc,
+	}
+}
+
+// nolint
+func (msg MsgEditValidator) Route() string                { return moduleName }
+func (msg MsgEditValidator) Type() string                 { return "edit_validator" }
+func (msg MsgEditValidator) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.signer()} }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgEditValidator) ValidateBasic() sdk.Error {
+	if len(msg.ValidatorAddr) == 0 {
+		return sdk.ErrInvalidAddress(msg.ValidatorAddr.String())
+	}
+	if err := msg.Description.Validate(); err != nil {
+		return ErrInvalidDescription(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
+func (msg MsgEditValidator) signer() sdk.AccAddress {
+	return ownerOrValidator(msg.Owner, msg.ValidatorAddr)
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgEditValidator) GetSignBytes() []byte {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
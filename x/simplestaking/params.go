@@ -0,0 +1,124 @@
+package simplestaking
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace defines the default simplestaking module parameter subspace
+const DefaultParamspace = "simplestaking"
+
+// Parameter store keys
+var (
+	KeySlashFractionDoubleSign = []byte("SlashFractionDoubleSign")
+	KeyUnbondingTime           = []byte("UnbondingTime")
+	KeySignedBlocksWindow      = []byte("SignedBlocksWindow")
+	KeyMaxMissedBlocks         = []byte("MaxMissedBlocks")
+	KeySlashFractionDowntime   = []byte("SlashFractionDowntime")
+	KeyDowntimeJailDuration    = []byte("DowntimeJailDuration")
+	KeyMaxValidators           = []byte("MaxValidators")
+	KeyMinSelfBond             = []byte("MinSelfBond")
+	KeyMaxBond                 = []byte("MaxBond")
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the parameters for the simplestaking module
+type Params struct {
+	// fraction of the bond burned when a validator double signs
+	SlashFractionDoubleSign sdk.Dec `json:"slash_fraction_double_sign"`
+
+	// number of blocks unbonded coins stay locked before they are
+	// returned to the owner
+	UnbondingTime int64 `json:"unbonding_time"`
+
+	// number of recent blocks in which missed signatures are counted
+	SignedBlocksWindow int64 `json:"signed_blocks_window"`
+
+	// validators missing more blocks than this in the window are
+	// slashed and jailed
+	MaxMissedBlocks int64 `json:"max_missed_blocks"`
+
+	// fraction of the bond burned when a validator is jailed for downtime
+	SlashFractionDowntime sdk.Dec `json:"slash_fraction_downtime"`
+
+	// number of blocks a validator jailed for downtime must wait
+	// before it may unjail
+	DowntimeJailDuration int64 `json:"downtime_jail_duration"`
+
+	// number of validators with the most power that are active, the
+	// others stay bonded but are left out of the validator set. Zero
+	// means no limit.
+	MaxValidators uint16 `json:"max_validators"`
+
+	// minimum stake a new validator must bond
+	MinSelfBond int64 `json:"min_self_bond"`
+
+	// maximum power a validator may reach by bonding. Zero means no
+	// limit.
+	MaxBond int64 `json:"max_bond"`
+}
+
+// ParamKeyTable for simplestaking module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// ParamSetPairs implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign},
+		{KeyUnbondingTime, &p.UnbondingTime},
+		{KeySignedBlocksWindow, &p.SignedBlocksWindow},
+		{KeyMaxMissedBlocks, &p.MaxMissedBlocks},
+		{KeySlashFractionDowntime, &p.SlashFractionDowntime},
+		{KeyDowntimeJailDuration, &p.DowntimeJailDuration},
+		{KeyMaxValidators, &p.MaxValidators},
+		{KeyMinSelfBond, &p.MinSelfBond},
+		{KeyMaxBond, &p.MaxBond},
+	}
+}
+
+// DefaultParams returns a default set of parameters
+func DefaultParams() Params {
+	return Params{
+		SlashFractionDoubleSign: sdk.NewDecWithPrec(5, 2), // 5%
+		UnbondingTime:           100,
+		SignedBlocksWindow:      100,
+		MaxMissedBlocks:         50,
+		SlashFractionDowntime:   sdk.NewDecWithPrec(1, 2), // 1%
+		DowntimeJailDuration:    600,
+		MaxValidators:           100,
+		MinSelfBond:             1,
+		MaxBond:                 0,
+	}
+}
+
+// String returns a human readable representation of the parameters
+func (p Params) String() string {
+	return fmt.Sprintf(`Params:
+  Slash Fraction Double Sign: %s
+  Unbonding Time:             %d
+  Signed Blocks Window:       %d
+  Max Missed Blocks:          %d
+  Slash Fraction Downtime:    %s
+  Downtime Jail Duration:     %d
+  Max Validators:             %d
+  Min Self Bond:              %d
+  Max Bond:                   %d`, p.SlashFractionDoubleSign, p.UnbondingTime,
+		p.SignedBlocksWindow, p.MaxMissedBlocks, p.SlashFractionDowntime, p.DowntimeJailDuration,
+		p.MaxValidators, p.MinSelfBond, p.MaxBond)
+}
+
+// GetParams returns the current simplestaking parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the simplestaking parameters
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,119 @@
+package simplestaking
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the simplestaking Querier
+const (
+	QuerierRoute    = "simplestaking"
+	QueryUnbonding  = "unbonding"
+	QueryValidator  = "validator"
+	QueryValidators = "validators"
+)
+
+// NewQuerier returns a querier for the simplestaking module
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("no simplestaking query endpoint given")
+		}
+		switch path[0] {
+		case QueryUnbonding:
+			return queryUnbonding(ctx, path[1:], k)
+		case QueryValidator:
+			return queryValidator(ctx, path[1:], k)
+		case QueryValidators:
+			return queryValidators(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown simplestaking query endpoint")
+		}
+	}
+}
+
+// queryUnbonding returns the pending unbondings of the bech32 address given
+// as the query path
+func queryUnbonding(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected an account address")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	return marshalResult(k.GetUnbondings(ctx, addr))
+}
+
+// queryValidator returns the validator bonded by the bech32 address given
+// as the query path
+func queryValidator(ctx sdk.Context, path []string, k Keeper) ([]byte, sdk.Error) {
+	if len(path) != 1 {
+		return nil, sdk.ErrUnknownRequest("expected a validator address")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(path[0])
+	if err != nil {
+		return nil, sdk.ErrInvalidAddress(err.Error())
+	}
+
+	val, found := k.GetValidator(ctx, addr)
+	if !found {
+		return nil, ErrUnknownValidator(k.codespace)
+	}
+
+	return marshalResult(QueryValidatorResult{
+		Validator:   val,
+		BondedCoins: sdk.Coins{sdk.NewInt64Coin(stakingToken, val.Power)},
+	})
+}
+
+// queryValidators returns a page of the bonded validators in power order,
+// with the number of bonded validators. The QueryValidatorsParams are given
+// as the query data, the first page of DefaultValidatorsLimit validators is
+// returned without them.
+func queryValidators(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	params := QueryValidatorsParams{Page: 1, Limit: DefaultValidatorsLimit}
+	if len(req.Data) != 0 {
+		if err := codec.Cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("could not parse validators query params", err.Error()))
+		}
+	}
+	if params.Page < 1 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d, pages start at 1", params.Page))
+	}
+	if params.Limit < 1 || params.Limit > MaxValidatorsLimit {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid limit %d, must be between 1 and %d", params.Limit, MaxValidatorsLimit))
+	}
+
+	vals := []Validator{}
+	k.IterateValidators(ctx, func(val Validator) bool {
+		vals = append(vals, val)
+		return false
+	})
+	sortByPower(vals)
+
+	res := QueryValidatorsResult{Validators: []Validator{}, Total: len(vals)}
+	if start := (params.Page - 1) * params.Limit; start < len(vals) {
+		end := start + params.Limit
+		if end > len(vals) {
+			end = len(vals)
+		}
+		res.Validators = vals[start:end]
+	}
+	return marshalResult(res)
+}
+
+func marshalResult(res interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(codec.Cdc, res)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,136 @@
+package simplestaking
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQueryValidator(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	// unknown validators are not found
+	_, err := querier(ctx, []string{QueryValidator, addr.String()}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	require.Equal(t, CodeUnknownValidator, err.Code())
+
+	_, err = keeper.Bond(ctx, addr, pubKey, sdk.NewInt64Coin(stakingToken, 10))
+	require.Nil(t, err)
+
+	bz, err := querier(ctx, []string{QueryValidator, addr.String()}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var res QueryValidatorResult
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+	require.Equal(t, addr, res.Address)
+	require.Equal(t, pubKey, res.PubKey)
+	require.Equal(t, int64(10), res.Power)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakingToken, 10)}, res.BondedCoins)
+
+	// malformed addresses are rejected
+	_, err = querier(ctx, []string{QueryValidator, "foo"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInvalidAddress, err.Code())
+}
+
+func TestQueryValidatorDescription(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	handler := NewHandler(keeper)
+	querier := NewQuerier(keeper)
+	addr := fundedAddr(ctx, ak, 100)
+	pubKey := ed25519.GenPrivKey().PubKey()
+
+	query := func() Description {
+		bz, err := querier(ctx, []string{QueryValidator, addr.String()}, abci.RequestQuery{})
+		require.Nil(t, err)
+		var res QueryValidatorResult
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+		return res.Description
+	}
+
+	desc := Description{Moniker: "frosty", Website: "https://frosty.example", Details: "cold validator"}
+	require.True(t, handler(ctx, NewMsgCreateValidator(addr, pubKey, desc, sdk.NewInt64Coin(stakingToken, 10))).IsOK())
+	require.Equal(t, desc, query())
+
+	// only the owner edits the metadata
+	res := handler(ctx, MsgEditValidator{ValidatorAddr: addr, Description: testDescription, Owner: fundedAddr(ctx, ak, 1)})
+	require.Equal(t, CodeNotValidatorOwner, res.Code)
+	require.Equal(t, desc, query())
+
+	edited := Description{Moniker: "chilly"}
+	require.True(t, handler(ctx, NewMsgEditValidator(addr, edited)).IsOK())
+	require.Equal(t, edited, query())
+
+	// the fields are length checked
+	tooLong := Description{Moniker: "chilly", Details: strings.Repeat("x", MaxDetailsLength+1)}
+	require.NotNil(t, NewMsgEditValidator(addr, tooLong).ValidateBasic())
+	require.NotNil(t, NewMsgEditValidator(addr, Description{}).ValidateBasic())
+	require.Nil(t, NewMsgEditValidator(addr, edited).ValidateBasic())
+
+	// unknown validators can't be edited
+	res = handler(ctx, NewMsgEditValidator(fundedAddr(ctx, ak, 1), edited))
+	require.Equal(t, CodeUnknownValidator, res.Code)
+}
+
+func TestQueryValidators(t *testing.T) {
+	ctx, ak, keeper := createTestInput(t)
+	querier := NewQuerier(keeper)
+
+	// five validators, two of them with the same power
+	for _, power := range []int64{10, 30, 20, 30, 15} {
+		_, err := keeper.Bond(ctx, fundedAddr(ctx, ak, 100), ed25519.GenPrivKey().PubKey(), sdk.NewInt64Coin(stakingToken, power))
+		require.Nil(t, err)
+	}
+
+	query := func(page, limit int) QueryValidatorsResult {
+		bz, err := querier(ctx, []string{QueryValidators}, abci.RequestQuery{
+			Data: codec.Cdc.MustMarshalJSON(QueryValidatorsParams{Page: page, Limit: limit}),
+		})
+		require.Nil(t, err)
+		var res QueryValidatorsResult
+		require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+		return res
+	}
+
+	// paging through the validators returns each of them once, in power order
+	var vals []Validator
+	for page := 1; page <= 3; page++ {
+		res := query(page, 2)
+		require.Equal(t, 5, res.Total)
+		vals = append(vals, res.Validators...)
+	}
+	require.Len(t, vals, 5)
+	for i, power := range []int64{30, 30, 20, 15, 10} {
+		require.Equal(t, power, vals[i].Power)
+	}
+	require.True(t, bytes.Compare(vals[0].Address, vals[1].Address) < 0)
+	require.Len(t, query(3, 2).Validators, 1)
+
+	// past the last page
+	res := query(4, 2)
+	require.Equal(t, 5, res.Total)
+	require.Empty(t, res.Validators)
+
+	// without params the first page is returned
+	bz, err := querier(ctx, []string{QueryValidators}, abci.RequestQuery{})
+	require.Nil(t, err)
+	require.Nil(t, codec.Cdc.UnmarshalJSON(bz, &res))
+	require.Equal(t, vals, res.Validators)
+
+	// the bounds are checked
+	for _, params := range []QueryValidatorsParams{{0, 2}, {-1, 2}, {1, 0}, {1, MaxValidatorsLimit + 1}} {
+		_, err := querier(ctx, []string{QueryValidators}, abci.RequestQuery{Data: codec.Cdc.MustMarshalJSON(params)})
+		require.NotNil(t, err)
+		require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+	}
+}
@@ -0,0 +1,173 @@
+package simplestaking
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Validator is a bonded validator of the simple stake module
+type Validator struct {
+	Address sdk.AccAddress `json:"address"`
+	PubKey  crypto.PubKey  `json:"pub_key"`
+	Power   int64          `json:"power"`
+	Jailed  bool           `json:"jailed"`
+
+	// height from which a jailed validator may unjail
+	JailedUntil int64 `json:"jailed_until,omitempty"`
+
+	// account controlling the validator
+	Owner sdk.AccAddress `json:"owner"`
+
+	Description Description `json:"description"`
+}
+
+// maximum lengths of the fields of a Description
+const (
+	MaxMonikerLength = 70
+	MaxWebsiteLength = 140
+	MaxDetailsLength = 280
+)
+
+// Description is the metadata a validator is created with
+type Description struct {
+	Moniker string `json:"moniker"`
+	Website string `json:"website,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// Validate checks the moniker is set and the fields are not too long
+func (d Description) Validate() error {
+	if len(d.Moniker) == 0 {
+		return fmt.Errorf("moniker must not be empty")
+	}
+	if len(d.Moniker) > MaxMonikerLength {
+		return fmt.Errorf("moniker is longer than %d", MaxMonikerLength)
+	}
+	if len(d.Website) > MaxWebsiteLength {
+		return fmt.Errorf("website is longer than %d", MaxWebsiteLength)
+	}
+	if len(d.Details) > MaxDetailsLength {
+		return fmt.Errorf("details are longer than %d", MaxDetailsLength)
+	}
+	return nil
+}
+
+// QueryValidatorResult is the result of a validator query
+type QueryValidatorResult struct {
+	Validator
+	BondedCoins sdk.Coins `json:"bonded_coins"`
+}
+
+// limits of the number of validators returned by a validators query
+const (
+	DefaultValidatorsLimit = 30
+	MaxValidatorsLimit     = 100
+)
+
+// QueryValidatorsParams selects a page of a validators query. Pages start
+// at 1 and hold Limit validators.
+type QueryValidatorsParams struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
+
+// QueryValidatorsResult is the result of a validators query, a page of the
+// bonded validators and the number of bonded validators
+type QueryValidatorsResult struct {
+	Validators []Validator `json:"validators"`
+	Total      int         `json:"total"`
+}
+
+// UnbondingEntry is an unbonded stake waiting to be returned to its owner
+type UnbondingEntry struct {
+	Address          sdk.AccAddress `json:"address"`
+	Amount           sdk.Coin       `json:"amount"`
+	CompletionHeight int64          `json:"completion_height"`
+}
+
+// DelegationEntry is the stake delegated to a single validator by
+// MsgDelegateMulti
+type DelegationEntry struct {
+	Validator sdk.AccAddress `json:"validator"`
+	Amount    sdk.Coin       `json:"amount"`
+}
+
+// bondInfo is the bond of a validator. Shares are issued to the validator
+// and its delegators for their stake, power is the stake left after
+// slashing.
+type bondInfo struct {
+	PubKey crypto.PubKey
+	Power  int64
+	Shares int64
+
+	// jailed validators are left out of the validator set until unjailed,
+	// which is possible from the JailedUntil height
+	Jailed      bool
+	JailedUntil int64
+
+	// account controlling the validator, the validator address itself
+	// until ownership is transferred
+	Owner sdk.AccAddress
+
+	Description Description
+}
+
+// ownerOf returns the account controlling the validator at addr
+func (bi bondInfo) ownerOf(addr sdk.AccAddress) sdk.AccAddress {
+	if len(bi.Owner) == 0 {
+		return addr
+	}
+	return bi.Owner
+}
+
+// sharesFor returns the shares issued for the given stake
+func (bi bondInfo) sharesFor(stake int64) int64 {
+	if bi.Shares == 0 || bi.Power == 0 {
+		return stake
+	}
+	return sdk.NewDec(stake).MulInt64(bi.Shares).QuoInt64(bi.Power).TruncateInt64()
+}
+
+// fits returns whether the stake can be added to the bond without its power
+// or shares overflowing an int64
+func (bi bondInfo) fits(stake sdk.Coin) bool {
+	if !stake.Amount.IsInt64() {
+		return false
+	}
+	amount := stake.Amount.Int64()
+	if amount > math.MaxInt64-bi.Power {
+		return false
+	}
+	shares := stake.Amount
+	if bi.Shares != 0 && bi.Power != 0 {
+		shares = sdk.NewDec(amount).MulInt64(bi.Shares).QuoInt64(bi.Power).TruncateInt()
+	}
+	return shares.IsInt64() && shares.Int64() <= math.MaxInt64-bi.Shares
+}
+
+// powerOf returns the stake the given shares are worth
+func (bi bondInfo) powerOf(shares int64) int64 {
+	return sdk.NewDec(bi.Power).MulInt64(shares).QuoInt64(bi.Shares).TruncateInt64()
+}
+
+func (bi bondInfo) isEmpty() bool {
+	return bi.PubKey == nil
+}
+
+// signingInfo tracks the blocks missed by a validator in the signed blocks
+// window
+type signingInfo struct {
+	IndexOffset  int64
+	MissedBlocks int64
+}
+
+// lastValidator is a validator as last reported to Tendermint, kept so it
+// can be removed from the validator set once it is no longer active
+type lastValidator struct {
+	PubKey crypto.PubKey
+	Power  int64
+}
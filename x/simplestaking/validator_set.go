@@ -0,0 +1,114 @@
+package simplestaking
+
+import (
+	"bytes"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetActiveValidators returns the validators in the validator set, the
+// MaxValidators unjailed validators with the most power, in power order.
+func (k Keeper) GetActiveValidators(ctx sdk.Context) []Validator {
+	var vals []Validator
+	k.IterateValidators(ctx, func(val Validator) bool {
+		if !val.Jailed && val.Power > 0 {
+			vals = append(vals, val)
+		}
+		return false
+	})
+
+	sortByPower(vals)
+
+	max := int(k.GetParams(ctx).MaxValidators)
+	if max > 0 && len(vals) > max {
+		vals = vals[:max]
+	}
+	return vals
+}
+
+// sortByPower sorts validators by power, descending, then address,
+// ascending. Addresses are unique so the order is strict and every node
+// computes the same validator set.
+func sortByPower(vals []Validator) {
+	sort.Slice(vals, func(i, j int) bool {
+		return powerLess(vals[i], vals[j])
+	})
+}
+
+// powerLess reports whether a comes before b in power order
+func powerLess(a, b Validator) bool {
+	if a.Power != b.Power {
+		return a.Power > b.Power
+	}
+	return bytes.Compare(a.Address, b.Address) < 0
+}
+
+func (k Keeper) setLastValidator(ctx sdk.Context, addr sdk.AccAddress, lv lastValidator) {
+	store := ctx.KVStore(k.key)
+	store.Set(GetLastPowerKey(addr), k.cdc.MustMarshalBinaryLengthPrefixed(lv))
+}
+
+// iterateLastValidators iterates over the validators last reported to
+// Tendermint in address order
+func (k Keeper) iterateLastValidators(ctx sdk.Context, fn func(addr sdk.AccAddress, lv lastValidator) (stop bool)) {
+	store := ctx.KVStore(k.key)
+	iter := sdk.KVStorePrefixIterator(store, LastPowerKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var lv lastValidator
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &lv)
+		if fn(sdk.AccAddress(iter.Key()[len(LastPowerKey):]), lv) {
+			break
+		}
+	}
+}
+
+// updateValidatorSet compares the active validators with the ones last
+// reported to Tendermint and returns the updates between them
+func (k Keeper) updateValidatorSet(ctx sdk.Context) (updates []abci.ValidatorUpdate) {
+	store := ctx.KVStore(k.key)
+
+	// validators that were reported, removed unless still active
+	last := make(map[string]lastValidator)
+	iter := sdk.KVStorePrefixIterator(store, LastPowerKey)
+	for ; iter.Valid(); iter.Next() {
+		var lv lastValidator
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &lv)
+		last[string(iter.Key()[len(LastPowerKey):])] = lv
+	}
+	iter.Close()
+
+	for _, val := range k.GetActiveValidators(ctx) {
+		lv, found := last[string(val.Address)]
+		delete(last, string(val.Address))
+		if found && lv.Power == val.Power && lv.PubKey.Equals(val.PubKey) {
+			continue
+		}
+
+		updates = append(updates, abci.ValidatorUpdate{
+			PubKey: tmtypes.TM2PB.PubKey(val.PubKey),
+			Power:  val.Power,
+		})
+		k.setLastValidator(ctx, val.Address, lastValidator{val.PubKey, val.Power})
+	}
+
+	// map iteration is random, remove in address order to stay deterministic
+	removed := make([]string, 0, len(last))
+	for addr := range last {
+		removed = append(removed, addr)
+	}
+	sort.Strings(removed)
+	for _, addr := range removed {
+		updates = append(updates, abci.ValidatorUpdate{
+			PubKey: tmtypes.TM2PB.PubKey(last[addr].PubKey),
+			Power:  0,
+		})
+		store.Delete(GetLastPowerKey(sdk.AccAddress(addr)))
+	}
+
+	return updates
+}